@@ -29,6 +29,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build/commands"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
 )
 
 func NewCertManagerCtlCommand(ctx context.Context, in io.Reader, out, err io.Writer) *cobra.Command {
@@ -42,6 +43,8 @@ func NewCertManagerCtlCommand(ctx context.Context, in io.Reader, out, err io.Wri
 		},
 	}
 	cmds.SetUsageTemplate(usageTemplate())
+	util.AddOutputWidthFlags(cmds.PersistentFlags())
+	util.AddColorFlags(cmds.PersistentFlags())
 
 	cmds.Flags().AddGoFlagSet(flag.CommandLine)
 	flag.CommandLine.Parse([]string{})
@@ -29,6 +29,7 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/decision"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -44,7 +45,10 @@ var (
 {{.BuildName}} approve my-cr --namespace default
 
 # Approve a CertificateRequest giving a custom reason and message
-{{.BuildName}} approve my-cr --reason "ManualApproval" --reason "Approved by PKI department"
+{{.BuildName}} approve my-cr --reason "ManualApproval" --message "Approved by PKI department"
+
+# Approve the CertificateRequests listed in 'approvals.yaml', each with its own reason and message
+{{.BuildName}} approve --from-file approvals.yaml
 `)))
 )
 
@@ -56,6 +60,9 @@ type Options struct {
 	// Message is the string that will be set on the Message field of the
 	// Approved condition.
 	Message string
+	// FromFile is the path of a YAML file listing the CertificateRequests to
+	// approve, instead of the one given as argument.
+	FromFile string
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -72,9 +79,28 @@ func NewCmdApprove(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	o := newOptions(ioStreams)
 
 	cmd := &cobra.Command{
-		Use:               "approve",
-		Short:             "Approve a CertificateRequest",
-		Long:              `Mark a CertificateRequest as Approved, so it may be signed by a configured Issuer.`,
+		Use:   "approve",
+		Short: "Approve a CertificateRequest",
+		Long: templates.LongDesc(i18n.T(`
+Mark a CertificateRequest as Approved, so it may be signed by a configured Issuer.
+
+CertificateRequests which are already Approved or Denied are not changed, as
+approval and denial are final. The Approved condition is printed on success.
+
+With --from-file, the CertificateRequests listed in the given YAML file are
+approved one by one, e.g. to apply a reviewed and version-controlled list of
+approvals. The file contains a list of entries with the fields name, namespace,
+reason and message:
+
+    - name: my-cr
+      namespace: default
+      reason: ManualApproval
+      message: Approved by PKI department
+
+The namespace, reason and message default to the --namespace, --reason and
+--message flags. The result of each entry is printed, entries which are
+already Approved or Denied are skipped, and the command fails if any entry
+could not be approved.`)),
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -83,10 +109,12 @@ func NewCmdApprove(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		},
 	}
 
-	cmd.Flags().StringVar(&o.Reason, "reason", "KubectlCertManager",
+	cmd.Flags().StringVar(&o.Reason, "reason", "cert-manager.io",
 		"The reason to give as to what approved this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually approved by %q", build.Name()),
 		"The message to give as to why this CertificateRequest was approved.")
+	cmd.Flags().StringVar(&o.FromFile, "from-file", o.FromFile,
+		"Path to a YAML file listing the CertificateRequests to approve, with their namespace, reason and message.")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -95,7 +123,11 @@ func NewCmdApprove(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 
 // Validate validates the provided options
 func (o *Options) Validate(args []string) error {
-	if len(args) < 1 {
+	if len(o.FromFile) > 0 {
+		if len(args) > 0 {
+			return errors.New("the --from-file flag cannot be used in conjunction with the name of a CertificateRequest")
+		}
+	} else if len(args) < 1 {
 		return errors.New("the name of the CertificateRequest to approve has to be provided as an argument")
 	}
 	if len(args) > 1 {
@@ -115,28 +147,41 @@ func (o *Options) Validate(args []string) error {
 
 // Run executes approve command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	cr, err := o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if len(o.FromFile) > 0 {
+		return o.runFromFile(ctx)
+	}
+
+	cr, err := o.approve(ctx, approval{Name: args[0], Namespace: o.Namespace, Reason: o.Reason, Message: o.Message})
 	if err != nil {
 		return err
 	}
+	o.printApproved(cr)
+	return nil
+}
 
-	if apiutil.CertificateRequestIsApproved(cr) {
-		return errors.New("CertificateRequest is already approved")
+// approve sets the Approved condition of the CertificateRequest, returning
+// a *decision.AlreadyDecidedError if it is already Approved or Denied.
+func (o *Options) approve(ctx context.Context, a approval) (*cmapi.CertificateRequest, error) {
+	cr, err := o.CMClient.CertmanagerV1().CertificateRequests(a.Namespace).Get(ctx, a.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
 	}
 
-	if apiutil.CertificateRequestIsDenied(cr) {
-		return errors.New("CertificateRequest is already denied")
+	if err := decision.CheckUndecided(cr); err != nil {
+		return nil, err
 	}
 
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved,
-		cmmeta.ConditionTrue, o.Reason, o.Message)
+		cmmeta.ConditionTrue, a.Reason, a.Message)
 
-	_, err = o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
-	if err != nil {
-		return err
-	}
+	return o.CMClient.CertmanagerV1().CertificateRequests(a.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
+}
 
+// printApproved prints the Approved condition of the approved
+// CertificateRequest.
+func (o *Options) printApproved(cr *cmapi.CertificateRequest) {
 	fmt.Fprintf(o.Out, "Approved CertificateRequest '%s/%s'\n", cr.Namespace, cr.Name)
-
-	return nil
+	if cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved); cond != nil {
+		fmt.Fprintf(o.Out, "  %s: %s, Reason: %s, Message: %s\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
 }
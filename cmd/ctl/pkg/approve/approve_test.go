@@ -17,13 +17,25 @@ limitations under the License.
 package approve
 
 import (
+	"bytes"
+	"context"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestValidate(t *testing.T) {
 	tests := map[string]struct {
 		args            []string
 		reason, message string
+		fromFile        string
 		expErr          bool
 		expErrMsg       string
 	}{
@@ -55,6 +67,20 @@ func TestValidate(t *testing.T) {
 			expErr:    true,
 			expErrMsg: "a message must be given as to why this CertificateRequest is approved",
 		},
+		"CR name passed in conjunction with --from-file throws error": {
+			args:      []string{"cr-1"},
+			reason:    "foo",
+			message:   "bar",
+			fromFile:  "approvals.yaml",
+			expErr:    true,
+			expErrMsg: "the --from-file flag cannot be used in conjunction with the name of a CertificateRequest",
+		},
+		"--from-file without CR name should not error": {
+			reason:   "foo",
+			message:  "bar",
+			fromFile: "approvals.yaml",
+			expErr:   false,
+		},
 		"all fields populated should not error": {
 			args:    []string{"cr-1"},
 			reason:  "foo",
@@ -66,8 +92,9 @@ func TestValidate(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			opts := &Options{
-				Reason:  test.reason,
-				Message: test.message,
+				Reason:   test.reason,
+				Message:  test.message,
+				FromFile: test.fromFile,
 			}
 
 			// Validating args and flags
@@ -82,3 +109,65 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		condition *cmapi.CertificateRequestCondition
+		expOutput string
+		expErrMsg string
+	}{
+		"pending CertificateRequest is approved and the condition printed": {
+			expOutput: `Approved CertificateRequest 'default/cr-1'
+  Approved: True, Reason: cert-manager.io, Message: manually approved by "cmctl"
+`,
+		},
+		"approved CertificateRequest throws error": {
+			condition: &cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "approved by policy"},
+			expErrMsg: "CertificateRequest 'default/cr-1' is already approved: Reason: policy, Message: approved by policy",
+		},
+		"denied CertificateRequest throws error": {
+			condition: &cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "denied by policy"},
+			expErrMsg: "CertificateRequest 'default/cr-1' is already denied: Reason: policy, Message: denied by policy",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default"))
+			if test.condition != nil {
+				cr.Status.Conditions = []cmapi.CertificateRequestCondition{*test.condition}
+			}
+			cmClient := cmfake.NewSimpleClientset(cr)
+
+			var out bytes.Buffer
+			opts := &Options{
+				Reason:    "cert-manager.io",
+				Message:   `manually approved by "cmctl"`,
+				IOStreams: genericclioptions.IOStreams{Out: &out, ErrOut: &out},
+				Factory:   &factory.Factory{CMClient: cmClient, Namespace: "default"},
+			}
+
+			err := opts.Run(context.TODO(), []string{"cr-1"})
+			switch {
+			case test.expErrMsg == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.expErrMsg != "":
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("expected error %q, got %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+
+			got, err := cmClient.CertmanagerV1().CertificateRequests("default").Get(context.TODO(), "cr-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Type != cmapi.CertificateRequestConditionApproved {
+				t.Errorf("expected the Approved condition to be set, got %v", got.Status.Conditions)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/decision"
+)
+
+// approval is an entry of the file given by --from-file.
+type approval struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// readApprovals reads the entries of the file at path, defaulting their
+// namespace, reason and message to the ones of the options.
+func (o *Options) readApprovals(path string) ([]approval, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %q: %w", path, err)
+	}
+	var approvals []approval
+	if err := yaml.UnmarshalStrict(data, &approvals); err != nil {
+		return nil, fmt.Errorf("error when parsing %q: %w", path, err)
+	}
+	if len(approvals) == 0 {
+		return nil, fmt.Errorf("no CertificateRequests are listed in %q", path)
+	}
+	for i := range approvals {
+		if len(approvals[i].Name) == 0 {
+			return nil, fmt.Errorf("entry %d of %q has no name", i+1, path)
+		}
+		if len(approvals[i].Namespace) == 0 {
+			approvals[i].Namespace = o.Namespace
+		}
+		if len(approvals[i].Reason) == 0 {
+			approvals[i].Reason = o.Reason
+		}
+		if len(approvals[i].Message) == 0 {
+			approvals[i].Message = o.Message
+		}
+	}
+	return approvals, nil
+}
+
+// runFromFile approves the CertificateRequests listed in FromFile one by
+// one, printing the result of each. CertificateRequests which are already
+// Approved or Denied are skipped, other errors are reported and the
+// remaining entries still approved.
+func (o *Options) runFromFile(ctx context.Context) error {
+	approvals, err := o.readApprovals(o.FromFile)
+	if err != nil {
+		return err
+	}
+
+	var approved, skipped, failed int
+	for _, a := range approvals {
+		cr, err := o.approve(ctx, a)
+		var decided *decision.AlreadyDecidedError
+		switch {
+		case errors.As(err, &decided):
+			skipped++
+			fmt.Fprintf(o.Out, "Skipped %s\n", decided)
+		case err != nil:
+			failed++
+			fmt.Fprintf(o.ErrOut, "error: failed to approve CertificateRequest '%s/%s': %v\n", a.Namespace, a.Name, err)
+		default:
+			approved++
+			o.printApproved(cr)
+		}
+	}
+
+	fmt.Fprintf(o.Out, "%d approved, %d skipped, %d failed\n", approved, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to approve %d of %d CertificateRequests listed in %q", failed, len(approvals), o.FromFile)
+	}
+	return nil
+}
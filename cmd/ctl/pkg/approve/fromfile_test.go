@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approve
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRunFromFile(t *testing.T) {
+	denied := gen.CertificateRequest("cr-denied",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "denied by policy",
+		}),
+	)
+
+	tests := map[string]struct {
+		file string
+
+		expOutput   string
+		expErrOut   string
+		expErrMsg   string
+		expApproved map[string]string
+	}{
+		"entries are approved with their reason and message, defaulting to the flags": {
+			file: `
+- name: cr-1
+  reason: Reviewed
+  message: approved in PR 42
+- name: cr-2
+  namespace: other
+`,
+			expOutput: `Approved CertificateRequest 'default/cr-1'
+  Approved: True, Reason: Reviewed, Message: approved in PR 42
+Approved CertificateRequest 'other/cr-2'
+  Approved: True, Reason: cert-manager.io, Message: manually approved by "cmctl"
+2 approved, 0 skipped, 0 failed
+`,
+			expApproved: map[string]string{"default/cr-1": "Reviewed", "other/cr-2": "cert-manager.io"},
+		},
+		"decided entries are skipped and missing ones reported": {
+			file: `
+- name: cr-denied
+- name: cr-missing
+- name: cr-1
+`,
+			expOutput: `Skipped CertificateRequest 'default/cr-denied' is already denied: Reason: policy, Message: denied by policy
+Approved CertificateRequest 'default/cr-1'
+  Approved: True, Reason: cert-manager.io, Message: manually approved by "cmctl"
+1 approved, 1 skipped, 1 failed
+`,
+			expErrOut: `error: failed to approve CertificateRequest 'default/cr-missing': certificaterequests.cert-manager.io "cr-missing" not found
+`,
+			expErrMsg:   `failed to approve 1 of 3 CertificateRequests listed in "approvals.yaml"`,
+			expApproved: map[string]string{"default/cr-1": "cert-manager.io"},
+		},
+		"entry without name throws error": {
+			file: `
+- name: cr-1
+- reason: Reviewed
+`,
+			expErrMsg: `entry 2 of "approvals.yaml" has no name`,
+		},
+		"unknown field throws error": {
+			file: `
+- name: cr-1
+  reasons: Reviewed
+`,
+			expErrMsg: `error when parsing "approvals.yaml": error unmarshaling JSON: while decoding JSON: json: unknown field "reasons"`,
+		},
+		"empty file throws error": {
+			file:      "",
+			expErrMsg: `no CertificateRequests are listed in "approvals.yaml"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "approvals.yaml"), []byte(test.file), 0600); err != nil {
+				t.Fatal(err)
+			}
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(wd)
+
+			cmClient := cmfake.NewSimpleClientset(
+				gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default")),
+				gen.CertificateRequest("cr-2", gen.SetCertificateRequestNamespace("other")),
+				denied,
+			)
+
+			var out, errOut bytes.Buffer
+			opts := &Options{
+				Reason:    "cert-manager.io",
+				Message:   `manually approved by "cmctl"`,
+				FromFile:  "approvals.yaml",
+				IOStreams: genericclioptions.IOStreams{Out: &out, ErrOut: &errOut},
+				Factory:   &factory.Factory{CMClient: cmClient, Namespace: "default"},
+			}
+
+			err = opts.Run(context.TODO(), nil)
+			if test.expErrMsg == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if test.expErrMsg != "" && (err == nil || err.Error() != test.expErrMsg) {
+				t.Fatalf("expected error %q, got %v", test.expErrMsg, err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+			if errOut.String() != test.expErrOut {
+				t.Errorf("unexpected error output; expected: \n%s\nactual: \n%s", test.expErrOut, errOut.String())
+			}
+
+			for key, reason := range test.expApproved {
+				namespace, name, _ := strings.Cut(key, "/")
+				got, err := cmClient.CertmanagerV1().CertificateRequests(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Type != cmapi.CertificateRequestConditionApproved || got.Status.Conditions[0].Reason != reason {
+					t.Errorf("expected %s to be approved with reason %q, got %v", key, reason, got.Status.Conditions)
+				}
+			}
+		})
+	}
+}
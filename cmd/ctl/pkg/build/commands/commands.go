@@ -28,8 +28,10 @@ import (
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/completion"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/convert"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/delete"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/deny"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/experimental"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/explain"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/renew"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status"
@@ -51,12 +53,14 @@ func Commands() []RegisterCommandFunc {
 		version.NewCmdVersion,
 		convert.NewCmdConvert,
 		create.NewCmdCreate,
+		delete.NewCmdDelete,
 		renew.NewCmdRenew,
 		status.NewCmdStatus,
 		inspect.NewCmdInspect,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
+		explain.NewCmdExplain,
 		upgrade.NewCmdUpgrade,
 
 		// Experimental features
@@ -20,13 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/kubectl/pkg/describe"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -51,6 +54,13 @@ type Options struct {
 	// Print details regarding encountered errors
 	Verbose bool
 
+	// Group versions which have to be served by the API server, e.g.
+	// cert-manager.io/v1
+	ExpectVersions []string
+
+	// expectVersions are the parsed ExpectVersions
+	expectVersions []schema.GroupVersion
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -60,7 +70,18 @@ This check attempts to perform a dry-run create of a cert-manager *v1alpha2*
 Certificate resource in order to verify that CRDs are installed and all the
 required webhooks are reachable by the K8S API server.
 We use v1alpha2 API to ensure that the API server has also connected to the
-cert-manager conversion webhook.`))
+cert-manager conversion webhook.
+
+With --expect-version, the check additionally requires the API server to serve
+the given group version, e.g. cert-manager.io/v1, and lists the served versions
+of the group otherwise. In conjunction with --wait, the check is repeated until
+the version is served.
+
+Every check is reported on stderr, listing the attempt, the time elapsed and why
+the API is not ready yet. If the API is not ready before --wait elapses, the
+last error is printed and the command exits with a non-zero exit code, so that
+it can be used to block until the webhook is serving, e.g. right after
+installing cert-manager.`))
 
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
@@ -73,6 +94,15 @@ func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 func (o *Options) Complete() error {
 	var err error
 
+	o.expectVersions = nil
+	for _, expectVersion := range o.ExpectVersions {
+		gv, err := schema.ParseGroupVersion(expectVersion)
+		if err != nil || gv.Group == "" || gv.Version == "" {
+			return fmt.Errorf("invalid --expect-version %q: must be of the form <group>/<version>, e.g. cert-manager.io/v1", expectVersion)
+		}
+		o.expectVersions = append(o.expectVersions, gv)
+	}
+
 	// We pass the scheme that is used in the RESTConfig's NegotiatedSerializer,
 	// this makes sure that the cmapi is also added to NegotiatedSerializer's scheme
 	// see: https://github.com/cert-manager/cert-manager/pull/4205#discussion_r668660271
@@ -105,6 +135,7 @@ func NewCmdCheckApi(ctx context.Context, ioStreams genericclioptions.IOStreams)
 	cmd.Flags().DurationVar(&o.Wait, "wait", 0, "Wait until the cert-manager API is ready (default 0s = poll once)")
 	cmd.Flags().DurationVar(&o.Interval, "interval", 5*time.Second, "Time between checks when waiting, must include unit, e.g. 1m or 10m")
 	cmd.Flags().BoolVarP(&o.Verbose, "verbose", "v", false, "Print detailed error messages")
+	cmd.Flags().StringSliceVar(&o.ExpectVersions, "expect-version", nil, "Require the API server to serve the given group version, e.g. cert-manager.io/v1. May be repeated or comma separated")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -113,21 +144,41 @@ func NewCmdCheckApi(ctx context.Context, ioStreams genericclioptions.IOStreams)
 
 // Run executes check api command
 func (o *Options) Run(ctx context.Context) {
-	if !o.Verbose {
-		log.SetFlags(0) // Disable prefixing logs with timestamps.
+	if err := o.waitReady(ctx); err != nil {
+		cmcmdutil.SetExitCode(err)
+
+		runtime.Goexit() // Do soft exit (handle all defers, that should set correct exit code)
 	}
-	log.SetOutput(o.ErrOut) // Log all intermediate errors to stderr
+}
+
+// waitReady checks whether the cert-manager API is ready every --interval
+// until it is, or until --wait elapses. Every failed check is reported on
+// stderr. The conclusion is printed to stdout, and if the API did not become
+// ready, the last error of the checks is returned.
+func (o *Options) waitReady(ctx context.Context) error {
+	progress := describe.NewPrefixWriter(o.ErrOut)
+	out := describe.NewPrefixWriter(o.Out)
 
 	start := time.Now()
-	pollErr := wait.PollUntilContextCancel(ctx, o.Interval, false, func(ctx context.Context) (bool, error) {
-		if err := o.APIChecker.Check(ctx); err != nil {
+	attempt := 0
+	var lastErr error
+	pollErr := wait.PollUntilContextCancel(ctx, o.Interval, true, func(ctx context.Context) (bool, error) {
+		attempt++
+		err := o.APIChecker.Check(ctx)
+		if err == nil {
+			err = o.checkExpectedVersions(attempt > 1)
+		}
+		if err != nil {
 			if !o.Verbose && errors.Unwrap(err) != nil {
 				err = errors.Unwrap(err)
 			}
+			lastErr = err
 
-			log.Printf("Not ready: %v", err)
+			elapsed := time.Since(start)
+			progress.Write(describe.LEVEL_0, "Attempt %d (%s elapsed):\n", attempt, elapsed.Round(time.Second))
+			progress.Write(describe.LEVEL_1, "Not ready: %v\n", err)
 
-			if time.Since(start) > o.Wait {
+			if elapsed >= o.Wait {
 				return false, context.DeadlineExceeded
 			}
 			return false, nil
@@ -136,17 +187,68 @@ func (o *Options) Run(ctx context.Context) {
 		return true, nil
 	})
 
-	log.SetOutput(o.Out) // Log conclusion to stdout
-
 	if pollErr != nil {
+		if lastErr == nil {
+			// Cancelled before the first check
+			return pollErr
+		}
 		if errors.Is(pollErr, context.DeadlineExceeded) && o.Wait > 0 {
-			log.Printf("Timed out after %s", o.Wait)
+			out.Write(describe.LEVEL_0, "Timed out after %s, the last error was:\n", o.Wait)
+		} else {
+			out.Write(describe.LEVEL_0, "The cert-manager API is not ready:\n")
 		}
+		out.Write(describe.LEVEL_1, "%v\n", lastErr)
+		return lastErr
+	}
+
+	out.Write(describe.LEVEL_0, "The cert-manager API is ready\n")
+	return nil
+}
 
-		cmcmdutil.SetExitCode(pollErr)
+// checkExpectedVersions returns an error listing the served versions of the
+// group if any of the expected group versions is not served. If refresh is
+// true, the cached discovery information is invalidated first.
+func (o *Options) checkExpectedVersions(refresh bool) error {
+	if len(o.expectVersions) == 0 {
+		return nil
+	}
 
-		runtime.Goexit() // Do soft exit (handle all defers, that should set correct exit code)
+	client, err := o.Discovery()
+	if err != nil {
+		return fmt.Errorf("error when discovering the served API versions: %w", err)
+	}
+	// The served versions may change while waiting, so they are only read
+	// from the cache on the first attempt
+	if cached, ok := client.(discovery.CachedDiscoveryInterface); ok && refresh {
+		cached.Invalidate()
+	}
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return fmt.Errorf("error when discovering the served API versions: %w", err)
 	}
 
-	log.Printf("The cert-manager API is ready")
+	for _, expected := range o.expectVersions {
+		var served []string
+		found := false
+		for _, group := range groups.Groups {
+			if group.Name != expected.Group {
+				continue
+			}
+			for _, version := range group.Versions {
+				if version.Version == expected.Version {
+					found = true
+				}
+				served = append(served, version.GroupVersion)
+			}
+		}
+
+		if found {
+			continue
+		}
+		if len(served) == 0 {
+			return fmt.Errorf("the API group %q is not served", expected.Group)
+		}
+		return fmt.Errorf("the version %q is not served, served versions are: %s", expected, strings.Join(served, ", "))
+	}
+	return nil
 }
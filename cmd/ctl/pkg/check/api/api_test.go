@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+)
+
+func TestCompleteExpectVersion(t *testing.T) {
+	for _, expectVersion := range []string{"v1", "cert-manager.io/", "cert-manager.io/v1/extra"} {
+		t.Run(expectVersion, func(t *testing.T) {
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.ExpectVersions = []string{expectVersion}
+
+			expErrMsg := `invalid --expect-version "` + expectVersion + `": must be of the form <group>/<version>, e.g. cert-manager.io/v1`
+			if err := opts.Complete(); err == nil || err.Error() != expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestCheckExpectedVersions(t *testing.T) {
+	tests := map[string]struct {
+		servedGroupVersions []string
+		expectVersions      []schema.GroupVersion
+
+		expErrMsg string
+	}{
+		"no expected versions": {
+			servedGroupVersions: []string{"cert-manager.io/v1"},
+		},
+		"served expected versions are accepted": {
+			servedGroupVersions: []string{"cert-manager.io/v1", "acme.cert-manager.io/v1"},
+			expectVersions:      []schema.GroupVersion{{Group: "cert-manager.io", Version: "v1"}, {Group: "acme.cert-manager.io", Version: "v1"}},
+		},
+		"expected version which is not served lists the served versions": {
+			servedGroupVersions: []string{"cert-manager.io/v1", "cert-manager.io/v1beta1", "acme.cert-manager.io/v1"},
+			expectVersions:      []schema.GroupVersion{{Group: "cert-manager.io", Version: "v2"}},
+			expErrMsg:           `the version "cert-manager.io/v2" is not served, served versions are: cert-manager.io/v1, cert-manager.io/v1beta1`,
+		},
+		"expected group which is not served": {
+			servedGroupVersions: []string{"apps/v1"},
+			expectVersions:      []schema.GroupVersion{{Group: "cert-manager.io", Version: "v1"}},
+			expErrMsg:           `the API group "cert-manager.io" is not served`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			for _, gv := range test.servedGroupVersions {
+				kubeClient.Resources = append(kubeClient.Resources, &metav1.APIResourceList{GroupVersion: gv})
+			}
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.Factory = &factory.Factory{KubeClient: kubeClient}
+			opts.expectVersions = test.expectVersions
+
+			err := opts.checkExpectedVersions(false)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+// fakeCachedDiscovery counts how often its cache is invalidated.
+type fakeCachedDiscovery struct {
+	discovery.DiscoveryInterface
+	invalidated int
+}
+
+func (d *fakeCachedDiscovery) Fresh() bool { return true }
+func (d *fakeCachedDiscovery) Invalidate() { d.invalidated++ }
+
+func TestCheckExpectedVersionsRefresh(t *testing.T) {
+	for _, refresh := range []bool{false, true} {
+		t.Run(fmt.Sprintf("refresh=%t", refresh), func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			kubeClient.Resources = []*metav1.APIResourceList{{GroupVersion: "cert-manager.io/v1"}}
+			client := &fakeCachedDiscovery{DiscoveryInterface: kubeClient.Discovery()}
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.Factory = &factory.Factory{KubeClient: kubeClient, DiscoveryClient: client}
+			opts.expectVersions = []schema.GroupVersion{{Group: "cert-manager.io", Version: "v1"}}
+
+			if err := opts.checkExpectedVersions(refresh); err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			expInvalidated := 0
+			if refresh {
+				expInvalidated = 1
+			}
+			if client.invalidated != expInvalidated {
+				t.Errorf("got unexpected number of invalidations, expected: %d; actual: %d", expInvalidated, client.invalidated)
+			}
+		})
+	}
+}
+
+// fakeAPIChecker fails with the given errors in turn, then succeeds.
+type fakeAPIChecker struct {
+	errs []error
+}
+
+func (c *fakeAPIChecker) Check(context.Context) error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	return err
+}
+
+func TestWaitReady(t *testing.T) {
+	webhookErr := fmt.Errorf("error when creating the Certificate: %w", errors.New("the cert-manager webhook is not reachable"))
+	crdErr := fmt.Errorf("error when creating the Certificate: %w", errors.New("the cert-manager CRDs are not installed"))
+
+	tests := map[string]struct {
+		errs    []error
+		wait    time.Duration
+		verbose bool
+
+		expErrMsg string
+		expOut    string
+		expErrOut string
+	}{
+		"ready on the first check": {
+			expOut: "The cert-manager API is ready\n",
+		},
+		"ready after failing checks": {
+			errs:      []error{crdErr, webhookErr},
+			wait:      time.Minute,
+			expOut:    "The cert-manager API is ready\n",
+			expErrOut: "Attempt 1 (0s elapsed):\n  Not ready: the cert-manager CRDs are not installed\nAttempt 2 (0s elapsed):\n  Not ready: the cert-manager webhook is not reachable\n",
+		},
+		"not ready when polling once": {
+			errs:      []error{webhookErr},
+			expErrMsg: "the cert-manager webhook is not reachable",
+			expOut:    "The cert-manager API is not ready:\n  the cert-manager webhook is not reachable\n",
+			expErrOut: "Attempt 1 (0s elapsed):\n  Not ready: the cert-manager webhook is not reachable\n",
+		},
+		"timeout prints the last error": {
+			errs:      []error{crdErr, webhookErr},
+			wait:      time.Nanosecond,
+			verbose:   true,
+			expErrMsg: "error when creating the Certificate: the cert-manager CRDs are not installed",
+			expOut:    "Timed out after 1ns, the last error was:\n  error when creating the Certificate: the cert-manager CRDs are not installed\n",
+			expErrOut: "Attempt 1 (0s elapsed):\n  Not ready: error when creating the Certificate: the cert-manager CRDs are not installed\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, outBuf, errOutBuf := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.APIChecker = &fakeAPIChecker{errs: test.errs}
+			opts.Wait = test.wait
+			opts.Interval = time.Millisecond
+			opts.Verbose = test.verbose
+
+			err := opts.waitReady(context.Background())
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+			if outBuf.String() != test.expOut {
+				t.Errorf("got unexpected output, expected: %q; actual: %q", test.expOut, outBuf.String())
+			}
+			if errOutBuf.String() != test.expErrOut {
+				t.Errorf("got unexpected error output, expected: %q; actual: %q", test.expErrOut, errOutBuf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/secret"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Check that none of the Secrets managed by cert-manager expire soon.
+
+Every Secret in the namespace, or in all namespaces with --all-namespaces, which
+is annotated with the name of the Certificate it belongs to is checked. If the
+certificate stored in any of them expires within the duration given by --within,
+or cannot be parsed, the offending Secrets are listed and the command exits with
+a non-zero exit code. Secrets which do not contain a certificate yet are ignored.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Fail if any certificate managed by cert-manager in namespace 'my-namespace' expires within 7 days
+{{.BuildName}} check expiry --within 168h --namespace my-namespace
+
+# Fail if any certificate managed by cert-manager in the cluster expires within 30 days
+{{.BuildName}} check expiry --within 720h --all-namespaces
+`)))
+)
+
+// Options is a struct to support check expiry command
+type Options struct {
+	// Secrets whose certificate expires within this duration are reported
+	Within time.Duration
+	// If true, check the Secrets of all namespaces
+	AllNamespaces bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckExpiry returns a cobra command for check expiry
+func NewCmdCheckExpiry(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "expiry",
+		Short:   "Check that no certificate managed by cert-manager expires soon",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.Within, "within", o.Within,
+		"Report the Secrets whose certificate expires within this duration, must include unit, e.g. 168h")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces,
+		"If present, check the Secrets across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("the check expiry command does not accept arguments")
+	}
+	if o.Within <= 0 {
+		return errors.New("the --within flag must be set to a positive duration")
+	}
+	return nil
+}
+
+// Run executes check expiry command
+func (o *Options) Run(ctx context.Context) error {
+	ns := o.Namespace
+	if o.AllNamespaces {
+		ns = metav1.NamespaceAll
+	}
+
+	secretsList, err := o.KubeClient.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error when listing Secrets: %w", err)
+	}
+
+	headers, rows := o.expiringTable(secretsList.Items)
+	if len(rows) == 0 {
+		fmt.Fprintf(o.Out, "No certificates managed by cert-manager expire within %s\n", o.Within)
+		return nil
+	}
+
+	if err := util.WriteTable(o.Out, headers, rows); err != nil {
+		return err
+	}
+	return fmt.Errorf("%d Secret(s) contain a certificate which expires within %s", len(rows), o.Within)
+}
+
+// expiringTable returns the headers and rows of the table listing the Secrets
+// managed by cert-manager whose certificate expires within o.Within, or cannot
+// be parsed. The rows are sorted by namespace and name.
+func (o *Options) expiringTable(secrets []corev1.Secret) ([]string, [][]string) {
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Namespace != secrets[j].Namespace {
+			return secrets[i].Namespace < secrets[j].Namespace
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	headers := []string{"NAME", "CERTIFICATE", "NOT AFTER", "EXPIRES IN"}
+	if o.AllNamespaces {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+
+	now := clock.Now()
+	var rows [][]string
+	for _, s := range secrets {
+		crtName, ok := s.Annotations[cmapi.CertificateNameKey]
+		if !ok || len(s.Data[corev1.TLSCertKey]) == 0 {
+			continue
+		}
+
+		notAfter, expiresIn := "<invalid>", "<unknown>"
+		cert, err := secret.LeafCertificate(s.Data[corev1.TLSCertKey])
+		if err == nil {
+			remaining := cert.NotAfter.Sub(now)
+			if remaining > o.Within {
+				continue
+			}
+			notAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+			expiresIn = "expired"
+			if remaining > 0 {
+				expiresIn = duration.HumanDuration(remaining)
+			}
+		}
+
+		row := []string{s.Name, crtName, notAfter, expiresIn}
+		if o.AllNamespaces {
+			row = append([]string{s.Namespace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		opts      *Options
+		inputArgs []string
+		expErrMsg string
+	}{
+		"arguments throw error": {
+			opts:      &Options{Within: time.Hour},
+			inputArgs: []string{"secret-1"},
+			expErrMsg: "the check expiry command does not accept arguments",
+		},
+		"missing --within throws error": {
+			opts:      &Options{},
+			expErrMsg: "the --within flag must be set to a positive duration",
+		},
+		"--within is valid": {
+			opts: &Options{Within: time.Hour},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.opts.Validate(test.inputArgs)
+			if test.expErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErrMsg)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	realClock := clock
+	clock = fakeclock.NewFakeClock(now)
+	t.Cleanup(func() { clock = realClock })
+
+	newSecret := func(ns, name, crtName string, notAfter time.Time) *corev1.Secret {
+		key, err := pki.GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template, err := pki.GenerateTemplate(gen.Certificate(name,
+			gen.SetCertificateCommonName("example.com"),
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+		template.NotBefore = notAfter.Add(-90 * 24 * time.Hour)
+		template.NotAfter = notAfter
+		certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+		}
+		if crtName != "" {
+			s.Annotations = map[string]string{cmapi.CertificateNameKey: crtName}
+		}
+		return s
+	}
+
+	objects := []runtime.Object{
+		newSecret("ns-1", "expiring", "expiring-crt", now.Add(48*time.Hour)),
+		newSecret("ns-1", "expired", "expired-crt", now.Add(-time.Hour)),
+		newSecret("ns-1", "valid", "valid-crt", now.Add(60*24*time.Hour)),
+		newSecret("ns-1", "unmanaged", "", now.Add(time.Hour)),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "ns-1",
+				Annotations: map[string]string{cmapi.CertificateNameKey: "pending-crt"}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "ns-2",
+				Annotations: map[string]string{cmapi.CertificateNameKey: "invalid-crt"}},
+			Data: map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")},
+		},
+		newSecret("ns-2", "expiring", "expiring-crt", now.Add(time.Hour)),
+	}
+
+	tests := map[string]struct {
+		namespace     string
+		allNamespaces bool
+		within        time.Duration
+
+		expOutput string
+		expErrMsg string
+	}{
+		"expiring and expired Secrets of the namespace are reported": {
+			namespace: "ns-1",
+			within:    7 * 24 * time.Hour,
+			expOutput: `NAME      CERTIFICATE   NOT AFTER             EXPIRES IN
+expired   expired-crt   2022-02-28T23:00:00Z  expired
+expiring  expiring-crt  2022-03-03T00:00:00Z  2d
+`,
+			expErrMsg: "2 Secret(s) contain a certificate which expires within 168h0m0s",
+		},
+		"Secrets of all namespaces are reported with their namespace": {
+			allNamespaces: true,
+			within:        24 * time.Hour,
+			expOutput: `NAMESPACE  NAME      CERTIFICATE   NOT AFTER             EXPIRES IN
+ns-1       expired   expired-crt   2022-02-28T23:00:00Z  expired
+ns-2       expiring  expiring-crt  2022-03-01T01:00:00Z  60m
+ns-2       invalid   invalid-crt   <invalid>             <unknown>
+`,
+			expErrMsg: "3 Secret(s) contain a certificate which expires within 24h0m0s",
+		},
+		"expired Secrets are reported regardless of --within": {
+			namespace: "ns-1",
+			within:    time.Minute,
+			expOutput: "NAME     CERTIFICATE  NOT AFTER             EXPIRES IN\nexpired  expired-crt  2022-02-28T23:00:00Z  expired\n",
+			expErrMsg: "1 Secret(s) contain a certificate which expires within 1m0s",
+		},
+		"empty namespace succeeds": {
+			namespace: "ns-3",
+			within:    time.Hour,
+			expOutput: "No certificates managed by cert-manager expire within 1h0m0s\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+			opts := &Options{
+				Within:        test.within,
+				AllNamespaces: test.allNamespaces,
+				IOStreams:     streams,
+				Factory: &factory.Factory{
+					Namespace:  test.namespace,
+					KubeClient: kubefake.NewSimpleClientset(objects...),
+				},
+			}
+
+			err := opts.Run(context.TODO())
+			if test.expErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErrMsg)
+			}
+			assert.Equal(t, test.expOutput, outBuf.String())
+		})
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/wait"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Check that an Issuer or ClusterIssuer is Ready.
+
+The command exits with a non-zero exit code if the Ready condition of the issuer
+is not True for its current generation, printing the reason and message of the
+condition. With --wait-ready, the command instead blocks until the issuer
+becomes Ready, or fails once the --timeout is reached. This is useful in scripts
+which create an issuer and immediately request certificates from it.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Check that the Issuer 'my-issuer' in namespace 'my-namespace' is Ready
+{{.BuildName}} check issuer my-issuer --namespace my-namespace
+
+# Wait up to 2 minutes for the ClusterIssuer 'letsencrypt' to become Ready
+{{.BuildName}} check issuer letsencrypt --kind ClusterIssuer --wait-ready --timeout 2m
+`)))
+)
+
+// Options is a struct to support check issuer command
+type Options struct {
+	// Kind of the issuer, either Issuer or ClusterIssuer
+	Kind string
+	// If true, block until the issuer is Ready
+	WaitReady bool
+	// Time before timeout when waiting for the issuer to become Ready
+	Timeout time.Duration
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Kind:      cmapi.IssuerKind,
+		Timeout:   5 * time.Minute,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckIssuer returns a cobra command for check issuer
+func NewCmdCheckIssuer(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "issuer",
+		Short:   "Check that an Issuer or ClusterIssuer is Ready",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Kind, "kind", o.Kind,
+		fmt.Sprintf("Kind of the issuer to check, one of: (%s, %s)", cmapi.IssuerKind, cmapi.ClusterIssuerKind))
+	cmd.Flags().BoolVar(&o.WaitReady, "wait-ready", o.WaitReady,
+		"If true, wait until the issuer is Ready instead of failing immediately")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout,
+		"Time before timeout when waiting for the issuer to become Ready, must include unit, e.g. 2m or 1h")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the issuer has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the issuer")
+	}
+	if o.Kind != cmapi.IssuerKind && o.Kind != cmapi.ClusterIssuerKind {
+		return fmt.Errorf("the --kind flag must be one of: (%s, %s)", cmapi.IssuerKind, cmapi.ClusterIssuerKind)
+	}
+	if o.WaitReady && o.Timeout <= 0 {
+		return errors.New("the --timeout flag must be set to a positive duration")
+	}
+	return nil
+}
+
+// Run executes check issuer command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	obj := o.issuerObject(args[0])
+
+	if o.WaitReady {
+		fmt.Fprintf(o.ErrOut, "Waiting for %s to become Ready...\n", o.describeIssuer(args[0]))
+		if _, err := wait.For(ctx, o.Timeout, obj, isReady); err != nil {
+			return err
+		}
+	} else {
+		issuer, err := obj.Get(ctx)
+		if err != nil {
+			return err
+		}
+		if ready, _ := isReady(issuer); !ready {
+			return fmt.Errorf("%s is not Ready: %s", o.describeIssuer(args[0]), describeReady(issuer))
+		}
+	}
+
+	fmt.Fprintf(o.Out, "%s is Ready\n", o.describeIssuer(args[0]))
+	return nil
+}
+
+// issuerObject returns the wait.Object for the Issuer or ClusterIssuer with
+// the given name.
+func (o *Options) issuerObject(name string) wait.Object {
+	obj := wait.Object{
+		Name:        name,
+		Description: fmt.Sprintf("%s to become Ready", o.describeIssuer(name)),
+		Describe:    describeReady,
+	}
+
+	if o.Kind == cmapi.ClusterIssuerKind {
+		issuers := o.CMClient.CertmanagerV1().ClusterIssuers()
+		obj.Get = func(ctx context.Context) (runtime.Object, error) {
+			issuer, err := issuers.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("error when getting ClusterIssuer resource: %v", err)
+			}
+			return issuer, nil
+		}
+		obj.Watch = func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			return issuers.Watch(ctx, opts)
+		}
+		return obj
+	}
+
+	issuers := o.CMClient.CertmanagerV1().Issuers(o.Namespace)
+	obj.Get = func(ctx context.Context) (runtime.Object, error) {
+		issuer, err := issuers.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error when getting Issuer resource: %v", err)
+		}
+		return issuer, nil
+	}
+	obj.Watch = func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		return issuers.Watch(ctx, opts)
+	}
+	return obj
+}
+
+func (o *Options) describeIssuer(name string) string {
+	if o.Kind == cmapi.ClusterIssuerKind {
+		return fmt.Sprintf("ClusterIssuer %s", name)
+	}
+	return fmt.Sprintf("Issuer %s/%s", o.Namespace, name)
+}
+
+// isReady returns true if the Ready condition of the issuer is True, and has
+// been observed for its current generation if the issuer reports it.
+func isReady(obj runtime.Object) (bool, error) {
+	issuer := obj.(cmapi.GenericIssuer)
+	cond := util.IssuerReadyCondition(issuer.GetStatus().Conditions)
+	if cond == nil || cond.Status != cmmeta.ConditionTrue {
+		return false, nil
+	}
+	return cond.ObservedGeneration == 0 || cond.ObservedGeneration >= issuer.GetGeneration(), nil
+}
+
+// describeReady describes the Ready condition of the issuer.
+func describeReady(obj runtime.Object) string {
+	cond := util.IssuerReadyCondition(obj.(cmapi.GenericIssuer).GetStatus().Conditions)
+	if cond == nil {
+		return "the Ready condition is not set"
+	}
+	return fmt.Sprintf("Ready: %s, Reason: %s, Message: %s", cond.Status, cond.Reason, cond.Message)
+}
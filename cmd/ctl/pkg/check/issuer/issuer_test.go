@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		opts      *Options
+		args      []string
+		expErrMsg string
+	}{
+		"no name throws error": {
+			opts:      &Options{Kind: cmapi.IssuerKind},
+			expErrMsg: "the name of the issuer has to be provided as argument",
+		},
+		"more than one name throws error": {
+			opts:      &Options{Kind: cmapi.IssuerKind},
+			args:      []string{"issuer-1", "issuer-2"},
+			expErrMsg: "only one argument can be passed in: the name of the issuer",
+		},
+		"unknown kind throws error": {
+			opts:      &Options{Kind: "Certificate"},
+			args:      []string{"issuer-1"},
+			expErrMsg: "the --kind flag must be one of: (Issuer, ClusterIssuer)",
+		},
+		"--wait-ready without a timeout throws error": {
+			opts:      &Options{Kind: cmapi.IssuerKind, WaitReady: true},
+			args:      []string{"issuer-1"},
+			expErrMsg: "the --timeout flag must be set to a positive duration",
+		},
+		"ClusterIssuer with --wait-ready is valid": {
+			opts: &Options{Kind: cmapi.ClusterIssuerKind, WaitReady: true, Timeout: time.Minute},
+			args: []string{"issuer-1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.opts.Validate(test.args)
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	const ns = "test-ns"
+
+	readyIssuer := gen.Issuer("ready-issuer",
+		gen.SetIssuerNamespace(ns),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2,
+		}),
+	)
+	readyIssuer.Generation = 2
+	outdatedIssuer := gen.Issuer("outdated-issuer",
+		gen.SetIssuerNamespace(ns),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 1,
+		}),
+	)
+	outdatedIssuer.Generation = 2
+	notReadyIssuer := gen.Issuer("not-ready-issuer",
+		gen.SetIssuerNamespace(ns),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse, Reason: "ErrRegisterACMEAccount", Message: "Failed to register ACME account",
+		}),
+	)
+	readyClusterIssuer := gen.ClusterIssuer("letsencrypt",
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue,
+		}),
+	)
+	newClusterIssuer := gen.ClusterIssuer("new-issuer")
+
+	tests := map[string]struct {
+		kind      string
+		name      string
+		waitReady bool
+		expOutput string
+		expErrMsg string
+	}{
+		"Ready Issuer is reported": {
+			kind:      cmapi.IssuerKind,
+			name:      readyIssuer.Name,
+			expOutput: "Issuer test-ns/ready-issuer is Ready\n",
+		},
+		"Issuer which is not Ready throws error with the reason": {
+			kind:      cmapi.IssuerKind,
+			name:      notReadyIssuer.Name,
+			expErrMsg: "Issuer test-ns/not-ready-issuer is not Ready: Ready: False, Reason: ErrRegisterACMEAccount, Message: Failed to register ACME account",
+		},
+		"Issuer which is Ready for a previous generation throws error": {
+			kind:      cmapi.IssuerKind,
+			name:      outdatedIssuer.Name,
+			expErrMsg: "Issuer test-ns/outdated-issuer is not Ready: Ready: True, Reason: , Message: ",
+		},
+		"missing Issuer throws error": {
+			kind:      cmapi.IssuerKind,
+			name:      "missing-issuer",
+			expErrMsg: `error when getting Issuer resource: issuers.cert-manager.io "missing-issuer" not found`,
+		},
+		"Ready Issuer is reported right away when waiting": {
+			kind:      cmapi.IssuerKind,
+			name:      readyIssuer.Name,
+			waitReady: true,
+			expOutput: "Issuer test-ns/ready-issuer is Ready\n",
+		},
+		"Issuer which is not Ready times out with the reason": {
+			kind:      cmapi.IssuerKind,
+			name:      notReadyIssuer.Name,
+			waitReady: true,
+			expErrMsg: "timed out waiting for Issuer test-ns/not-ready-issuer to become Ready: Ready: False, Reason: ErrRegisterACMEAccount, Message: Failed to register ACME account",
+		},
+		"Ready ClusterIssuer is reported": {
+			kind:      cmapi.ClusterIssuerKind,
+			name:      readyClusterIssuer.Name,
+			waitReady: true,
+			expOutput: "ClusterIssuer letsencrypt is Ready\n",
+		},
+		"ClusterIssuer without a Ready condition times out": {
+			kind:      cmapi.ClusterIssuerKind,
+			name:      newClusterIssuer.Name,
+			waitReady: true,
+			expErrMsg: "timed out waiting for ClusterIssuer new-issuer to become Ready: the Ready condition is not set",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.Kind = test.kind
+			opts.WaitReady = test.waitReady
+			opts.Timeout = 10 * time.Millisecond
+			opts.Factory = &factory.Factory{
+				Namespace: ns,
+				CMClient:  cmfake.NewSimpleClientset(readyIssuer, outdatedIssuer, notReadyIssuer, readyClusterIssuer, newClusterIssuer),
+			}
+
+			err := opts.Run(context.Background(), []string{test.name})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
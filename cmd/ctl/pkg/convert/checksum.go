@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// checksumTrailerPrefix starts the line appended to the output by --checksum.
+const checksumTrailerPrefix = "# sha256: "
+
+// validateChecksum validates the --checksum and --checksum-file flags. The
+// trailer is a YAML comment, so it can only be appended to YAML output.
+func (o *Options) validateChecksum() error {
+	if !o.checksumRequested() {
+		return nil
+	}
+	if len(o.OutputDir) > 0 {
+		return errors.New("the --checksum and --checksum-file flags cannot be used in conjunction with --output-dir")
+	}
+	if format := o.outputFormat(); o.Checksum && format != "yaml" {
+		return fmt.Errorf("the --checksum flag can only be used with the yaml output format, not %q, use --checksum-file instead", format)
+	}
+	return nil
+}
+
+// checksumRequested returns true if a checksum of the output is written.
+func (o *Options) checksumRequested() bool {
+	return o.Checksum || len(o.ChecksumFile) > 0
+}
+
+// writeChecksummed writes output to w, followed by a '# sha256: <hash>'
+// trailer if --checksum is set, and writes the hash to --checksum-file if
+// set. The hash is the hex encoded SHA-256 of output without the trailer.
+// The printers serialize objects deterministically, with sorted map keys and
+// LF line endings, so the same input always results in the same hash.
+func (o *Options) writeChecksummed(w io.Writer, output []byte) error {
+	sum := sha256.Sum256(output)
+	hash := hex.EncodeToString(sum[:])
+
+	if _, err := w.Write(output); err != nil {
+		return err
+	}
+	if o.Checksum {
+		if _, err := fmt.Fprintf(w, "%s%s\n", checksumTrailerPrefix, hash); err != nil {
+			return err
+		}
+	}
+	if len(o.ChecksumFile) > 0 {
+		if err := os.WriteFile(o.ChecksumFile, []byte(hash+"\n"), 0644); err != nil {
+			return fmt.Errorf("error when writing the checksum file: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestValidateChecksum(t *testing.T) {
+	tests := map[string]struct {
+		checksum     bool
+		checksumFile string
+		outputDir    string
+		format       string
+
+		expErrMsg string
+	}{
+		"trailer with yaml output is valid": {
+			checksum: true,
+			format:   "yaml",
+		},
+		"checksum file with json output is valid": {
+			checksumFile: "out.json.sha256",
+			format:       "json",
+		},
+		"trailer with json output throws error": {
+			checksum:  true,
+			format:    "json",
+			expErrMsg: `the --checksum flag can only be used with the yaml output format, not "json", use --checksum-file instead`,
+		},
+		"checksum file in conjunction with output directory throws error": {
+			checksumFile: "out.sha256",
+			outputDir:    "out",
+			format:       "yaml",
+			expErrMsg:    "the --checksum and --checksum-file flags cannot be used in conjunction with --output-dir",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.Checksum = test.checksum
+			o.ChecksumFile = test.checksumFile
+			o.OutputDir = test.outputDir
+			o.PrintFlags.OutputFormat = &test.format
+
+			err := o.validateChecksum()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWriteChecksummed(t *testing.T) {
+	const (
+		output = "apiVersion: cert-manager.io/v1\nkind: Certificate\n"
+		// sha256sum of output
+		hash = "31c09cd95b09de9f6a7699d7c7552c21a03683313159181e68e4b131c0333372"
+	)
+
+	checksumFile := filepath.Join(t.TempDir(), "out.yaml.sha256")
+	o := &Options{Checksum: true, ChecksumFile: checksumFile}
+
+	var out bytes.Buffer
+	if err := o.writeChecksummed(&out, []byte(output)); err != nil {
+		t.Fatal(err)
+	}
+
+	expOutput := output + "# sha256: " + hash + "\n"
+	if out.String() != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, out.String())
+	}
+
+	fileContent, err := os.ReadFile(checksumFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(fileContent) != hash+"\n" {
+		t.Errorf("Unexpected checksum file; expected: \n%s\nactual: \n%s", hash, fileContent)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// serverMetadataFields are the fields of the metadata of objects which are
+// populated by the API server, and are dropped by --clean.
+var serverMetadataFields = []string{
+	"managedFields",
+	"resourceVersion",
+	"uid",
+	"selfLink",
+	"generation",
+	"creationTimestamp",
+}
+
+// noiseAnnotations are the annotations dropped by --clean unless
+// --keep-annotations is given, by kind. Annotations set on all kinds are
+// listed under "".
+var noiseAnnotations = map[string][]string{
+	"": {corev1.LastAppliedConfigAnnotation},
+	// Set by the controller on the CertificateRequests it creates for
+	// Certificates
+	cmapi.CertificateRequestKind: {
+		cmapi.CertificateNameKey,
+		cmapi.CertificateRequestRevisionAnnotationKey,
+		cmapi.CertificateRequestPrivateKeyAnnotationKey,
+	},
+}
+
+// validateClean validates the --clean and --keep-annotations flags.
+func (o *Options) validateClean() error {
+	if len(o.KeepAnnotations) > 0 && !o.Clean {
+		return errors.New("the --keep-annotations flag must be used in conjunction with --clean")
+	}
+	for _, prefix := range o.KeepAnnotations {
+		if len(prefix) == 0 {
+			return errors.New("the prefixes given by --keep-annotations must not be empty")
+		}
+	}
+	return nil
+}
+
+// cleanObject drops the metadata populated by the API server and the
+// controllers from obj, so that it can be applied to another cluster. If
+// keepPrefixes is empty, the known noise annotations are dropped, otherwise
+// only the annotations with one of the prefixes are kept. Objects which are
+// not unstructured are left unchanged.
+func cleanObject(obj runtime.Object, keepPrefixes []string) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	for _, field := range serverMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+
+	annotations := u.GetAnnotations()
+	if len(annotations) == 0 {
+		return
+	}
+	var noise []string
+	noise = append(noise, noiseAnnotations[""]...)
+	noise = append(noise, noiseAnnotations[u.GetKind()]...)
+	for key := range annotations {
+		if len(keepPrefixes) > 0 {
+			if !hasAnyPrefix(key, keepPrefixes) {
+				delete(annotations, key)
+			}
+			continue
+		}
+		for _, noiseKey := range noise {
+			if key == noiseKey {
+				delete(annotations, key)
+			}
+		}
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+		return
+	}
+	u.SetAnnotations(annotations)
+}
+
+// hasAnyPrefix returns true if s starts with any of prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
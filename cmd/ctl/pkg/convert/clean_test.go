@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCleanObject(t *testing.T) {
+	object := func(kind string, annotations map[string]interface{}) map[string]interface{} {
+		metadata := map[string]interface{}{
+			"name":              "test",
+			"namespace":         "default",
+			"uid":               "2d6f1c58-7d4e-4b8c-9a4e-5d2b9c1f0e3a",
+			"resourceVersion":   "1234",
+			"generation":        int64(2),
+			"creationTimestamp": "2022-01-01T00:00:00Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		}
+		if annotations != nil {
+			metadata["annotations"] = annotations
+		}
+		return map[string]interface{}{"kind": kind, "metadata": metadata}
+	}
+	cleaned := func(kind string, annotations map[string]interface{}) map[string]interface{} {
+		metadata := map[string]interface{}{"name": "test", "namespace": "default"}
+		if annotations != nil {
+			metadata["annotations"] = annotations
+		}
+		return map[string]interface{}{"kind": kind, "metadata": metadata}
+	}
+
+	tests := map[string]struct {
+		input        map[string]interface{}
+		keepPrefixes []string
+		expObj       map[string]interface{}
+	}{
+		"server metadata and noise annotations are dropped": {
+			input: object("Certificate", map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"example.com/owner": "team-a",
+			}),
+			expObj: cleaned("Certificate", map[string]interface{}{
+				"example.com/owner": "team-a",
+			}),
+		},
+		"annotations set by cert-manager on CertificateRequests are dropped": {
+			input: object("CertificateRequest", map[string]interface{}{
+				"cert-manager.io/certificate-name":        "test",
+				"cert-manager.io/certificate-revision":    "1",
+				"cert-manager.io/private-key-secret-name": "test-abcde",
+			}),
+			expObj: cleaned("CertificateRequest", nil),
+		},
+		"annotations set by users on Certificates are kept": {
+			input: object("Certificate", map[string]interface{}{
+				"cert-manager.io/issue-temporary-certificate": "true",
+			}),
+			expObj: cleaned("Certificate", map[string]interface{}{
+				"cert-manager.io/issue-temporary-certificate": "true",
+			}),
+		},
+		"only annotations with a kept prefix are kept": {
+			input: object("Certificate", map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"example.com/owner":                           "team-a",
+				"example.org/owner":                           "team-b",
+				"cert-manager.io/issue-temporary-certificate": "true",
+			}),
+			keepPrefixes: []string{"example.com/", "cert-manager.io/"},
+			expObj: cleaned("Certificate", map[string]interface{}{
+				"example.com/owner":                           "team-a",
+				"cert-manager.io/issue-temporary-certificate": "true",
+			}),
+		},
+		"objects without annotations are cleaned": {
+			input:  object("Issuer", nil),
+			expObj: cleaned("Issuer", nil),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: test.input}
+			cleanObject(u, test.keepPrefixes)
+			if !reflect.DeepEqual(u.Object, test.expObj) {
+				t.Errorf("unexpected object; expected: %v, actual: %v", test.expObj, u.Object)
+			}
+		})
+	}
+}
+
+func TestValidateClean(t *testing.T) {
+	tests := map[string]struct {
+		clean           bool
+		keepAnnotations []string
+
+		expErrMsg string
+	}{
+		"--clean without --keep-annotations is valid": {
+			clean: true,
+		},
+		"--keep-annotations in conjunction with --clean is valid": {
+			clean:           true,
+			keepAnnotations: []string{"example.com/"},
+		},
+		"--keep-annotations without --clean throws error": {
+			keepAnnotations: []string{"example.com/"},
+			expErrMsg:       "the --keep-annotations flag must be used in conjunction with --clean",
+		},
+		"empty prefix throws error": {
+			clean:           true,
+			keepAnnotations: []string{""},
+			expErrMsg:       "the prefixes given by --keep-annotations must not be empty",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{Clean: test.clean, KeepAnnotations: test.keepAnnotations}
+			err := o.validateClean()
+			switch {
+			case test.expErrMsg == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.expErrMsg != "" && (err == nil || err.Error() != test.expErrMsg):
+				t.Errorf("expected error %q, got %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
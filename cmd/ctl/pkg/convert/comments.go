@@ -0,0 +1,343 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// validatePreserveComments validates the --preserve-comments flag. Comments
+// can only be kept for the YAML documents of local files, which are read
+// again to edit them.
+func (o *Options) validatePreserveComments() error {
+	if !o.PreserveComments {
+		return nil
+	}
+	if format := o.outputFormat(); !o.InPlace && format != "yaml" {
+		return fmt.Errorf("the --preserve-comments flag can only be used with the yaml output format, not %q", format)
+	}
+	if o.StdinJSONArray || o.readsStdin() || len(o.Kustomize) > 0 {
+		return errors.New("the --preserve-comments flag can only be used with local files, not stdin or --kustomize")
+	}
+	for _, filename := range o.Filenames {
+		if strings.HasPrefix(filename, "http://") || strings.HasPrefix(filename, "https://") {
+			return fmt.Errorf("the --preserve-comments flag can only be used with local files, not %q", filename)
+		}
+	}
+	if len(o.OutputDir) > 0 || o.Diff {
+		return errors.New("the --preserve-comments flag cannot be used in conjunction with the --output-dir or --diff flags")
+	}
+	if len(o.DefaultKind) > 0 || len(o.ManagedByFilter) > 0 {
+		return errors.New("the --preserve-comments flag cannot be used in conjunction with the --default-kind or --managed-by-filter flags")
+	}
+	return nil
+}
+
+// writePreservingComments writes the converted objects to the output file by
+// file, keeping the comments of the files they were read from.
+func (o *Options) writePreservingComments(converted []convertedObject) error {
+	var paths []string
+	objectsByPath := map[string][]runtime.Object{}
+	for _, c := range converted {
+		if _, ok := objectsByPath[c.source]; !ok {
+			paths = append(paths, c.source)
+		}
+		objectsByPath[c.source] = append(objectsByPath[c.source], c.object)
+	}
+
+	for i, path := range paths {
+		content, err := o.preserveComments(path, objectsByPath[path])
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			io.WriteString(o.Out, "---\n")
+		}
+		if _, err := o.Out.Write(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preserveComments returns the YAML documents of the file at path with their
+// values replaced by the ones of the objects converted from them. Values are
+// edited in place, so that comments and the order of fields are kept.
+// Documents whose fields are restructured by the conversion, rather than
+// changed or renamed, are replaced by the converted object with a warning.
+func (o *Options) preserveComments(path string, objects []runtime.Object) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %q: %w", path, err)
+	}
+	// The documents are edited as read by the resource.Builder, JSON objects
+	// mixed with YAML documents are kept as flow mappings
+	normalized, err := io.ReadAll(newMixedDocumentReader(bytes.NewReader(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %q: %w", path, err)
+	}
+	documents, err := decodeDocuments(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading %q: %w", path, err)
+	}
+
+	converted := make([]*kyaml.Node, 0, len(objects))
+	for _, object := range objects {
+		node, err := o.convertedDocument(object)
+		if err != nil {
+			return nil, fmt.Errorf("error when writing %q: %w", path, err)
+		}
+		converted = append(converted, node)
+	}
+
+	if len(documents) != len(converted) {
+		// e.g. a List, whose items are converted as separate objects
+		fmt.Fprintf(o.ErrOut, "warning: the comments of %q cannot be preserved, as its documents cannot be matched to the converted resources\n", path)
+		documents = converted
+	}
+	for i, document := range documents {
+		if document == converted[i] {
+			continue
+		}
+		want, err := nodeValue(converted[i])
+		if err != nil {
+			return nil, fmt.Errorf("error when writing %q: %w", path, err)
+		}
+		if !patchNode(document.Content[0], want) {
+			fmt.Fprintf(o.ErrOut, "warning: the comments of document %d of %q cannot be preserved, as its fields are restructured by the conversion\n", i+1, path)
+			documents[i] = converted[i]
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := kyaml.NewEncoderWithOptions(&buf, &kyaml.EncoderOptions{
+		SeqIndent: kyaml.SequenceIndentStyle(kyaml.DeriveSeqIndentStyle(string(raw))),
+	})
+	for _, document := range documents {
+		if err := enc.Encode(document); err != nil {
+			return nil, fmt.Errorf("error when writing %q: %w", path, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("error when writing %q: %w", path, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// convertedDocument returns object printed as YAML, as it is output without
+// --preserve-comments, as a document node.
+func (o *Options) convertedDocument(object runtime.Object) (*kyaml.Node, error) {
+	format := "yaml"
+	flags := *o.PrintFlags
+	flags.OutputFormat = &format
+	printer, err := flags.ToPrinter()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := printer.PrintObj(object, &buf); err != nil {
+		return nil, err
+	}
+	documents, err := decodeDocuments(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(documents) != 1 {
+		return nil, fmt.Errorf("expected one document for a converted object, got %d", len(documents))
+	}
+	return documents[0], nil
+}
+
+// decodeDocuments returns the non-empty YAML documents in data.
+func decodeDocuments(data []byte) ([]*kyaml.Node, error) {
+	var documents []*kyaml.Node
+	dec := kyaml.NewDecoder(bytes.NewReader(data))
+	for {
+		document := new(kyaml.Node)
+		err := dec.Decode(document)
+		if err == io.EOF {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(document.Content) == 0 || document.Content[0].Tag == "!!null" {
+			continue
+		}
+		documents = append(documents, document)
+	}
+}
+
+// nodeValue returns the value represented by node as decoded from JSON, so
+// that it can be compared to the values of converted objects.
+func nodeValue(node *kyaml.Node) (interface{}, error) {
+	data, err := kyaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// equalValue returns true if node represents want.
+func equalValue(node *kyaml.Node, want interface{}) bool {
+	value, err := nodeValue(node)
+	return err == nil && reflect.DeepEqual(value, want)
+}
+
+// patchNode edits node in place to represent want, returning false if this
+// is not possible without restructuring it. Scalars are replaced keeping
+// their comments, keys whose value is kept are renamed, and keys missing from
+// want are dropped. Sequences have to keep their length.
+func patchNode(node *kyaml.Node, want interface{}) bool {
+	if equalValue(node, want) {
+		return true
+	}
+	if node.Kind == kyaml.AliasNode || len(node.Anchor) > 0 {
+		// Editing an anchor would change its aliases as well
+		return false
+	}
+
+	switch node.Kind {
+	case kyaml.MappingNode:
+		wantMap, ok := want.(map[string]interface{})
+		return ok && patchMapping(node, wantMap)
+	case kyaml.SequenceNode:
+		wantSlice, ok := want.([]interface{})
+		if !ok || len(wantSlice) != len(node.Content) {
+			return false
+		}
+		for i := range node.Content {
+			if !patchNode(node.Content[i], wantSlice[i]) {
+				return false
+			}
+		}
+		return true
+	case kyaml.ScalarNode:
+		switch want.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+		return replaceScalar(node, want)
+	default:
+		return false
+	}
+}
+
+// patchMapping edits the mapping node in place to represent want.
+func patchMapping(node *kyaml.Node, want map[string]interface{}) bool {
+	var removed []int
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if node.Content[i].Tag == kyaml.MergeTag {
+			return false
+		}
+		seen[key] = true
+		value, ok := want[key]
+		if !ok {
+			removed = append(removed, i)
+			continue
+		}
+		if !patchNode(node.Content[i+1], value) {
+			return false
+		}
+	}
+
+	var added []string
+	for key := range want {
+		if !seen[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	// Keys added by the conversion are either renamed keys, keeping the value
+	// of a removed key, or empty values written by the serialization, e.g.
+	// 'creationTimestamp: null', which are left out.
+	for _, key := range added {
+		if isEmptyValue(want[key]) {
+			continue
+		}
+		renamed := false
+		for j, i := range removed {
+			if equalValue(node.Content[i+1], want[key]) {
+				node.Content[i].Value = key
+				removed = append(removed[:j], removed[j+1:]...)
+				renamed = true
+				break
+			}
+		}
+		if !renamed {
+			return false
+		}
+	}
+
+	for j := len(removed) - 1; j >= 0; j-- {
+		i := removed[j]
+		node.Content = append(node.Content[:i], node.Content[i+2:]...)
+	}
+	return true
+}
+
+// replaceScalar replaces the value of the scalar node by want, keeping its
+// comments and, for strings, its quoting style.
+func replaceScalar(node *kyaml.Node, want interface{}) bool {
+	// Numbers decoded from JSON are floats, whole numbers are written as
+	// integers as they were most likely given as such
+	if f, ok := want.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		want = int64(f)
+	}
+	head, line, foot := node.HeadComment, node.LineComment, node.FootComment
+	style, wasString := node.Style, node.Tag == "!!str"
+	if err := node.Encode(want); err != nil {
+		return false
+	}
+	node.HeadComment, node.LineComment, node.FootComment = head, line, foot
+	if wasString && node.Tag == "!!str" && style&(kyaml.SingleQuotedStyle|kyaml.DoubleQuotedStyle) != 0 {
+		node.Style = style
+	}
+	return true
+}
+
+// isEmptyValue returns true if value is null, an empty map or an empty list.
+func isEmptyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestValidatePreserveComments(t *testing.T) {
+	tests := map[string]struct {
+		modify    func(o *Options)
+		expErrMsg string
+	}{
+		"--preserve-comments with stdin throws error": {
+			modify:    func(o *Options) { o.Filenames = []string{"crt.yaml", "-"} },
+			expErrMsg: "the --preserve-comments flag can only be used with local files, not stdin or --kustomize",
+		},
+		"--preserve-comments with a URL throws error": {
+			modify:    func(o *Options) { o.Filenames = []string{"https://example.com/crt.yaml"} },
+			expErrMsg: `the --preserve-comments flag can only be used with local files, not "https://example.com/crt.yaml"`,
+		},
+		"--preserve-comments with json output throws error": {
+			modify: func(o *Options) {
+				format := "json"
+				o.PrintFlags.OutputFormat = &format
+			},
+			expErrMsg: `the --preserve-comments flag can only be used with the yaml output format, not "json"`,
+		},
+		"--preserve-comments with --in-place ignores the output format": {
+			modify: func(o *Options) {
+				format := "json"
+				o.PrintFlags.OutputFormat = &format
+				o.InPlace = true
+			},
+		},
+		"--preserve-comments with --diff throws error": {
+			modify:    func(o *Options) { o.Diff = true },
+			expErrMsg: "the --preserve-comments flag cannot be used in conjunction with the --output-dir or --diff flags",
+		},
+		"--preserve-comments with local files is valid": {
+			modify: func(o *Options) {},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.Filenames = []string{"crt.yaml"}
+			o.PreserveComments = true
+			test.modify(o)
+
+			err := o.validatePreserveComments()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestPreserveComments(t *testing.T) {
+	const certificate = `# Certificate of the example service
+apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: example # the name
+  namespace: default
+spec:
+  # Where the key pair is stored
+  secretName: example-tls
+  dnsNames:
+  - example.com # primary
+  - www.example.com
+  issuerRef:
+    name: ca-issuer
+`
+	const request = `apiVersion: cert-manager.io/v1alpha2
+kind: CertificateRequest
+metadata:
+  name: req
+spec:
+  csr: QUJD # the CSR
+  issuerRef:
+    name: ca-issuer
+`
+	const restructured = `apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: moved
+spec:
+  secretName: moved-tls
+  keyAlgorithm: ecdsa # moved to privateKey
+  issuerRef:
+    name: ca-issuer
+`
+
+	tests := map[string]struct {
+		input   string
+		inPlace bool
+
+		expOutput  string
+		expWarning string
+	}{
+		"changed values are replaced keeping comments and order": {
+			input: certificate,
+			expOutput: `# Certificate of the example service
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: example # the name
+  namespace: default
+spec:
+  # Where the key pair is stored
+  secretName: example-tls
+  dnsNames:
+  - example.com # primary
+  - www.example.com
+  issuerRef:
+    name: ca-issuer
+`,
+		},
+		"renamed fields are renamed in place": {
+			input: request,
+			expOutput: `apiVersion: cert-manager.io/v1
+kind: CertificateRequest
+metadata:
+  name: req
+spec:
+  request: QUJD # the CSR
+  issuerRef:
+    name: ca-issuer
+`,
+		},
+		"restructured documents are converted without comments": {
+			input: request + "---\n" + restructured,
+			expOutput: `apiVersion: cert-manager.io/v1
+kind: CertificateRequest
+metadata:
+  name: req
+spec:
+  request: QUJD # the CSR
+  issuerRef:
+    name: ca-issuer
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  creationTimestamp: null
+  name: moved
+spec:
+  issuerRef:
+    name: ca-issuer
+  privateKey:
+    algorithm: ECDSA
+  secretName: moved-tls
+status: {}
+`,
+			expWarning: "warning: the comments of document 2 of \"<path>\" cannot be preserved, as its fields are restructured by the conversion\n",
+		},
+		"files are rewritten keeping comments with --in-place": {
+			input:   request,
+			inPlace: true,
+			expOutput: `apiVersion: cert-manager.io/v1
+kind: CertificateRequest
+metadata:
+  name: req
+spec:
+  request: QUJD # the CSR
+  issuerRef:
+    name: ca-issuer
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "input.yaml")
+			if err := os.WriteFile(path, []byte(test.input), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			streams, _, out, errOut := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Filenames = []string{path}
+			o.OutputVersion = "cert-manager.io/v1"
+			o.PreserveComments = true
+			o.InPlace = test.inPlace
+			if err := o.Complete(); err != nil {
+				t.Fatal(err)
+			}
+			if err := o.Run(context.TODO()); err != nil {
+				t.Fatal(err)
+			}
+
+			output := out.String()
+			if test.inPlace {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				output = string(content)
+			}
+			if output != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, output)
+			}
+			expWarning := strings.ReplaceAll(test.expWarning, "<path>", path)
+			if errOut.String() != expWarning {
+				t.Errorf("Unexpected warning; expected: %q, actual: %q", expWarning, errOut.String())
+			}
+		})
+	}
+}
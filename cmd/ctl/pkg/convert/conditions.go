@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// pruneStatusConditions trims the status conditions of obj to the latest
+// condition of every type, which is the one with the latest
+// lastTransitionTime, or the last one listed if the times are equal. The
+// conditions stay in the order in which their types are first listed.
+// Objects without a list of conditions are left unchanged.
+func pruneStatusConditions(obj runtime.Object) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	conditions, found, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if !found || err != nil {
+		return
+	}
+
+	var pruned []interface{}
+	index := make(map[string]int, len(conditions))
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if !ok {
+			pruned = append(pruned, condition)
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(c, "type")
+		i, seen := index[conditionType]
+		if !seen {
+			index[conditionType] = len(pruned)
+			pruned = append(pruned, c)
+			continue
+		}
+		if !transitionTime(c).Before(transitionTime(pruned[i].(map[string]interface{}))) {
+			pruned[i] = c
+		}
+	}
+
+	// Cannot fail as the status of obj exists
+	_ = unstructured.SetNestedSlice(u.Object, pruned, "status", "conditions")
+}
+
+// transitionTime returns the lastTransitionTime of condition, or the zero
+// time if it is not set or invalid.
+func transitionTime(condition map[string]interface{}) time.Time {
+	value, _, _ := unstructured.NestedString(condition, "lastTransitionTime")
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
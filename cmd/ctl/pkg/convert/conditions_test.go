@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPruneStatusConditions(t *testing.T) {
+	condition := func(conditionType, status, lastTransitionTime string) map[string]interface{} {
+		c := map[string]interface{}{"type": conditionType, "status": status}
+		if len(lastTransitionTime) > 0 {
+			c["lastTransitionTime"] = lastTransitionTime
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		input  map[string]interface{}
+		expObj map[string]interface{}
+	}{
+		"the latest condition of every type is kept in order": {
+			input: map[string]interface{}{
+				"kind": "Certificate",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						condition("Ready", "False", "2022-01-01T00:00:00Z"),
+						condition("Issuing", "True", "2022-01-01T00:00:00Z"),
+						condition("Ready", "True", "2022-01-02T00:00:00Z"),
+						condition("Ready", "Unknown", "2021-12-31T00:00:00Z"),
+						condition("Issuing", "False", "2022-01-02T00:00:00Z"),
+					},
+				},
+			},
+			expObj: map[string]interface{}{
+				"kind": "Certificate",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						condition("Ready", "True", "2022-01-02T00:00:00Z"),
+						condition("Issuing", "False", "2022-01-02T00:00:00Z"),
+					},
+				},
+			},
+		},
+		"the last condition is kept if the times are equal or missing": {
+			input: map[string]interface{}{
+				"kind": "Issuer",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						condition("Ready", "False", ""),
+						condition("Ready", "True", ""),
+					},
+				},
+			},
+			expObj: map[string]interface{}{
+				"kind": "Issuer",
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						condition("Ready", "True", ""),
+					},
+				},
+			},
+		},
+		"objects without conditions are unchanged": {
+			input: map[string]interface{}{
+				"kind":   "Order",
+				"status": map[string]interface{}{"state": "valid"},
+			},
+			expObj: map[string]interface{}{
+				"kind":   "Order",
+				"status": map[string]interface{}{"state": "valid"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: test.input}
+			pruneStatusConditions(obj)
+			if !reflect.DeepEqual(obj.Object, test.expObj) {
+				t.Errorf("Unexpected object; expected: \n%v\nactual: \n%v", test.expObj, obj.Object)
+			}
+		})
+	}
+}
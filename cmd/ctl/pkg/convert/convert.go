@@ -17,14 +17,22 @@ limitations under the License.
 package convert
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
-	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -44,36 +52,325 @@ var (
 		# Convert 'cert.yaml' to latest version and print to stdout.
 		{{.BuildName}} convert -f cert.yaml
 
+		# Convert the resources output by kubectl, read from stdin, to 'cert-manager.io/v1'.
+		kubectl get certificates -o yaml | {{.BuildName}} convert -f - --output-version cert-manager.io/v1
+
+		# Convert all manifests under 'manifests/' and its subdirectories to latest version.
+		{{.BuildName}} convert -f manifests/ -R
+
 		# Convert kustomize overlay under current directory to 'cert-manager.io/v1alpha3'
-		{{.BuildName}} convert -k . --output-version cert-manager.io/v1alpha3`)))
+		{{.BuildName}} convert -k . --output-version cert-manager.io/v1alpha3
+
+		# Convert the Certificates and Challenges in 'bundle.yaml' to 'cert-manager.io/v1' and 'acme.cert-manager.io/v1'.
+		{{.BuildName}} convert -f bundle.yaml --output-version v1
+
+		# Convert 'bundle.yaml' to 'cert-manager.io/v1', but keep Issuers at 'cert-manager.io/v1beta1'.
+		{{.BuildName}} convert -f bundle.yaml --output-version cert-manager.io/v1 --output-version-per-kind Issuer=cert-manager.io/v1beta1
+
+		# Convert 'cert.yaml' to 'cert-manager.io/v1', refusing to do so if the current cluster doesn't serve that version.
+		{{.BuildName}} convert -f cert.yaml --output-version cert-manager.io/v1 --target-served-only
+
+		# Convert 'fragment.yaml', treating objects without apiVersion and kind as 'cert-manager.io/v1alpha2' Certificates.
+		{{.BuildName}} convert -f fragment.yaml --default-apiversion cert-manager.io/v1alpha2 --default-kind Certificate
+
+		# Convert 'cert.yaml' to latest version, moving all namespaced resources to the namespace 'my-namespace'.
+		{{.BuildName}} convert -f cert.yaml --input-namespace my-namespace
+
+		# Convert 'cert.yaml' to latest version, failing if any resource is missing a required field.
+		{{.BuildName}} convert -f cert.yaml --require-complete
+
+		# Convert the List in 'all.yaml' to latest version, writing one file per kind to 'converted/'.
+		{{.BuildName}} convert -f all.yaml --output-dir converted --split-by-kind
+
+		# Convert the manifests under 'manifests/' and its subdirectories to latest version, writing them to the same paths under 'converted/'.
+		{{.BuildName}} convert -f manifests/ -R --output-dir converted
+
+		# Convert 'exported.yaml' to latest version, keeping only the latest status condition of every type.
+		{{.BuildName}} convert -f exported.yaml --prune-status-conditions
+
+		# Convert the resources exported to 'exported.yaml' to latest version, dropping all annotations except those starting with 'example.com/'.
+		{{.BuildName}} convert -f exported.yaml --clean --keep-annotations example.com/
+
+		# Convert the JSON array of objects output by 'jq', printing the converted objects as a JSON array.
+		jq '[.items[] | select(.kind == "Certificate")]' all.json | {{.BuildName}} convert --stdin-json-array -o json
+
+		# Convert the List in 'exported.yaml', printing the converted objects as a JSON array.
+		{{.BuildName}} convert -f exported.yaml -o json --json-array
+
+		# Only convert the objects in 'resources.yaml' created for Certificates by cert-manager.
+		{{.BuildName}} convert -f resources.yaml --managed-by-filter 'annotations:cert-manager.io/certificate-name'
+
+		# Convert 'cert.yaml' to latest version, writing the SHA-256 of the output to 'cert.v1.yaml.sha256'.
+		{{.BuildName}} convert -f cert.yaml --checksum-file cert.v1.yaml.sha256 > cert.v1.yaml
+
+		# Convert the manifests under 'manifests/' and its subdirectories to 'cert-manager.io/v1', overwriting every file.
+		{{.BuildName}} convert -f manifests/ -R --output-version cert-manager.io/v1 --in-place
+
+		# Print the changes converting 'cert.yaml' to 'cert-manager.io/v1' would make, without converting it.
+		{{.BuildName}} convert -f cert.yaml --output-version cert-manager.io/v1 --diff
+
+		# Convert the cert-manager resources rendered by 'helm template', passing all other resources through unchanged.
+		helm template my-release ./chart | {{.BuildName}} convert -f - --skip-non-cert-manager
+
+		# List every object of 'bundle.yaml' which cannot be converted to 'cert-manager.io/v1', converting all others.
+		{{.BuildName}} convert -f bundle.yaml --output-version cert-manager.io/v1 --fail-fast=false
+
+		# Convert all files listed in 'files.txt', one path per line, to latest version.
+		{{.BuildName}} convert --from-file-list files.txt
+
+		# Convert the manifests under 'manifests/' to latest version, writing a markdown summary of the conversion to 'report.md'.
+		{{.BuildName}} convert -f manifests/ --report-format markdown --report-file report.md`)))
 
 	longDesc = templates.LongDesc(i18n.T(`
 Convert cert-manager config files between different API versions. Both YAML
-and JSON formats are accepted.
+and JSON formats are accepted, and can be mixed within a file, e.g. a JSON
+object followed by YAML documents.
 
 The command takes filename, directory, or URL as input, and converts into the
-format of the version specified by --output-version flag. If target version is
-not specified or not supported, it will convert to the latest version
+format of the version specified by --output-version flag. If the flag is not
+given, the version is read from the CMCTL_CONVERT_VERSION environment variable.
+If target version is not specified, it will convert to the latest version. A
+version which is not supported is rejected, listing the supported versions.
+
+The version can be given without its group, e.g. v1, to convert the resources
+of both the cert-manager.io and acme.cert-manager.io groups, such as
+Certificates and Challenges, to that version of their group. A version which
+is only supported by one of the groups is rejected as ambiguous.
+
+The version of individual kinds can be set with --output-version-per-kind, e.g.
+Certificate=cert-manager.io/v1,Issuer=cert-manager.io/v1beta1 to migrate kinds
+one at a time. Kinds which are not listed are converted to --output-version.
 
 The default output will be printed to stdout in YAML format. One can use -o option
-to change to output destination.`))
+to change to output destination.
+
+The filename '-' reads the input from stdin, e.g. to convert the output of kubectl
+without writing it to a file first. It can be given once, in addition to other files.
+
+If a directory is given as filename, the files with the extensions .json, .yaml
+and .yml it contains are converted in lexical order, including the files in its
+subdirectories if -R/--recursive is set. Other files are skipped with a warning.
+
+Large numbers of inputs can be listed in a file given by --from-file-list, one
+path per line, instead of passing each of them with -f. Relative paths are
+resolved against the current directory. Blank lines and lines starting with '#'
+are ignored.
+
+If --stdin-json-array is set, a JSON array of objects is read from stdin, as output
+by jq-based pipelines, instead of the files given by --filename. The converted objects
+are printed as a JSON array with -o json, or as one document per object otherwise.
+
+With -o json, multiple objects are printed as a List, as kubectl does. If --json-array
+is set, they are printed as a JSON array of objects instead, whichever input they are
+read from.
+
+By default every object is converted. If --managed-by-filter is set, only objects
+matching the given predicate are converted and output, e.g. to restrict the conversion
+to objects created by the cert-manager controllers. A predicate is of the form
+'labels:<selector>' or 'annotations:<selector>', where the selector uses the syntax of
+label selectors, e.g. 'labels:app.kubernetes.io/managed-by=cert-manager' or
+'annotations:cert-manager.io/certificate-name'. If the flag is repeated, objects have
+to match all predicates.
+
+Objects which are not cert-manager resources cannot be converted and fail the
+conversion. If --skip-non-cert-manager is set, objects outside of the cert-manager.io
+and acme.cert-manager.io API groups are output unchanged in their original position
+instead, e.g. to convert the output of 'helm template' or 'kustomize build' as a
+whole. The objects passed through are neither cleaned nor moved to --input-namespace.
+In conjunction with --preserve-comments, their documents are kept verbatim.
+
+By default the conversion is aborted by the first object which fails to be
+decoded or converted. With --fail-fast=false, all objects are attempted instead:
+the objects which were converted are output, and the errors of all other objects
+are listed at the end, failing the command, so that all problems of a bundle can
+be fixed in one pass. With --all-or-nothing, nothing is output if any object fails.
+
+If --target-served-only is set and a cluster is reachable, the conversion is refused
+if the target version is not served by the cluster.
+
+If --check-feature-gates is set and a cluster is reachable, a warning is printed
+for every Certificate setting a field which requires a feature gate of the webhook,
+e.g. spec.additionalOutputFormats, if the gate appears to be disabled in the
+cluster, as the Certificate would be rejected on apply. The Certificates are
+created in dry-run mode to detect this. With --strict, the conversion fails instead.
+
+If --require-complete is set, the conversion fails if any converted cert-manager
+resource is missing a field required by its schema, e.g. a Certificate without
+secretName or issuerRef, instead of outputting resources which would be rejected
+by the API server. All missing fields of every resource are listed.
+
+Issuers and ClusterIssuers configuring HTTP-01 with the legacy spec.acme.http01
+field, which predates spec.acme.solvers, are migrated to an HTTP-01 solver: the
+ingressClass, ingress and serviceType fields are moved to the class, name and
+serviceType fields of the solver's ingress. A warning is printed for every field
+which cannot be migrated, and the legacy field is dropped if solvers are already
+configured, as cert-manager ignores it in that case.
+
+If --clean is set, the metadata populated by the API server, i.e. managedFields,
+resourceVersion, uid, selfLink, generation and creationTimestamp, is dropped, as
+well as the kubectl.kubernetes.io/last-applied-configuration annotation and the
+annotations set by cert-manager on the CertificateRequests it creates. With
+--keep-annotations, only the annotations starting with one of the given prefixes
+are kept instead, e.g. '--clean --keep-annotations example.com/' drops every
+annotation which isn't under example.com/.
+
+The status of the resources is kept. If --prune-status-conditions is set, the
+status conditions are trimmed to the latest condition of every type, i.e. the one
+with the latest lastTransitionTime, to keep exported resources small.
+
+If --output-dir is set, the converted resources are written to files in the given
+directory instead of stdout. The paths of the input files relative to the directories
+given as filename are mirrored, e.g. "-f manifests/ -R --output-dir out" writes the
+resources of manifests/a/cert.yaml to out/a/cert.yaml, creating directories as needed.
+Files given as filename are written to the output directory by their name. The
+extension is changed if it doesn't match the output format. Resources which were not
+read from local files, e.g. from stdin, are written as with --flatten.
+
+With --flatten, every resource is written to its own file instead, named after its
+kind, namespace and name. With --split-by-kind, the resources are grouped into one
+file per kind instead, e.g. certificates.yaml and issuers.yaml, sorted by name.
+
+If --in-place is set, every file given as input is overwritten with its converted
+resources, e.g. to migrate a repository of manifests with "-f ./manifests -R". Files
+with the extension .json are written as JSON and all other files as YAML, with the
+resources of multi-document files written as separate documents. Comments are not
+kept unless --preserve-comments is set. The files are only written once all of them
+were converted, so no file is modified if any of them fails to convert.
+
+If --preserve-comments is set, the YAML files given as input are edited rather than
+serialized from the converted resources, so that their comments and the order of
+their fields are kept, e.g. when only the apiVersion changes. Changed values are
+replaced and renamed fields are renamed in place, fields dropped by the conversion
+are removed. Documents whose fields are restructured by the conversion, e.g. moved
+to another parent, are output as without --preserve-comments, with a warning. The
+indentation of the output is normalized. Can be combined with --in-place.
+
+If --diff is set, a unified diff between every resource and its converted form is
+output instead of the converted resources, both in YAML with sorted keys, to review
+what a migration changes. Resources which are not changed are left out. The command
+exits with a non-zero code if any resource is changed, so it can be used to check
+in CI whether manifests still have to be migrated.
+
+If --checksum is set, a '# sha256: <hash>' line is appended to the output, where the
+hash is the SHA-256 of the output preceding that line, so downstream tooling can
+verify that the converted manifest was not altered, e.g. with "sed '$d' out.yaml |
+sha256sum". With --checksum-file, the hash is written to the given file instead or
+in addition. Resources are always serialized the same way, so the hash is stable
+across runs.
+
+If --report-format is set, a report listing the source and target version of every
+resource, and whether any fields were lost by the conversion, is written to stderr
+or the file given by --report-file. The converted resources are still written to
+stdout. With --report-format json, the report is a JSON object which additionally
+lists every field lost by a conversion, with its path and values, so that it can
+be processed by migration tooling.`))
 )
 
 var (
 	// Use this scheme as it has the internal cert-manager types
 	// and their conversion functions registered.
 	scheme = ctl.Scheme
+
+	// clusterScopedKinds are the cert-manager kinds that are not namespaced.
+	clusterScopedKinds = map[schema.GroupKind]bool{
+		{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.ClusterIssuerKind}: true,
+	}
 )
 
+// outputVersionEnv is the environment variable providing the output version
+// if --output-version is not set.
+const outputVersionEnv = "CMCTL_CONVERT_VERSION"
+
 // Options is a struct to support convert command
 type Options struct {
 	PrintFlags *genericclioptions.PrintFlags
 	Printer    printers.ResourcePrinter
 
 	OutputVersion string
+	// OutputVersionPerKind maps kinds to the group version they are output
+	// with, overriding OutputVersion
+	OutputVersionPerKind map[string]string
+	// If true, refuse to convert to a version that is not served by the
+	// cluster, if one is reachable
+	TargetServedOnly bool
+	// The apiVersion and kind set on objects which declare neither of them
+	DefaultAPIVersion string
+	DefaultKind       string
+	// The namespace set on all namespaced cert-manager objects
+	InputNamespace string
+	// If true, replace namespaces conflicting with InputNamespace
+	Force bool
+	// Format of the report summarizing the conversion, no report is written if empty
+	ReportFormat string
+	// File the report is written to, if empty it is written to stderr
+	ReportFile string
+	// File listing paths to convert in addition to the given filenames, one per line
+	FromFileList string
+	// If true, refuse to output objects which are missing required fields
+	RequireComplete bool
+	// Directory the converted objects are written to instead of stdout
+	OutputDir string
+	// If true, objects written to OutputDir are grouped into one file per kind
+	SplitByKind bool
+	// If true, objects written to OutputDir are written to files named after
+	// them, instead of mirroring the paths of the files they were read from
+	Flatten bool
+	// If true, only the latest status condition of every type is output
+	PruneStatusConditions bool
+	// If true, the metadata populated by the API server and the noise
+	// annotations of kubectl and cert-manager are dropped
+	Clean bool
+	// Prefixes of the annotations which are kept by Clean, all other
+	// annotations are dropped if set
+	KeepAnnotations []string
+	// If true, the input is a JSON array of objects read from stdin, and the
+	// converted objects are output as an array
+	StdinJSONArray bool
+	// If true, the converted objects are output as a JSON array of objects
+	// instead of a List, must be used with the json output format
+	JSONArray bool
+	// Predicates on the labels or annotations of objects, only matching
+	// objects are converted
+	ManagedByFilter []string
+	// If true, a '# sha256: <hash>' trailer over the output is appended
+	Checksum bool
+	// File the hash of the output is written to
+	ChecksumFile string
+	// If true, the converted objects are written back to the files they were
+	// read from instead of stdout
+	InPlace bool
+	// If true, a unified diff between every document and its converted form
+	// is output instead of the converted objects
+	Diff bool
+	// If true, warn about Certificates setting fields of feature gates which
+	// appear to be disabled in the cluster
+	CheckFeatureGates bool
+	// If true, fail instead of warning about fields of disabled feature gates
+	Strict bool
+	// If true, the YAML documents read are edited in place to keep their
+	// comments and the order of their fields
+	PreserveComments bool
+	// If true, objects which are not part of the cert-manager API groups are
+	// output unchanged instead of failing the conversion
+	SkipNonCertManager bool
+	// If false, objects which fail to convert are left out of the output
+	// instead of aborting the conversion, and their errors listed at the end
+	FailFast bool
+	// If true, nothing is output if any object fails to convert with
+	// --fail-fast=false
+	AllOrNothing bool
+
+	// managedByPredicates are the parsed predicates of ManagedByFilter
+	managedByPredicates []managedByPredicate
+	// outputVersions are the parsed versions of OutputVersionPerKind
+	outputVersions map[string]schema.GroupVersion
+	// outputPaths maps the local files read to the paths their objects are
+	// written to relative to OutputDir
+	outputPaths map[string]string
 
 	resource.FilenameOptions
 	genericclioptions.IOStreams
+	*factory.Factory
 }
 
 // NewOptions returns initialized Options
@@ -81,6 +378,7 @@ func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
 		IOStreams:  ioStreams,
 		PrintFlags: genericclioptions.NewPrintFlags("converted").WithDefaultOutput("yaml"),
+		FailFast:   true,
 	}
 }
 
@@ -96,24 +394,131 @@ func NewCmdConvert(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Complete())
-			cmdutil.CheckErr(o.Run())
+			cmdutil.CheckErr(o.Run(ctx))
 		},
 	}
 
-	cmd.Flags().StringVar(&o.OutputVersion, "output-version", o.OutputVersion, "Output the formatted object with the given group version (for ex: 'cert-manager.io/v1alpha3').")
+	cmd.Flags().StringVar(&o.OutputVersion, "output-version", o.OutputVersion, "Output the formatted object with the given group version (for ex: 'cert-manager.io/v1alpha3'), or the given version of its group (for ex: 'v1'). Defaults to the value of the "+outputVersionEnv+" environment variable, if set.")
+	cmd.Flags().StringToStringVar(&o.OutputVersionPerKind, "output-version-per-kind", o.OutputVersionPerKind, "Output the formatted objects of the given kinds with the given group versions, overriding --output-version (for ex: 'Certificate=cert-manager.io/v1,Issuer=cert-manager.io/v1beta1'). Kinds which are not listed are output with --output-version.")
+	cmd.Flags().BoolVar(&o.TargetServedOnly, "target-served-only", o.TargetServedOnly, "If true, refuse to convert to a version which is not served by the cluster. Has no effect if no cluster is reachable.")
+	cmd.Flags().StringVar(&o.DefaultAPIVersion, "default-apiversion", o.DefaultAPIVersion, "The apiVersion of objects which declare neither apiVersion nor kind, must be used in conjunction with --default-kind (for ex: 'cert-manager.io/v1alpha2').")
+	cmd.Flags().StringVar(&o.DefaultKind, "default-kind", o.DefaultKind, "The kind of objects which declare neither apiVersion nor kind, must be used in conjunction with --default-apiversion (for ex: 'Certificate').")
+	cmd.Flags().StringVar(&o.InputNamespace, "input-namespace", o.InputNamespace, "Set the namespace of all namespaced cert-manager resources to the given namespace. Cluster scoped resources are left unchanged.")
+	cmd.Flags().StringVar(&o.InputNamespace, "override-namespace", o.InputNamespace, "Alias of --input-namespace.")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "If true, replace the namespace of resources which already define a namespace that is different from --input-namespace.")
+	cmd.Flags().BoolVar(&o.RequireComplete, "require-complete", o.RequireComplete, "If true, fail if any converted cert-manager resource is missing a field required by its schema, instead of outputting it.")
+	cmd.Flags().StringVar(&o.OutputDir, "output-dir", o.OutputDir, "Write the converted resources to the given directory instead of stdout, mirroring the paths of the input files relative to the directories given as filename. Only supports the yaml and json output formats.")
+	cmd.Flags().BoolVar(&o.Flatten, "flatten", o.Flatten, "Write every resource to its own file in --output-dir, named after its kind, namespace and name, instead of mirroring the paths of the input files. Must be used in conjunction with --output-dir.")
+	cmd.Flags().BoolVar(&o.SplitByKind, "split-by-kind", o.SplitByKind, "Group the resources written to --output-dir into one file per kind, e.g. certificates.yaml, sorted by name. Must be used in conjunction with --output-dir.")
+	cmd.Flags().BoolVar(&o.PruneStatusConditions, "prune-status-conditions", o.PruneStatusConditions, "If true, trim the status conditions of every resource to the latest condition of every type.")
+	cmd.Flags().BoolVar(&o.Clean, "clean", o.Clean, "If true, drop the metadata populated by the API server, e.g. resourceVersion, uid and managedFields, and the annotations set by kubectl and the cert-manager controllers, e.g. to export resources to another cluster.")
+	cmd.Flags().StringSliceVar(&o.KeepAnnotations, "keep-annotations", o.KeepAnnotations, "Prefixes of the annotations to keep with --clean, e.g. 'example.com/'. All other annotations are dropped. May be repeated or comma separated. Must be used in conjunction with --clean.")
+	cmd.Flags().StringVar(&o.ReportFormat, "report-format", o.ReportFormat, fmt.Sprintf("If set, write a report summarizing the conversion of every resource in the given format. One of: (%s).", strings.Join(reportFormats, ", ")))
+	cmd.Flags().StringVar(&o.ReportFile, "report-file", o.ReportFile, "Write the report to the given file instead of stderr, must be used in conjunction with --report-format.")
 	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "Path to a file containing cert-manager resources to be converted.")
+	cmd.Flags().BoolVar(&o.StdinJSONArray, "stdin-json-array", o.StdinJSONArray, "Read a JSON array of objects from stdin instead of --filename, and output the converted objects as a JSON array with -o json, or as one document per object otherwise.")
+	cmd.Flags().BoolVar(&o.JSONArray, "json-array", o.JSONArray, "If true, output the converted objects as a JSON array of objects instead of a List, e.g. to iterate over them with 'jq .[]'. The array is output for a single object as well. Must be used in conjunction with -o json.")
+	cmd.Flags().StringArrayVar(&o.ManagedByFilter, "managed-by-filter", o.ManagedByFilter, "Only convert objects matching the given predicate, of the form 'labels:<selector>' or 'annotations:<selector>' using the label selector syntax (for ex: 'labels:app.kubernetes.io/managed-by=cert-manager'). May be repeated, objects have to match all predicates. Defaults to matching every object.")
+	cmd.Flags().BoolVar(&o.Checksum, "checksum", o.Checksum, "If true, append a '# sha256: <hash>' line to the output, where the hash is the SHA-256 of the output preceding that line. Only supports the yaml output format.")
+	cmd.Flags().StringVar(&o.ChecksumFile, "checksum-file", o.ChecksumFile, "Write the SHA-256 of the output, hex encoded, to the given file, e.g. to be stored next to the converted manifest.")
+	cmd.Flags().BoolVar(&o.InPlace, "in-place", o.InPlace, "If true, overwrite every file given as input with its converted resources instead of writing them to stdout. No file is modified if any of them fails to convert.")
+	cmd.Flags().BoolVar(&o.Diff, "diff", o.Diff, "If true, output a unified diff between every resource and its converted form instead of the converted resources, and exit with a non-zero code if any resource is changed by the conversion.")
+	cmd.Flags().BoolVar(&o.CheckFeatureGates, "check-feature-gates", o.CheckFeatureGates, "If true, warn about Certificates setting fields which require a feature gate, e.g. spec.additionalOutputFormats, if the gate appears to be disabled in the cluster. Detected by creating the Certificates in dry-run mode. Has no effect if no cluster is reachable.")
+	cmd.Flags().BoolVar(&o.Strict, "strict", o.Strict, "If true, fail instead of warning about fields of feature gates which appear to be disabled in the cluster. Must be used in conjunction with --check-feature-gates.")
+	cmd.Flags().BoolVar(&o.PreserveComments, "preserve-comments", o.PreserveComments, "If true, keep the comments and the order of fields of the YAML files given as input, by only editing the values changed by the conversion. Documents whose fields are restructured by the conversion are output as without this flag, with a warning. Only supports local files and the yaml output format.")
+	cmd.Flags().BoolVar(&o.SkipNonCertManager, "skip-non-cert-manager", o.SkipNonCertManager, "If true, output the objects which are not part of the cert-manager.io or acme.cert-manager.io API groups unchanged in their original position, instead of failing the conversion, e.g. to convert the output of 'helm template'.")
+	cmd.Flags().BoolVar(&o.FailFast, "fail-fast", o.FailFast, "If true, abort on the first object which fails to convert. If false, attempt to convert all objects, output the ones which succeeded and list the errors of all others at the end.")
+	cmd.Flags().BoolVar(&o.AllOrNothing, "all-or-nothing", o.AllOrNothing, "If true, output nothing if any object fails to convert, while still listing the errors of all objects. Must be used in conjunction with --fail-fast=false.")
+	cmd.Flags().StringVar(&o.FromFileList, "from-file-list", o.FromFileList, "Path to a file listing the files, directories or URLs to be converted, one per line. Blank lines and lines starting with '#' are ignored.")
 	o.PrintFlags.AddFlags(cmd)
 
+	// Converting doesn't require a cluster, it is only used to check the
+	// served versions if --target-served-only is set.
+	o.Factory = factory.NewOptional(ctx, cmd)
+
 	return cmd
 }
 
 // Complete collects information required to run Convert command from command line.
 func (o *Options) Complete() error {
+	if len(o.OutputVersion) == 0 {
+		o.OutputVersion = os.Getenv(outputVersionEnv)
+	}
+
+	if err := o.validateOutputVersion(); err != nil {
+		return err
+	}
+
+	if err := o.addFileList(); err != nil {
+		return err
+	}
+
+	if err := o.validateStdinJSONArray(); err != nil {
+		return err
+	}
+
+	if err := o.validateJSONArray(); err != nil {
+		return err
+	}
+
 	err := o.FilenameOptions.RequireFilenameOrKustomize()
 	if err != nil {
 		return err
 	}
 
+	if err := o.validateStdin(); err != nil {
+		return err
+	}
+
+	if err := o.validateDefaultType(); err != nil {
+		return err
+	}
+
+	if err := o.parseOutputVersionPerKind(); err != nil {
+		return err
+	}
+	o.resolveBareOutputVersion()
+
+	if err := o.parseManagedByFilter(); err != nil {
+		return err
+	}
+
+	if err := o.validateReport(); err != nil {
+		return err
+	}
+
+	if err := o.validateClean(); err != nil {
+		return err
+	}
+
+	if err := o.validateFeatureGates(); err != nil {
+		return err
+	}
+
+	if err := o.validateOutputDir(); err != nil {
+		return err
+	}
+
+	if err := o.validateChecksum(); err != nil {
+		return err
+	}
+
+	if err := o.validateInPlace(); err != nil {
+		return err
+	}
+
+	if err := o.validateDiff(); err != nil {
+		return err
+	}
+
+	if err := o.validatePreserveComments(); err != nil {
+		return err
+	}
+
+	if err := o.validateFailFast(); err != nil {
+		return err
+	}
+
 	// build the printer
 	o.Printer, err = o.PrintFlags.ToPrinter()
 	if err != nil {
@@ -124,12 +529,32 @@ func (o *Options) Complete() error {
 }
 
 // Run executes convert command
-func (o *Options) Run() error {
-	builder := new(resource.Builder)
+func (o *Options) Run(ctx context.Context) error {
+	// Objects are decoded into the internal types after reading them, so the
+	// version each object was read in is known for the report.
+	builder := new(resource.Builder).
+		Unstructured().
+		LocalParam(true)
 
-	r := builder.
-		WithScheme(scheme).
-		LocalParam(true).FilenameParam(false, &o.FilenameOptions).Flatten().Do()
+	// Directories are expanded here rather than by the builder, to warn
+	// about the files which are skipped
+	if err := o.expandDirectories(); err != nil {
+		return err
+	}
+
+	if o.StdinJSONArray {
+		in := readJSONArray(o.In)
+		defer in.Close()
+		o.In = in
+	}
+
+	builder, singleFile, closeFiles, err := o.filenameParam(builder)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+
+	r := builder.Flatten().Do()
 
 	if err := r.Err(); err != nil {
 		return err
@@ -140,11 +565,78 @@ func (o *Options) Run() error {
 	if err != nil {
 		return err
 	}
+	singleItemImplied = singleItemImplied || singleFile
 
 	if len(infos) == 0 {
+		if o.readsStdin() {
+			return fmt.Errorf("no objects passed to convert, no objects were read from stdin")
+		}
 		return fmt.Errorf("no objects passed to convert")
 	}
 
+	infos, err = o.filterManagedBy(infos)
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("none of the objects passed to convert match --managed-by-filter")
+	}
+
+	// The documents are kept as read, to be compared with the converted
+	// objects
+	var documents []diffDocument
+	if o.Diff {
+		documents, err = diffDocuments(infos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.SkipNonCertManager {
+		if err := passThroughNonCertManager(infos); err != nil {
+			return err
+		}
+	}
+
+	o.migrateLegacyHTTP01(infos)
+
+	if o.Clean {
+		for _, info := range infos {
+			cleanObject(info.Object, o.KeepAnnotations)
+		}
+	}
+
+	if o.PruneStatusConditions {
+		for _, info := range infos {
+			pruneStatusConditions(info.Object)
+		}
+	}
+
+	// With --fail-fast=false, the objects failing to convert are dropped and
+	// their errors returned after printing the others
+	total := len(infos)
+	var failures []error
+	if !o.FailFast {
+		infos, failures = dropUndecodable(infos)
+	}
+
+	sourceVersions, err := decodeInfos(infos)
+	if err != nil {
+		return err
+	}
+
+	if len(o.InputNamespace) > 0 {
+		if err := o.overrideNamespace(infos); err != nil {
+			return err
+		}
+	}
+
+	if o.CheckFeatureGates {
+		if err := o.checkFeatureGates(ctx, infos); err != nil {
+			return err
+		}
+	}
+
 	var specifiedOutputVersion schema.GroupVersion
 	if len(o.OutputVersion) > 0 {
 		specifiedOutputVersion, err = schema.ParseGroupVersion(o.OutputVersion)
@@ -153,27 +645,329 @@ func (o *Options) Run() error {
 		}
 	}
 
+	if o.TargetServedOnly {
+		if err := o.checkTargetServed(specifiedOutputVersion); err != nil {
+			return err
+		}
+		kinds := make([]string, 0, len(o.outputVersions))
+		for kind := range o.outputVersions {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		checked := map[schema.GroupVersion]bool{specifiedOutputVersion: true}
+		for _, kind := range kinds {
+			if checked[o.outputVersions[kind]] {
+				continue
+			}
+			checked[o.outputVersions[kind]] = true
+			if err := o.checkTargetServed(o.outputVersions[kind]); err != nil {
+				return err
+			}
+		}
+	}
+
 	factory := serializer.NewCodecFactory(scheme)
 	serializer := apijson.NewSerializerWithOptions(apijson.DefaultMetaFactory, scheme, scheme, apijson.SerializerOptions{})
 	encoder := factory.WithoutConversion().EncoderForVersion(serializer, nil)
-	objects, err := asVersionedObject(infos, !singleItemImplied, specifiedOutputVersion, encoder)
+	var report *conversionReport
+	if len(o.ReportFormat) > 0 {
+		report = newConversionReport(sourceVersions)
+	}
+	out := o.Out
+	defer func() { o.Out = out }()
+	var checksummed *bytes.Buffer
+	if o.checksumRequested() {
+		// The output is buffered to compute its checksum
+		checksummed = new(bytes.Buffer)
+		o.Out = checksummed
+	}
+
+	if !o.FailFast {
+		var errs []error
+		infos, errs = o.dropUnconvertible(infos, specifiedOutputVersion, encoder)
+		failures = append(failures, errs...)
+		if len(failures) > 0 && (o.AllOrNothing || len(infos) == 0) {
+			return conversionFailures(failures, total)
+		}
+	}
+
+	converted, err := o.convertObjects(infos, specifiedOutputVersion, encoder, report)
 	if err != nil {
 		return err
 	}
+	if o.RequireComplete {
+		if err := validateComplete(convertedRuntimeObjects(converted)...); err != nil {
+			return err
+		}
+	}
+
+	if err := o.writeConverted(converted, documents, !singleItemImplied, specifiedOutputVersion); err != nil {
+		return err
+	}
+
+	if checksummed != nil {
+		if err := o.writeChecksummed(out, checksummed.Bytes()); err != nil {
+			return err
+		}
+	}
 
-	return o.Printer.PrintObj(objects, o.Out)
+	if report != nil {
+		if err := o.writeReport(report); err != nil {
+			return err
+		}
+	}
+	if len(failures) > 0 {
+		return conversionFailures(failures, total)
+	}
+	return nil
 }
 
-// asVersionedObject converts a list of infos into a single object - either a List containing
-// the objects as children, or if only a single Object is present, as that object. The provided
-// version will be preferred as the conversion target, but the Object's mapping version will be
-// used if that version is not present.
-func asVersionedObject(infos []*resource.Info, forceList bool, specifiedOutputVersion schema.GroupVersion, encoder runtime.Encoder) (runtime.Object, error) {
-	objects, err := asVersionedObjects(infos, specifiedOutputVersion, encoder)
+// convertedObject is an object converted to its output version, along with
+// the source it was read from.
+type convertedObject struct {
+	source string
+	object runtime.Object
+}
+
+// convertObjects converts the objects in infos to their output version,
+// keeping track of the source every object was read from.
+func (o *Options) convertObjects(infos []*resource.Info, specifiedOutputVersion schema.GroupVersion, encoder runtime.Encoder, report *conversionReport) ([]convertedObject, error) {
+	converted := make([]convertedObject, 0, len(infos))
+	for _, info := range infos {
+		objects, err := asVersionedObjects([]*resource.Info{info}, specifiedOutputVersion, o.outputVersions, encoder, report)
+		if err != nil {
+			return nil, fmt.Errorf("error when converting %q from %s: %w", info.Name, info.Source, err)
+		}
+		for _, object := range objects {
+			converted = append(converted, convertedObject{source: info.Source, object: object})
+		}
+	}
+	return converted, nil
+}
+
+// convertedRuntimeObjects returns the objects of converted.
+func convertedRuntimeObjects(converted []convertedObject) []runtime.Object {
+	objects := make([]runtime.Object, 0, len(converted))
+	for _, c := range converted {
+		objects = append(objects, c.object)
+	}
+	return objects
+}
+
+// writeConverted writes the converted objects using the output mode selected
+// by the flags. documents are the objects as read, which are only used by
+// --diff. Without any output mode flag, the objects are printed to the output
+// as a List, or as a single object if only one was read and forceList is
+// false.
+func (o *Options) writeConverted(converted []convertedObject, documents []diffDocument, forceList bool, specifiedOutputVersion schema.GroupVersion) error {
+	switch {
+	case o.Diff:
+		return o.writeDiff(documents, convertedRuntimeObjects(converted))
+	case o.InPlace:
+		return o.writeInPlace(converted)
+	case len(o.OutputDir) > 0 && !o.Flatten && !o.SplitByKind:
+		return o.writeOutputTree(converted)
+	case len(o.OutputDir) > 0:
+		return o.writeOutputDir(convertedRuntimeObjects(converted))
+	case o.PreserveComments:
+		return o.writePreservingComments(converted)
+	case o.StdinJSONArray || o.JSONArray:
+		return o.printObjects(convertedRuntimeObjects(converted))
+	default:
+		object, err := asVersionedObject(convertedRuntimeObjects(converted), forceList, specifiedOutputVersion)
+		if err != nil {
+			return err
+		}
+		return o.Printer.PrintObj(object, o.Out)
+	}
+}
+
+// validateOutputVersion validates that OutputVersion is a version of the
+// cert-manager API groups registered in the scheme, listing the supported
+// versions if it is not.
+func (o *Options) validateOutputVersion() error {
+	if len(o.OutputVersion) == 0 {
+		return nil
+	}
+	if isBareVersion(o.OutputVersion) {
+		return o.validateBareOutputVersion()
+	}
+
+	var supported []string
+	for _, group := range []string{cmapi.SchemeGroupVersion.Group, cmacme.SchemeGroupVersion.Group} {
+		for _, version := range scheme.PrioritizedVersionsForGroup(group) {
+			if version.String() == o.OutputVersion {
+				return nil
+			}
+			supported = append(supported, version.String())
+		}
+	}
+	return fmt.Errorf("invalid --output-version %q: the version is not supported, must be one of: %s", o.OutputVersion, strings.Join(supported, ", "))
+}
+
+// parseOutputVersionPerKind parses OutputVersionPerKind, validating that
+// every kind is registered in the cert-manager scheme in the given version.
+func (o *Options) parseOutputVersionPerKind() error {
+	kinds := make([]string, 0, len(o.OutputVersionPerKind))
+	for kind := range o.OutputVersionPerKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	o.outputVersions = make(map[string]schema.GroupVersion, len(kinds))
+	for _, kind := range kinds {
+		version := o.OutputVersionPerKind[kind]
+		gv, err := schema.ParseGroupVersion(version)
+		if err != nil {
+			return fmt.Errorf("invalid --output-version-per-kind %s=%s: %w", kind, version, err)
+		}
+		if !scheme.IsVersionRegistered(gv) {
+			return fmt.Errorf("invalid --output-version-per-kind %s=%s: the version %q is not registered", kind, version, gv)
+		}
+		if !scheme.Recognizes(gv.WithKind(kind)) {
+			return fmt.Errorf("invalid --output-version-per-kind %s=%s: the kind %q is not registered in %q", kind, version, kind, gv)
+		}
+		o.outputVersions[kind] = gv
+	}
+	return nil
+}
+
+// decodeInfos decodes the unstructured objects in infos into the internal
+// cert-manager types, and returns the version each decoded object was read in.
+// Durations given as numbers are normalized before decoding.
+func decodeInfos(infos []*resource.Info) (map[runtime.Object]schema.GroupVersion, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder()
+	sourceVersions := make(map[runtime.Object]schema.GroupVersion, len(infos))
+	for _, info := range infos {
+		if info.Object == nil || isPassedThrough(info.Object) {
+			continue
+		}
+
+		obj, gvk, err := decodeObject(decoder, info.Object)
+		if err != nil {
+			return nil, fmt.Errorf("error when decoding %q from %s: %w", info.Name, info.Source, err)
+		}
+
+		info.Object = obj
+		sourceVersions[obj] = gvk.GroupVersion()
+	}
+
+	return sourceVersions, nil
+}
+
+// decodeObject decodes the unstructured object u into the internal types
+// using decoder, and returns the kind it was read in. Durations given as
+// numbers are normalized before decoding.
+func decodeObject(decoder runtime.Decoder, u runtime.Object) (runtime.Object, *schema.GroupVersionKind, error) {
+	if u, ok := u.(*unstructured.Unstructured); ok {
+		normalizeDurations(u)
+	}
+
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decoder.Decode(data, nil, nil)
+}
+
+// ToVersion converts the unstructured object u, given in any version
+// supported by the convert command, to the given version in the same way as
+// the convert command does.
+func ToVersion(u *unstructured.Unstructured, version schema.GroupVersion) (runtime.Object, error) {
+	obj, _, err := decodeObject(serializer.NewCodecFactory(scheme).UniversalDecoder(), u)
 	if err != nil {
 		return nil, err
 	}
+	return tryConvert(obj, version)
+}
+
+// checkTargetServed returns an error listing the served versions if the
+// given target version is not served by the cluster. If no target version is
+// given, the latest cert-manager.io version which is converted to by default
+// is checked. If no cluster is reachable, a warning is printed instead.
+func (o *Options) checkTargetServed(target schema.GroupVersion) error {
+	if target.Empty() {
+		target = scheme.PrioritizedVersionsForGroup(cmapi.SchemeGroupVersion.Group)[0]
+	}
+
+	if o.Factory == nil || o.KubeClient == nil {
+		fmt.Fprintf(o.ErrOut, "warning: no cluster configured, not checking whether %q is served\n", target)
+		return nil
+	}
+
+	client, err := o.Discovery()
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "warning: unable to reach the cluster, not checking whether %q is served: %v\n", target, err)
+		return nil
+	}
+	groups, err := client.ServerGroups()
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "warning: unable to reach the cluster, not checking whether %q is served: %v\n", target, err)
+		return nil
+	}
 
+	var served []string
+	for _, group := range groups.Groups {
+		if group.Name != target.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			if version.Version == target.Version {
+				return nil
+			}
+			served = append(served, version.GroupVersion)
+		}
+	}
+
+	if len(served) == 0 {
+		return fmt.Errorf("refusing to convert to %q: the API group %q is not served by the cluster", target, target.Group)
+	}
+	return fmt.Errorf("refusing to convert to %q as it is not served by the cluster, served versions are: %s", target, strings.Join(served, ", "))
+}
+
+// overrideNamespace sets the namespace of all namespaced cert-manager objects
+// in infos to the input namespace. Objects which already have a different
+// namespace set result in an error, unless --force is set.
+func (o *Options) overrideNamespace(infos []*resource.Info) error {
+	for _, info := range infos {
+		if info.Object == nil {
+			continue
+		}
+
+		gvks, _, err := scheme.ObjectKinds(info.Object)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) {
+				// Not a cert-manager object
+				continue
+			}
+			return err
+		}
+		if clusterScopedKinds[gvks[0].GroupKind()] {
+			continue
+		}
+
+		accessor, err := meta.Accessor(info.Object)
+		if err != nil {
+			return err
+		}
+
+		if ns := accessor.GetNamespace(); len(ns) > 0 && ns != o.InputNamespace && !o.Force {
+			return fmt.Errorf("the %s %q defines the namespace %q which conflicts with the input namespace %q, use --force to replace it",
+				gvks[0].Kind, accessor.GetName(), ns, o.InputNamespace)
+		}
+
+		accessor.SetNamespace(o.InputNamespace)
+		info.Namespace = o.InputNamespace
+	}
+
+	return nil
+}
+
+// asVersionedObject combines the converted objects into a single object -
+// either a List containing the objects as children, or if only a single Object
+// is present, as that object. The provided version will be preferred as the
+// version of the List, but v1 will be used if that version has no List.
+func asVersionedObject(objects []runtime.Object, forceList bool, specifiedOutputVersion schema.GroupVersion) (runtime.Object, error) {
 	var object runtime.Object
 	if len(objects) == 1 && !forceList {
 		object = objects[0]
@@ -196,32 +990,34 @@ func asVersionedObject(infos []*resource.Info, forceList bool, specifiedOutputVe
 		object = converted
 	}
 
-	actualVersion := object.GetObjectKind().GroupVersionKind()
-
-	if actualVersion.Version != specifiedOutputVersion.Version {
-		defaultVersionInfo := ""
-		if len(actualVersion.Version) > 0 {
-			defaultVersionInfo = fmt.Sprintf("Defaulting to %q", actualVersion.Version)
-		}
-		logf.V(logf.WarnLevel).Infof("info: the output version specified is invalid. %s\n", defaultVersionInfo)
-	}
-
 	return object, nil
 }
 
 // asVersionedObjects converts a list of infos into versioned objects. The provided
 // version will be preferred as the conversion target, but the Object's mapping version will be
-// used if that version is not present.
-func asVersionedObjects(infos []*resource.Info, specifiedOutputVersion schema.GroupVersion, encoder runtime.Encoder) ([]runtime.Object, error) {
+// used if that version is not present. The versions in perKindOutputVersions take precedence
+// for the objects of their kind. The conversion of every object is recorded in report, if not nil.
+func asVersionedObjects(infos []*resource.Info, specifiedOutputVersion schema.GroupVersion, perKindOutputVersions map[string]schema.GroupVersion, encoder runtime.Encoder, report *conversionReport) ([]runtime.Object, error) {
 	objects := []runtime.Object{}
 	for _, info := range infos {
 		if info.Object == nil {
 			continue
 		}
+		if isPassedThrough(info.Object) {
+			objects = append(objects, info.Object)
+			continue
+		}
+
+		outputVersion := specifiedOutputVersion
+		if gvks, _, err := scheme.ObjectKinds(info.Object); err == nil {
+			if version, ok := perKindOutputVersions[gvks[0].Kind]; ok {
+				outputVersion = version
+			}
+		}
 
 		targetVersions := []schema.GroupVersion{}
 		// objects that are not part of api.Scheme must be converted to JSON
-		if !specifiedOutputVersion.Empty() {
+		if !outputVersion.Empty() {
 			_, _, err := scheme.ObjectKinds(info.Object)
 			if err != nil {
 				if runtime.IsNotRegisteredError(err) {
@@ -236,7 +1032,7 @@ func asVersionedObjects(infos []*resource.Info, specifiedOutputVersion schema.Gr
 				return nil, err
 			}
 
-			targetVersions = append(targetVersions, specifiedOutputVersion)
+			targetVersions = append(targetVersions, outputVersion)
 		} else {
 			gvks, _, err := scheme.ObjectKinds(info.Object)
 			if err == nil {
@@ -251,6 +1047,7 @@ func asVersionedObjects(infos []*resource.Info, specifiedOutputVersion schema.Gr
 			return nil, err
 		}
 		objects = append(objects, converted)
+		report.add(info.Object, converted)
 	}
 
 	return objects, nil
@@ -0,0 +1,321 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+)
+
+func TestCheckTargetServed(t *testing.T) {
+	tests := map[string]struct {
+		servedGroupVersions []string
+		noCluster           bool
+		target              schema.GroupVersion
+
+		expErrMsg  string
+		expWarning string
+	}{
+		"served target version is accepted": {
+			servedGroupVersions: []string{"cert-manager.io/v1", "acme.cert-manager.io/v1"},
+			target:              schema.GroupVersion{Group: "cert-manager.io", Version: "v1"},
+		},
+		"no target version checks the latest version": {
+			servedGroupVersions: []string{"cert-manager.io/v1alpha2"},
+			expErrMsg:           `refusing to convert to "cert-manager.io/v1" as it is not served by the cluster, served versions are: cert-manager.io/v1alpha2`,
+		},
+		"target version which is not served is refused and served versions are listed": {
+			servedGroupVersions: []string{"cert-manager.io/v1", "cert-manager.io/v1beta1", "acme.cert-manager.io/v1"},
+			target:              schema.GroupVersion{Group: "cert-manager.io", Version: "v1alpha2"},
+			expErrMsg:           `refusing to convert to "cert-manager.io/v1alpha2" as it is not served by the cluster, served versions are: cert-manager.io/v1, cert-manager.io/v1beta1`,
+		},
+		"target group which is not served is refused": {
+			servedGroupVersions: []string{"apps/v1"},
+			target:              schema.GroupVersion{Group: "cert-manager.io", Version: "v1"},
+			expErrMsg:           `refusing to convert to "cert-manager.io/v1": the API group "cert-manager.io" is not served by the cluster`,
+		},
+		"no cluster only warns": {
+			noCluster:  true,
+			target:     schema.GroupVersion{Group: "cert-manager.io", Version: "v1alpha2"},
+			expWarning: "warning: no cluster configured, not checking whether \"cert-manager.io/v1alpha2\" is served\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			if !test.noCluster {
+				kubeClient := kubefake.NewSimpleClientset()
+				for _, gv := range test.servedGroupVersions {
+					kubeClient.Resources = append(kubeClient.Resources, &metav1.APIResourceList{GroupVersion: gv})
+				}
+				opts.Factory = &factory.Factory{KubeClient: kubeClient}
+			}
+
+			err := opts.checkTargetServed(test.target)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+
+			if errOut.String() != test.expWarning {
+				t.Errorf("got unexpected warning, expected: %q; actual: %q", test.expWarning, errOut.String())
+			}
+		})
+	}
+}
+
+func TestValidateDefaultType(t *testing.T) {
+	tests := map[string]struct {
+		defaultAPIVersion string
+		defaultKind       string
+		kustomize         string
+
+		expErrMsg string
+	}{
+		"no defaults is valid": {},
+		"registered default kind is valid": {
+			defaultAPIVersion: "cert-manager.io/v1alpha2",
+			defaultKind:       "Certificate",
+		},
+		"default apiVersion without default kind throws error": {
+			defaultAPIVersion: "cert-manager.io/v1alpha2",
+			expErrMsg:         "the --default-apiversion and --default-kind flags must be specified together",
+		},
+		"defaults in conjunction with kustomize throws error": {
+			defaultAPIVersion: "cert-manager.io/v1alpha2",
+			defaultKind:       "Certificate",
+			kustomize:         ".",
+			expErrMsg:         "the --default-apiversion and --default-kind flags cannot be used in conjunction with --kustomize",
+		},
+		"unregistered default kind throws error": {
+			defaultAPIVersion: "cert-manager.io/v1alpha2",
+			defaultKind:       "Deployment",
+			expErrMsg:         `the default kind "Deployment" in version "cert-manager.io/v1alpha2" is not a registered kind`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.DefaultAPIVersion = test.defaultAPIVersion
+			opts.DefaultKind = test.defaultKind
+			opts.Kustomize = test.kustomize
+
+			err := opts.validateDefaultType()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestCompleteOutputVersion(t *testing.T) {
+	tests := map[string]struct {
+		flag    string
+		env     string
+		perKind map[string]string
+
+		expOutputVersion  string
+		expOutputVersions map[string]schema.GroupVersion
+		expErrMsg         string
+	}{
+		"environment variable is used if the flag is not set": {
+			env:              "cert-manager.io/v1alpha3",
+			expOutputVersion: "cert-manager.io/v1alpha3",
+		},
+		"flag takes precedence over the environment variable": {
+			flag:             "cert-manager.io/v1",
+			env:              "cert-manager.io/v1alpha3",
+			expOutputVersion: "cert-manager.io/v1",
+		},
+		"no flag and no environment variable": {},
+		"acme version is valid": {
+			flag:             "acme.cert-manager.io/v1beta1",
+			expOutputVersion: "acme.cert-manager.io/v1beta1",
+		},
+		"unknown version throws error": {
+			flag:      "cert-manager.io/v1beta2",
+			expErrMsg: `invalid --output-version "cert-manager.io/v1beta2": the version is not supported, must be one of: cert-manager.io/v1, cert-manager.io/v1beta1, cert-manager.io/v1alpha3, cert-manager.io/v1alpha2, acme.cert-manager.io/v1alpha2, acme.cert-manager.io/v1alpha3, acme.cert-manager.io/v1beta1, acme.cert-manager.io/v1`,
+		},
+		"bare version is resolved for both groups": {
+			flag:             "v1",
+			expOutputVersion: "cert-manager.io/v1",
+			expOutputVersions: map[string]schema.GroupVersion{
+				"Challenge":     {Group: "acme.cert-manager.io", Version: "v1"},
+				"ChallengeList": {Group: "acme.cert-manager.io", Version: "v1"},
+				"Order":         {Group: "acme.cert-manager.io", Version: "v1"},
+				"OrderList":     {Group: "acme.cert-manager.io", Version: "v1"},
+			},
+		},
+		"bare version does not override the version of a kind": {
+			env:              "v1",
+			perKind:          map[string]string{"Order": "acme.cert-manager.io/v1beta1"},
+			expOutputVersion: "cert-manager.io/v1",
+			expOutputVersions: map[string]schema.GroupVersion{
+				"Challenge":     {Group: "acme.cert-manager.io", Version: "v1"},
+				"ChallengeList": {Group: "acme.cert-manager.io", Version: "v1"},
+				"Order":         {Group: "acme.cert-manager.io", Version: "v1beta1"},
+				"OrderList":     {Group: "acme.cert-manager.io", Version: "v1"},
+			},
+		},
+		"unknown bare version throws error": {
+			flag:      "v1beta2",
+			expErrMsg: `invalid --output-version "v1beta2": the version is not supported, must be one of: v1, v1beta1, v1alpha3, v1alpha2, or a group version`,
+		},
+		"version of another group throws error": {
+			env:       "apps/v1",
+			expErrMsg: `invalid --output-version "apps/v1": the version is not supported, must be one of: cert-manager.io/v1, cert-manager.io/v1beta1, cert-manager.io/v1alpha3, cert-manager.io/v1alpha2, acme.cert-manager.io/v1alpha2, acme.cert-manager.io/v1alpha3, acme.cert-manager.io/v1beta1, acme.cert-manager.io/v1`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(outputVersionEnv, test.env)
+
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.OutputVersion = test.flag
+			o.OutputVersionPerKind = test.perKind
+			o.Filenames = []string{"cert.yaml"}
+			err := o.Complete()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if o.OutputVersion != test.expOutputVersion {
+				t.Errorf("Unexpected output version; expected: %q, actual: %q", test.expOutputVersion, o.OutputVersion)
+			}
+			if test.expOutputVersions != nil && !reflect.DeepEqual(o.outputVersions, test.expOutputVersions) {
+				t.Errorf("Unexpected output versions per kind; expected: %v, actual: %v", test.expOutputVersions, o.outputVersions)
+			}
+		})
+	}
+}
+
+func TestCompleteOutputFormat(t *testing.T) {
+	tests := map[string]struct {
+		format string
+
+		expErrMsg string
+	}{
+		"yaml is the default output format": {},
+		"json output format": {
+			format: "json",
+		},
+		"unknown output format throws error": {
+			format:    "toml",
+			expErrMsg: `unable to match a printer suitable for the output format "toml", allowed formats are: go-template,go-template-file,json,jsonpath,jsonpath-as-json,jsonpath-file,name,template,templatefile,yaml`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.Filenames = []string{"cert.yaml"}
+			if len(test.format) > 0 {
+				o.PrintFlags.OutputFormat = &test.format
+			}
+
+			err := o.Complete()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if o.Printer == nil {
+				t.Errorf("Expected a printer to be built for the output format %q", test.format)
+			}
+		})
+	}
+}
+
+func TestParseOutputVersionPerKind(t *testing.T) {
+	tests := map[string]struct {
+		outputVersionPerKind map[string]string
+
+		expOutputVersions map[string]schema.GroupVersion
+		expErrMsg         string
+	}{
+		"no mappings is valid": {
+			expOutputVersions: map[string]schema.GroupVersion{},
+		},
+		"registered kinds and versions are parsed": {
+			outputVersionPerKind: map[string]string{"Certificate": "cert-manager.io/v1", "Issuer": "cert-manager.io/v1beta1"},
+			expOutputVersions: map[string]schema.GroupVersion{
+				"Certificate": {Group: "cert-manager.io", Version: "v1"},
+				"Issuer":      {Group: "cert-manager.io", Version: "v1beta1"},
+			},
+		},
+		"unregistered version throws error": {
+			outputVersionPerKind: map[string]string{"Certificate": "cert-manager.io/v1", "Issuer": "cert-manager.io/v2"},
+			expErrMsg:            `invalid --output-version-per-kind Issuer=cert-manager.io/v2: the version "cert-manager.io/v2" is not registered`,
+		},
+		"kind which is not registered in the version throws error": {
+			outputVersionPerKind: map[string]string{"Order": "cert-manager.io/v1"},
+			expErrMsg:            `invalid --output-version-per-kind Order=cert-manager.io/v1: the kind "Order" is not registered in "cert-manager.io/v1"`,
+		},
+		"invalid version throws error": {
+			outputVersionPerKind: map[string]string{"Certificate": "cert-manager.io/v1/v2"},
+			expErrMsg:            `invalid --output-version-per-kind Certificate=cert-manager.io/v1/v2: unexpected GroupVersion string: cert-manager.io/v1/v2`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.OutputVersionPerKind = test.outputVersionPerKind
+
+			err := opts.parseOutputVersionPerKind()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(opts.outputVersions, test.expOutputVersions) {
+				t.Errorf("Unexpected output versions; expected: %v, actual: %v", test.expOutputVersions, opts.outputVersions)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// validateDefaultType validates that the --default-apiversion and
+// --default-kind flags are set together, and refer to a kind registered in
+// the scheme.
+func (o *Options) validateDefaultType() error {
+	if len(o.DefaultAPIVersion) == 0 && len(o.DefaultKind) == 0 {
+		return nil
+	}
+	if len(o.DefaultAPIVersion) == 0 || len(o.DefaultKind) == 0 {
+		return errors.New("the --default-apiversion and --default-kind flags must be specified together")
+	}
+	if len(o.Kustomize) > 0 {
+		return errors.New("the --default-apiversion and --default-kind flags cannot be used in conjunction with --kustomize")
+	}
+
+	gv, err := schema.ParseGroupVersion(o.DefaultAPIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid --default-apiversion: %w", err)
+	}
+	if gvk := gv.WithKind(o.DefaultKind); !scheme.Recognizes(gvk) {
+		return fmt.Errorf("the default kind %q in version %q is not a registered kind", o.DefaultKind, o.DefaultAPIVersion)
+	}
+
+	return nil
+}
+
+// withDefaultType returns the documents read from r, setting the default
+// apiVersion and kind on every object which doesn't declare them. Items of a
+// List are defaulted as well. path is the file r reads, or "-" for the input
+// stream, and the returned stream can be passed to the resource.Builder in
+// place of r, so that every file is still read as its own source.
+func (o *Options) withDefaultType(r io.Reader, path string) (io.Reader, error) {
+	var out bytes.Buffer
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				return &out, nil
+			}
+			return nil, fmt.Errorf("error when decoding %q: %w", path, err)
+		}
+		// Skip empty documents
+		if obj == nil {
+			continue
+		}
+
+		o.setDefaultType(obj)
+		if items, ok := obj["items"].([]interface{}); ok && obj["kind"] == "List" {
+			for _, item := range items {
+				if item, ok := item.(map[string]interface{}); ok {
+					o.setDefaultType(item)
+				}
+			}
+		}
+
+		doc, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&out, "---\n%s\n", doc)
+	}
+}
+
+// setDefaultType sets the default apiVersion and kind on obj, if obj
+// declares neither of them.
+func (o *Options) setDefaultType(obj map[string]interface{}) {
+	if _, ok := obj["apiVersion"]; ok {
+		return
+	}
+	if _, ok := obj["kind"]; ok {
+		return
+	}
+	obj["apiVersion"] = o.DefaultAPIVersion
+	obj["kind"] = o.DefaultKind
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestWithDefaultType(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expOutput string
+		expErrMsg string
+	}{
+		"objects without a type are defaulted": {
+			input: `metadata:
+  name: crt-1
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret-1
+---
+`,
+			expOutput: `---
+{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"crt-1"}}
+---
+{"apiVersion":"v1","kind":"Secret","metadata":{"name":"secret-1"}}
+`,
+		},
+		"items of a List are defaulted": {
+			input: `{"apiVersion":"v1","kind":"List","items":[{"metadata":{"name":"crt-1"}}]}`,
+			expOutput: `---
+{"apiVersion":"v1","items":[{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"crt-1"}}],"kind":"List"}
+`,
+		},
+		"byte order mark is stripped and CRLF line endings are tolerated": {
+			input: "\ufeffmetadata:\r\n  name: crt-1\r\n---\r\nmetadata:\r\n  name: crt-2\r\n",
+			expOutput: `---
+{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"crt-1"}}
+---
+{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"crt-2"}}
+`,
+		},
+		"input with a UTF-16 byte order mark is decoded as UTF-16": {
+			input: encodeUTF16("metadata:\r\n  name: crt-1\r\n"),
+			expOutput: `---
+{"apiVersion":"cert-manager.io/v1","kind":"Certificate","metadata":{"name":"crt-1"}}
+`,
+		},
+		"invalid document returns an error": {
+			input:     "metadata: [",
+			expErrMsg: `error when decoding "-": error converting YAML to JSON: yaml: line 1: did not find expected node content`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{
+				DefaultAPIVersion: "cert-manager.io/v1",
+				DefaultKind:       "Certificate",
+				IOStreams:         genericclioptions.IOStreams{In: strings.NewReader(test.input)},
+			}
+
+			in, err := o.withDefaultType(o.openMixedDocuments("-"), "-")
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			output, err := io.ReadAll(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, output)
+			}
+		})
+	}
+}
+
+func encodeUTF16(s string) string {
+	encoded, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().String(s)
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines shown around every change
+// by --diff.
+const diffContextLines = 3
+
+// diffDocument is a document read by convert, kept to be compared with its
+// converted form by --diff.
+type diffDocument struct {
+	// id identifies the document in the diff headers
+	id         string
+	apiVersion string
+	content    string
+}
+
+// validateDiff validates the --diff flag. The diff replaces the output, so it
+// cannot be combined with flags writing the output elsewhere.
+func (o *Options) validateDiff() error {
+	if !o.Diff {
+		return nil
+	}
+	if len(o.OutputDir) > 0 || o.InPlace || o.checksumRequested() {
+		return errors.New("the --diff flag cannot be used in conjunction with the --output-dir, --in-place, --checksum or --checksum-file flags")
+	}
+	return nil
+}
+
+// diffDocuments returns the documents read into infos, before they get
+// decoded and converted.
+func diffDocuments(infos []*resource.Info) ([]diffDocument, error) {
+	var documents []diffDocument
+	for _, info := range infos {
+		if info.Object == nil {
+			continue
+		}
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		content, err := yaml.Marshal(normalizeForDiff(u.Object))
+		if err != nil {
+			return nil, err
+		}
+
+		id := u.GetKind() + " " + u.GetName()
+		if len(u.GetNamespace()) > 0 {
+			id = u.GetKind() + " " + u.GetNamespace() + "/" + u.GetName()
+		}
+		if len(info.Source) > 0 {
+			id = info.Source + ": " + id
+		}
+		documents = append(documents, diffDocument{id: id, apiVersion: u.GetAPIVersion(), content: string(content)})
+	}
+	return documents, nil
+}
+
+// writeDiff writes a unified diff between every document and its converted
+// object, in YAML with sorted keys. Documents which are not changed by the
+// conversion are not written. An error is returned if any document changed,
+// so that --diff can be used to check whether manifests need to be migrated.
+func (o *Options) writeDiff(documents []diffDocument, objects []runtime.Object) error {
+	if len(documents) != len(objects) {
+		return fmt.Errorf("%d documents were read, but %d objects were converted", len(documents), len(objects))
+	}
+
+	format := "yaml"
+	flags := *o.PrintFlags
+	flags.OutputFormat = &format
+
+	changed := 0
+	for i, document := range documents {
+		// Every object gets its own printer, so that no YAML document
+		// separators are written
+		printer, err := flags.ToPrinter()
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := printer.PrintObj(objects[i], &buf); err != nil {
+			return err
+		}
+		converted, apiVersion, err := normalizeYAMLForDiff(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		if converted == document.content {
+			continue
+		}
+
+		changed++
+		err = difflib.WriteUnifiedDiff(o.Out, difflib.UnifiedDiff{
+			A:        splitLines(document.content),
+			B:        splitLines(converted),
+			FromFile: fmt.Sprintf("%s (%s)", document.id, document.apiVersion),
+			ToFile:   fmt.Sprintf("%s (%s)", document.id, apiVersion),
+			Context:  diffContextLines,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if changed > 0 {
+		return fmt.Errorf("%d of %d documents are changed by the conversion", changed, len(documents))
+	}
+	return nil
+}
+
+// splitLines splits content into lines, keeping the line endings.
+// difflib.SplitLines returns an additional empty line if content ends with a
+// line ending.
+func splitLines(content string) []string {
+	return difflib.SplitLines(strings.TrimSuffix(content, "\n"))
+}
+
+// normalizeYAMLForDiff returns the YAML document data normalized by
+// normalizeForDiff, and its apiVersion.
+func normalizeYAMLForDiff(data []byte) (string, string, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return "", "", err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", "", err
+	}
+	object = normalizeForDiff(object)
+	content, err := yaml.Marshal(object)
+	if err != nil {
+		return "", "", err
+	}
+	apiVersion, _ := object["apiVersion"].(string)
+	return string(content), apiVersion, nil
+}
+
+// normalizeForDiff returns a copy of object without the fields the
+// serialization of the cert-manager types adds to every object, i.e. a null
+// creationTimestamp and an empty status, so that they don't show up as
+// changes.
+func normalizeForDiff(object map[string]interface{}) map[string]interface{} {
+	object = runtime.DeepCopyJSON(object)
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		if timestamp, ok := metadata["creationTimestamp"]; ok && timestamp == nil {
+			delete(metadata, "creationTimestamp")
+		}
+	}
+	if status, ok := object["status"].(map[string]interface{}); ok && len(status) == 0 {
+		delete(object, "status")
+	}
+	return object
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRunDiff(t *testing.T) {
+	const (
+		certificate = `apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: crt
+  namespace: default
+spec:
+  secretName: crt-tls
+  issuerRef:
+    name: ca
+  keyAlgorithm: ecdsa
+`
+		issuer = `apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  name: ca
+  namespace: default
+spec:
+  ca:
+    secretName: ca-key-pair
+`
+	)
+
+	tests := map[string]struct {
+		input         string
+		outputVersion string
+		expOutput     string
+		expErrMsg     string
+	}{
+		"changed documents are output as a unified diff": {
+			input:         certificate + "---\n" + issuer,
+			outputVersion: "cert-manager.io/v1",
+			expOutput: `--- stdin: Certificate default/crt (cert-manager.io/v1alpha2)
++++ stdin: Certificate default/crt (cert-manager.io/v1)
+@@ -1,4 +1,4 @@
+-apiVersion: cert-manager.io/v1alpha2
++apiVersion: cert-manager.io/v1
+ kind: Certificate
+ metadata:
+   name: crt
+@@ -6,5 +6,6 @@
+ spec:
+   issuerRef:
+     name: ca
+-  keyAlgorithm: ecdsa
++  privateKey:
++    algorithm: ECDSA
+   secretName: crt-tls
+`,
+			expErrMsg: "1 of 2 documents are changed by the conversion",
+		},
+		"nothing is output if no document is changed": {
+			input:         issuer,
+			outputVersion: "cert-manager.io/v1",
+			expOutput:     "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.input)
+
+			o := NewOptions(streams)
+			o.Filenames = []string{"-"}
+			o.OutputVersion = test.outputVersion
+			o.Diff = true
+			if err := o.Complete(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(context.TODO())
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%q\nactual: \n%q", test.expOutput, out.String())
+			}
+		})
+	}
+}
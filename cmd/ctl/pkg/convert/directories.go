@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// manifestExtensions are the extensions of the files converted when a
+// directory is given as filename.
+var manifestExtensions = []string{".json", ".yaml", ".yml"}
+
+// expandDirectories replaces every directory given as filename by the
+// manifest files it contains, descending into subdirectories if --recursive
+// is set. A warning is printed for every other file, which is skipped. Stdin
+// and URLs are kept as given. The path every local file is written to by
+// --output-dir is recorded.
+func (o *Options) expandDirectories() error {
+	filenames := make([]string, 0, len(o.Filenames))
+	for _, filename := range o.Filenames {
+		if filename == stdinFilename || strings.HasPrefix(filename, "http://") || strings.HasPrefix(filename, "https://") {
+			filenames = append(filenames, filename)
+			continue
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil || !info.IsDir() {
+			// Missing files are reported when reading them
+			filenames = append(filenames, filename)
+			if err == nil {
+				if err := o.recordOutputPath(filename, ""); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		files, skipped, err := expandDirectory(filename, o.Recursive)
+		if err != nil {
+			return fmt.Errorf("error when reading the directory %q: %w", filename, err)
+		}
+		for _, file := range skipped {
+			fmt.Fprintf(o.ErrOut, "warning: skipping %q, only files with the extensions %s are converted\n", file, strings.Join(manifestExtensions, ", "))
+		}
+		for _, file := range files {
+			if err := o.recordOutputPath(file, filename); err != nil {
+				return err
+			}
+		}
+		filenames = append(filenames, files...)
+	}
+
+	o.Filenames = filenames
+	return nil
+}
+
+// expandDirectory returns the manifest files in dir and the other files
+// which are skipped, both sorted lexically by path. Subdirectories are only
+// descended into if recursive is true.
+func expandDirectory(dir string, recursive bool) ([]string, []string, error) {
+	var files, skipped []string
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if p != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isManifest(p) {
+			files = append(files, p)
+		} else {
+			skipped = append(skipped, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, skipped, nil
+}
+
+// isManifest returns true if path has one of the manifestExtensions.
+func isManifest(path string) bool {
+	ext := filepath.Ext(path)
+	for _, manifestExtension := range manifestExtensions {
+		if ext == manifestExtension {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestExpandDirectories(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"b.yaml", "a.json", "README.md", "nested/c.yml", "nested/notes.txt"} {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(file string) string {
+		return filepath.Join(dir, file)
+	}
+
+	tests := map[string]struct {
+		filenames []string
+		recursive bool
+
+		expFilenames []string
+		expWarnings  string
+	}{
+		"directory is expanded to its manifests in lexical order": {
+			filenames:    []string{dir},
+			expFilenames: []string{join("a.json"), join("b.yaml")},
+			expWarnings:  `warning: skipping "` + join("README.md") + `", only files with the extensions .json, .yaml, .yml are converted` + "\n",
+		},
+		"subdirectories are expanded if recursive": {
+			filenames:    []string{dir},
+			recursive:    true,
+			expFilenames: []string{join("a.json"), join("b.yaml"), join("nested/c.yml")},
+			expWarnings: `warning: skipping "` + join("README.md") + `", only files with the extensions .json, .yaml, .yml are converted` + "\n" +
+				`warning: skipping "` + join("nested/notes.txt") + `", only files with the extensions .json, .yaml, .yml are converted` + "\n",
+		},
+		"files, stdin and URLs are kept in order": {
+			filenames:    []string{"-", join("nested/notes.txt"), "https://example.com/crt.yaml", join("nested")},
+			expFilenames: []string{"-", join("nested/notes.txt"), "https://example.com/crt.yaml", join("nested/c.yml")},
+			expWarnings:  `warning: skipping "` + join("nested/notes.txt") + `", only files with the extensions .json, .yaml, .yml are converted` + "\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Filenames = test.filenames
+			o.Recursive = test.recursive
+
+			if err := o.expandDirectories(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(o.Filenames, test.expFilenames) {
+				t.Errorf("Unexpected filenames; expected: \n%v\nactual: \n%v", test.expFilenames, o.Filenames)
+			}
+			if errOut.String() != test.expWarnings {
+				t.Errorf("Unexpected warnings; expected: \n%s\nactual: \n%s", test.expWarnings, errOut.String())
+			}
+		})
+	}
+}
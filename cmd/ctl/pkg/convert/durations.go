@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// durationFields are the paths of the duration fields of the cert-manager
+// kinds. The paths are the same in all versions.
+var durationFields = map[schema.GroupKind][][]string{
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.CertificateKind}: {
+		{"spec", "duration"},
+		{"spec", "renewBefore"},
+	},
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.CertificateRequestKind}: {
+		{"spec", "duration"},
+	},
+	{Group: cmacme.SchemeGroupVersion.Group, Kind: cmacme.OrderKind}: {
+		{"spec", "duration"},
+	},
+}
+
+// normalizeDurations replaces the duration fields of obj which are given as a
+// number instead of a duration string, e.g. `duration: 3600` or
+// `duration: "3600"`, with the equivalent duration string. Such numbers are
+// interpreted as seconds. Other values are left unchanged, so that invalid
+// durations are still reported when decoding obj.
+func normalizeDurations(obj *unstructured.Unstructured) {
+	for _, path := range durationFields[obj.GroupVersionKind().GroupKind()] {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, path...)
+		if !found || err != nil {
+			continue
+		}
+		if duration, ok := numericDuration(value); ok {
+			// Cannot fail as the parent of the field exists
+			_ = unstructured.SetNestedField(obj.Object, duration.String(), path...)
+		}
+	}
+}
+
+// numericDuration returns the duration in seconds given by value, if value is
+// a number or a string containing only a number.
+func numericDuration(value interface{}) (time.Duration, bool) {
+	var seconds float64
+	switch value := value.(type) {
+	case int64:
+		seconds = float64(value)
+	case float64:
+		seconds = value
+	case string:
+		var err error
+		seconds, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNormalizeDurations(t *testing.T) {
+	tests := map[string]struct {
+		input  map[string]interface{}
+		expObj map[string]interface{}
+	}{
+		"numbers and numeric strings are converted to durations": {
+			input: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1alpha2",
+				"kind":       "Certificate",
+				"spec": map[string]interface{}{
+					"duration":    int64(7776000),
+					"renewBefore": "1296000",
+				},
+			},
+			expObj: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1alpha2",
+				"kind":       "Certificate",
+				"spec": map[string]interface{}{
+					"duration":    "2160h0m0s",
+					"renewBefore": "360h0m0s",
+				},
+			},
+		},
+		"fractional seconds are kept": {
+			input: map[string]interface{}{
+				"apiVersion": "acme.cert-manager.io/v1alpha2",
+				"kind":       "Order",
+				"spec": map[string]interface{}{
+					"duration": float64(1.5),
+				},
+			},
+			expObj: map[string]interface{}{
+				"apiVersion": "acme.cert-manager.io/v1alpha2",
+				"kind":       "Order",
+				"spec": map[string]interface{}{
+					"duration": "1.5s",
+				},
+			},
+		},
+		"duration strings and invalid values are left unchanged": {
+			input: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1alpha3",
+				"kind":       "Certificate",
+				"spec": map[string]interface{}{
+					"duration":    "2160h",
+					"renewBefore": "soon",
+				},
+			},
+			expObj: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1alpha3",
+				"kind":       "Certificate",
+				"spec": map[string]interface{}{
+					"duration":    "2160h",
+					"renewBefore": "soon",
+				},
+			},
+		},
+		"fields of other kinds are left unchanged": {
+			input: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"spec": map[string]interface{}{
+					"duration": int64(60),
+				},
+			},
+			expObj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"spec": map[string]interface{}{
+					"duration": int64(60),
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: test.input}
+			normalizeDurations(obj)
+			if !reflect.DeepEqual(obj.Object, test.expObj) {
+				t.Errorf("Unexpected object; expected: \n%v\nactual: \n%v", test.expObj, obj.Object)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// validateFailFast validates the --fail-fast and --all-or-nothing flags.
+// Objects cannot be left out when rewriting or diffing the files they were
+// read from.
+func (o *Options) validateFailFast() error {
+	if o.AllOrNothing && o.FailFast {
+		return errors.New("the --all-or-nothing flag must be used in conjunction with --fail-fast=false")
+	}
+	if !o.FailFast && (o.InPlace || o.Diff) {
+		return errors.New("the --fail-fast=false flag cannot be used in conjunction with the --in-place or --diff flags")
+	}
+	return nil
+}
+
+// dropUndecodable returns the infos whose objects can be decoded into the
+// internal types, and the errors of the others.
+func dropUndecodable(infos []*resource.Info) ([]*resource.Info, []error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDecoder()
+	var decodable []*resource.Info
+	var errs []error
+	for _, info := range infos {
+		if info.Object == nil || isPassedThrough(info.Object) {
+			decodable = append(decodable, info)
+			continue
+		}
+		if _, _, err := decodeObject(decoder, info.Object); err != nil {
+			errs = append(errs, fmt.Errorf("error when decoding %q from %s: %w", info.Name, info.Source, err))
+			continue
+		}
+		decodable = append(decodable, info)
+	}
+	return decodable, errs
+}
+
+// dropUnconvertible returns the infos whose objects can be converted to
+// their output version, and the errors of the others. infos have to be
+// decoded into the internal types.
+func (o *Options) dropUnconvertible(infos []*resource.Info, specifiedOutputVersion schema.GroupVersion, encoder runtime.Encoder) ([]*resource.Info, []error) {
+	var convertible []*resource.Info
+	var errs []error
+	for _, info := range infos {
+		if _, err := asVersionedObjects([]*resource.Info{info}, specifiedOutputVersion, o.outputVersions, encoder, nil); err != nil {
+			errs = append(errs, fmt.Errorf("error when converting %q from %s: %w", info.Name, info.Source, err))
+			continue
+		}
+		convertible = append(convertible, info)
+	}
+	return convertible, errs
+}
+
+// conversionFailures returns an error listing errs, the errors of the
+// objects which failed to convert out of total objects.
+func conversionFailures(errs []error, total int) error {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = "\t" + err.Error()
+	}
+	return fmt.Errorf("%d of %d objects failed to convert:\n%s", len(errs), total, strings.Join(messages, "\n"))
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestValidateFailFast(t *testing.T) {
+	tests := map[string]struct {
+		modify    func(o *Options)
+		expErrMsg string
+	}{
+		"--fail-fast is valid": {
+			modify: func(o *Options) {},
+		},
+		"--all-or-nothing with --fail-fast throws error": {
+			modify:    func(o *Options) { o.AllOrNothing = true },
+			expErrMsg: "the --all-or-nothing flag must be used in conjunction with --fail-fast=false",
+		},
+		"--all-or-nothing with --fail-fast=false is valid": {
+			modify: func(o *Options) { o.FailFast = false; o.AllOrNothing = true },
+		},
+		"--fail-fast=false with --in-place throws error": {
+			modify:    func(o *Options) { o.FailFast = false; o.InPlace = true },
+			expErrMsg: "the --fail-fast=false flag cannot be used in conjunction with the --in-place or --diff flags",
+		},
+		"--fail-fast=false with --diff throws error": {
+			modify:    func(o *Options) { o.FailFast = false; o.Diff = true },
+			expErrMsg: "the --fail-fast=false flag cannot be used in conjunction with the --in-place or --diff flags",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			test.modify(o)
+
+			err := o.validateFailFast()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// featureGatedField is a field of Certificates which is rejected by the
+// webhook unless the feature gate is enabled.
+type featureGatedField struct {
+	path string
+	gate featuregate.Feature
+	set  func(crt *cmapi.Certificate) bool
+}
+
+var featureGatedFields = []featureGatedField{
+	{
+		path: "spec.additionalOutputFormats",
+		gate: feature.AdditionalCertificateOutputFormats,
+		set:  func(crt *cmapi.Certificate) bool { return len(crt.Spec.AdditionalOutputFormats) > 0 },
+	},
+	{
+		path: "spec.literalSubject",
+		gate: feature.LiteralCertificateSubject,
+		set:  func(crt *cmapi.Certificate) bool { return len(crt.Spec.LiteralSubject) > 0 },
+	},
+}
+
+// validateFeatureGates validates the --check-feature-gates and --strict flags.
+func (o *Options) validateFeatureGates() error {
+	if o.Strict && !o.CheckFeatureGates {
+		return errors.New("the --strict flag must be used in conjunction with --check-feature-gates")
+	}
+	return nil
+}
+
+// checkFeatureGates warns about every Certificate in infos setting a field of
+// a feature gate which appears to be disabled in the cluster, so that the
+// Certificate would be rejected on apply. Whether a gate is disabled is
+// detected by creating the Certificate in dry-run mode and looking for the
+// field being forbidden by the webhook. An error is returned instead if
+// --strict is set. infos have to be decoded into the internal types.
+func (o *Options) checkFeatureGates(ctx context.Context, infos []*resource.Info) error {
+	if o.Factory == nil || o.CMClient == nil {
+		fmt.Fprintln(o.ErrOut, "warning: no cluster configured, not checking whether the feature gates of the fields used are enabled")
+		return nil
+	}
+
+	rejected := 0
+	for _, info := range infos {
+		if info.Object == nil {
+			continue
+		}
+		obj, err := tryConvert(info.Object, cmapi.SchemeGroupVersion)
+		if err != nil {
+			continue
+		}
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			continue
+		}
+		var gated []featureGatedField
+		for _, f := range featureGatedFields {
+			if f.set(crt) {
+				gated = append(gated, f)
+			}
+		}
+		if len(gated) == 0 {
+			continue
+		}
+
+		denial, err := o.dryRunDenial(ctx, crt)
+		if err != nil {
+			fmt.Fprintf(o.ErrOut, "warning: unable to check the feature gates of the fields of Certificate %q: %v\n", crt.Name, err)
+			continue
+		}
+		for _, f := range gated {
+			// The webhook denies requests with the aggregated field errors
+			// as message, e.g. "spec.literalSubject: Forbidden: ..."
+			if !strings.Contains(denial, f.path+": "+field.ErrorTypeForbidden.String()) {
+				continue
+			}
+			rejected++
+			fmt.Fprintf(o.ErrOut, "warning: Certificate %q sets %s, but the feature gate %s appears to be disabled in the cluster, so the Certificate will be rejected\n",
+				crt.Name, f.path, f.gate)
+		}
+	}
+
+	if rejected > 0 && o.Strict {
+		return fmt.Errorf("refusing to convert: %d fields require feature gates which appear to be disabled in the cluster", rejected)
+	}
+	return nil
+}
+
+// dryRunDenial creates crt in dry-run mode and returns the message of the
+// webhook denying it, or an empty string if it was admitted. Errors not
+// caused by admission, e.g. as the cluster cannot be reached, are returned.
+func (o *Options) dryRunDenial(ctx context.Context, crt *cmapi.Certificate) (string, error) {
+	crt = crt.DeepCopy()
+	crt.ResourceVersion = ""
+	crt.UID = ""
+	namespace := crt.Namespace
+	if len(namespace) == 0 {
+		namespace = o.Namespace
+	}
+
+	_, err := o.CMClient.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	switch {
+	case err == nil, apierrors.IsAlreadyExists(err):
+		return "", nil
+	case apierrors.IsNotAcceptable(err), apierrors.IsInvalid(err):
+		return err.Error(), nil
+	default:
+		return "", err
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+func TestCheckFeatureGates(t *testing.T) {
+	const denial = `admission webhook "webhook.cert-manager.io" denied the request: spec.additionalOutputFormats: Forbidden: feature gate AdditionalCertificateOutputFormats must be enabled`
+	notAcceptable := &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReasonNotAcceptable,
+		Message: denial,
+	}}
+
+	gated := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "gated", Namespace: "my-namespace", ResourceVersion: "1"},
+		Spec: cmapi.CertificateSpec{
+			SecretName:              "gated",
+			AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{{Type: cmapi.CertificateOutputFormatCombinedPEM}},
+		},
+	}
+	plain := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "my-namespace"},
+		Spec:       cmapi.CertificateSpec{SecretName: "plain"},
+	}
+
+	tests := map[string]struct {
+		certificates []*cmapi.Certificate
+		noCluster    bool
+		createErr    error
+		strict       bool
+
+		expCreates int
+		expErrMsg  string
+		expWarning string
+	}{
+		"Certificates without gated fields are not checked": {
+			certificates: []*cmapi.Certificate{plain},
+		},
+		"gated field admitted by the cluster": {
+			certificates: []*cmapi.Certificate{gated, plain},
+			expCreates:   1,
+		},
+		"gated field of a disabled gate is warned about": {
+			certificates: []*cmapi.Certificate{gated},
+			createErr:    notAcceptable,
+			expCreates:   1,
+			expWarning:   "warning: Certificate \"gated\" sets spec.additionalOutputFormats, but the feature gate AdditionalCertificateOutputFormats appears to be disabled in the cluster, so the Certificate will be rejected\n",
+		},
+		"gated field of a disabled gate fails with --strict": {
+			certificates: []*cmapi.Certificate{gated},
+			createErr:    notAcceptable,
+			strict:       true,
+			expCreates:   1,
+			expErrMsg:    "refusing to convert: 1 fields require feature gates which appear to be disabled in the cluster",
+			expWarning:   "warning: Certificate \"gated\" sets spec.additionalOutputFormats, but the feature gate AdditionalCertificateOutputFormats appears to be disabled in the cluster, so the Certificate will be rejected\n",
+		},
+		"unreachable cluster only warns": {
+			certificates: []*cmapi.Certificate{gated},
+			createErr:    errors.New("connection refused"),
+			strict:       true,
+			expCreates:   1,
+			expWarning:   "warning: unable to check the feature gates of the fields of Certificate \"gated\": connection refused\n",
+		},
+		"no cluster only warns": {
+			certificates: []*cmapi.Certificate{gated},
+			noCluster:    true,
+			expWarning:   "warning: no cluster configured, not checking whether the feature gates of the fields used are enabled\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var infos []*resource.Info
+			for _, crt := range test.certificates {
+				obj, err := scheme.ConvertToVersion(crt.DeepCopy(), runtime.InternalGroupVersioner)
+				if err != nil {
+					t.Fatal(err)
+				}
+				infos = append(infos, &resource.Info{Name: crt.Name, Object: obj})
+			}
+
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.Strict = test.strict
+			cmClient := cmfake.NewSimpleClientset()
+			cmClient.PrependReactor("create", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+				if crt := action.(coretesting.CreateAction).GetObject().(*cmapi.Certificate); len(crt.ResourceVersion) > 0 {
+					t.Errorf("expected the resourceVersion to be cleared, got %q", crt.ResourceVersion)
+				}
+				return true, nil, test.createErr
+			})
+			if !test.noCluster {
+				opts.Factory = &factory.Factory{CMClient: cmClient}
+			}
+
+			err := opts.checkFeatureGates(context.TODO(), infos)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+
+			if errOut.String() != test.expWarning {
+				t.Errorf("got unexpected warning, expected: %q; actual: %q", test.expWarning, errOut.String())
+			}
+			if creates := len(cmClient.Actions()); creates != test.expCreates {
+				t.Errorf("expected %d dry-run creates, got %d", test.expCreates, creates)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// addFileList adds the paths listed in the file given by --from-file-list to
+// the filenames to convert.
+func (o *Options) addFileList() error {
+	if len(o.FromFileList) == 0 {
+		return nil
+	}
+
+	f, err := os.Open(o.FromFileList)
+	if err != nil {
+		return fmt.Errorf("error when reading --from-file-list %q: %w", o.FromFileList, err)
+	}
+	defer f.Close()
+
+	paths, err := readFileList(f)
+	if err != nil {
+		return fmt.Errorf("error when reading --from-file-list %q: %w", o.FromFileList, err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths listed in --from-file-list %q", o.FromFileList)
+	}
+
+	o.Filenames = append(o.Filenames, paths...)
+	return nil
+}
+
+// readFileList returns the paths listed in r, one per line. Blank lines and
+// lines starting with '#' are ignored.
+func readFileList(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
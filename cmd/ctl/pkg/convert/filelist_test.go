@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestAddFileList(t *testing.T) {
+	tests := map[string]struct {
+		filenames []string
+		fileList  string
+
+		expFilenames []string
+		expErrMsg    string
+	}{
+		"paths are added after the given filenames, skipping comments and blank lines": {
+			filenames: []string{"cert.yaml"},
+			fileList: `# generated inventory
+team-a/issuer.yaml
+
+  team-b/certs/  
+	# indented comment
+https://example.com/cert.yaml
+`,
+			expFilenames: []string{"cert.yaml", "team-a/issuer.yaml", "team-b/certs/", "https://example.com/cert.yaml"},
+		},
+		"list without paths throws error": {
+			fileList:  "# nothing to convert\n\n",
+			expErrMsg: "no paths listed in --from-file-list %q",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "files.txt")
+			if err := os.WriteFile(path, []byte(test.fileList), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			o := &Options{
+				FromFileList:    path,
+				FilenameOptions: resource.FilenameOptions{Filenames: test.filenames},
+			}
+			err := o.addFileList()
+			if test.expErrMsg != "" {
+				expErrMsg := fmt.Sprintf(test.expErrMsg, path)
+				if err == nil || err.Error() != expErrMsg {
+					t.Errorf("Unexpected error; expected: %s, actual: %v", expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(o.Filenames, test.expFilenames) {
+				t.Errorf("Unexpected filenames; expected: %v, actual: %v", test.expFilenames, o.Filenames)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// inPlaceFile is a file rewritten by --in-place and its converted content.
+type inPlaceFile struct {
+	path    string
+	content []byte
+}
+
+// validateInPlace validates the --in-place flag. Only local files can be
+// rewritten, and every object read has to be written back to its file.
+func (o *Options) validateInPlace() error {
+	if !o.InPlace {
+		return nil
+	}
+	if o.StdinJSONArray || o.readsStdin() {
+		return errors.New("the --in-place flag cannot be used when reading from stdin")
+	}
+	if len(o.Kustomize) > 0 {
+		return errors.New("the --in-place flag cannot be used in conjunction with --kustomize")
+	}
+	for _, filename := range o.Filenames {
+		if strings.HasPrefix(filename, "http://") || strings.HasPrefix(filename, "https://") {
+			return fmt.Errorf("the --in-place flag can only be used with local files, not %q", filename)
+		}
+	}
+	if len(o.OutputDir) > 0 || o.checksumRequested() {
+		return errors.New("the --in-place flag cannot be used in conjunction with the --output-dir, --checksum or --checksum-file flags")
+	}
+	if len(o.DefaultKind) > 0 || len(o.ManagedByFilter) > 0 {
+		return errors.New("the --in-place flag cannot be used in conjunction with the --default-kind or --managed-by-filter flags")
+	}
+	return nil
+}
+
+// writeInPlace writes the converted objects back to the files they were
+// read from, printing the paths of the rewritten files. The files are only
+// written once all objects were converted, and are replaced by renaming
+// temporary files, so no file is modified if any of them fails to convert.
+func (o *Options) writeInPlace(converted []convertedObject) error {
+	var paths []string
+	objectsByPath := map[string][]runtime.Object{}
+	for _, c := range converted {
+		if _, ok := objectsByPath[c.source]; !ok {
+			paths = append(paths, c.source)
+		}
+		objectsByPath[c.source] = append(objectsByPath[c.source], c.object)
+	}
+
+	files := make([]inPlaceFile, 0, len(paths))
+	for _, path := range paths {
+		content, err := o.printInPlace(path, objectsByPath[path])
+		if err != nil {
+			return err
+		}
+		files = append(files, inPlaceFile{path: path, content: content})
+	}
+
+	return o.replaceFiles(files)
+}
+
+// printInPlace returns objects in the format matching the extension of path,
+// i.e. JSON for .json files and YAML otherwise. Objects of YAML files are
+// written as separate documents.
+func (o *Options) printInPlace(path string, objects []runtime.Object) ([]byte, error) {
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	if o.PreserveComments && format == "yaml" {
+		return o.preserveComments(path, objects)
+	}
+	// Every file gets its own printer, so that YAML document separators are
+	// only written between the objects of the same file.
+	flags := *o.PrintFlags
+	flags.OutputFormat = &format
+	printer, err := flags.ToPrinter()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, object := range objects {
+		if err := printer.PrintObj(object, &buf); err != nil {
+			return nil, fmt.Errorf("error when writing %q: %w", path, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// replaceFiles writes every file to a temporary file next to it, and only
+// replaces the files once all temporary files were written.
+func (o *Options) replaceFiles(files []inPlaceFile) error {
+	temps := make([]string, 0, len(files))
+	defer func() {
+		for _, temp := range temps {
+			os.Remove(temp)
+		}
+	}()
+
+	for _, file := range files {
+		temp, err := writeTemp(file)
+		if err != nil {
+			return err
+		}
+		temps = append(temps, temp)
+	}
+
+	for i, file := range files {
+		if err := os.Rename(temps[i], file.path); err != nil {
+			return fmt.Errorf("error when replacing %q: %w", file.path, err)
+		}
+		fmt.Fprintln(o.Out, file.path)
+	}
+	return nil
+}
+
+// writeTemp writes the content of file to a temporary file in the same
+// directory, with the permissions of the file, and returns its path.
+func writeTemp(file inPlaceFile) (string, error) {
+	stat, err := os.Stat(file.path)
+	if err != nil {
+		return "", fmt.Errorf("error when reading %q: %w", file.path, err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(file.path), "."+filepath.Base(file.path)+".*")
+	if err != nil {
+		return "", fmt.Errorf("error when writing %q: %w", file.path, err)
+	}
+	_, err = f.Write(file.content)
+	if err == nil {
+		err = f.Chmod(stat.Mode().Perm())
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("error when writing %q: %w", file.path, err)
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestValidateInPlace(t *testing.T) {
+	tests := map[string]struct {
+		modify    func(o *Options)
+		expErrMsg string
+	}{
+		"--in-place with stdin throws error": {
+			modify:    func(o *Options) { o.Filenames = []string{"crt.yaml", "-"} },
+			expErrMsg: "the --in-place flag cannot be used when reading from stdin",
+		},
+		"--in-place with --stdin-json-array throws error": {
+			modify:    func(o *Options) { o.StdinJSONArray = true },
+			expErrMsg: "the --in-place flag cannot be used when reading from stdin",
+		},
+		"--in-place with --kustomize throws error": {
+			modify:    func(o *Options) { o.Filenames = nil; o.Kustomize = "overlay" },
+			expErrMsg: "the --in-place flag cannot be used in conjunction with --kustomize",
+		},
+		"--in-place with a URL throws error": {
+			modify:    func(o *Options) { o.Filenames = []string{"https://example.com/crt.yaml"} },
+			expErrMsg: `the --in-place flag can only be used with local files, not "https://example.com/crt.yaml"`,
+		},
+		"--in-place with --output-dir throws error": {
+			modify:    func(o *Options) { o.OutputDir = "converted" },
+			expErrMsg: "the --in-place flag cannot be used in conjunction with the --output-dir, --checksum or --checksum-file flags",
+		},
+		"--in-place with --managed-by-filter throws error": {
+			modify:    func(o *Options) { o.ManagedByFilter = []string{"labels:app=foo"} },
+			expErrMsg: "the --in-place flag cannot be used in conjunction with the --default-kind or --managed-by-filter flags",
+		},
+		"--in-place with local files is valid": {
+			modify: func(o *Options) {},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.InPlace = true
+			o.Filenames = []string{"crt.yaml", "manifests/"}
+			test.modify(o)
+
+			err := o.validateInPlace()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestRunInPlace(t *testing.T) {
+	const (
+		issuers = `apiVersion: cert-manager.io/v1alpha2
+kind: Issuer
+metadata:
+  name: a
+  namespace: default
+spec:
+  selfSigned: {}
+---
+apiVersion: cert-manager.io/v1alpha2
+kind: Issuer
+metadata:
+  name: b
+  namespace: default
+spec:
+  selfSigned: {}
+`
+		certificate = `{
+  "apiVersion": "cert-manager.io/v1alpha2",
+  "kind": "Certificate",
+  "metadata": {"name": "crt", "namespace": "default"},
+  "spec": {"secretName": "crt-tls", "issuerRef": {"name": "a"}}
+}
+`
+		incompleteCertificate = `apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: crt
+  namespace: default
+spec:
+  secretName: crt-tls
+`
+	)
+
+	tests := map[string]struct {
+		files           map[string]string
+		requireComplete bool
+
+		expFiles  map[string]string
+		expErrMsg string
+	}{
+		"files are rewritten in the format of their extension": {
+			files: map[string]string{
+				"issuers.yaml":     issuers,
+				"certificate.json": certificate,
+			},
+			expFiles: map[string]string{
+				"issuers.yaml": `apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  creationTimestamp: null
+  name: a
+  namespace: default
+spec:
+  selfSigned: {}
+status: {}
+---
+apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  creationTimestamp: null
+  name: b
+  namespace: default
+spec:
+  selfSigned: {}
+status: {}
+`,
+				"certificate.json": `{
+    "kind": "Certificate",
+    "apiVersion": "cert-manager.io/v1",
+    "metadata": {
+        "name": "crt",
+        "namespace": "default",
+        "creationTimestamp": null
+    },
+    "spec": {
+        "secretName": "crt-tls",
+        "issuerRef": {
+            "name": "a"
+        }
+    },
+    "status": {}
+}
+`,
+			},
+		},
+		"no file is modified if any file fails to convert": {
+			files: map[string]string{
+				"issuers.yaml":     issuers,
+				"certificate.yaml": incompleteCertificate,
+			},
+			requireComplete: true,
+			expFiles: map[string]string{
+				"issuers.yaml":     issuers,
+				"certificate.yaml": incompleteCertificate,
+			},
+			expErrMsg: "spec.issuerRef",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.Filenames = []string{dir}
+			o.OutputVersion = "cert-manager.io/v1"
+			o.InPlace = true
+			o.RequireComplete = test.requireComplete
+			if err := o.Complete(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(context.TODO())
+			if test.expErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expErrMsg) {
+					t.Errorf("Unexpected error; expected to contain: %s, actual: %v", test.expErrMsg, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(test.expFiles) {
+				t.Errorf("Unexpected number of files; expected: %d, actual: %d", len(test.expFiles), len(entries))
+			}
+			for name, expContent := range test.expFiles {
+				content, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(content) != expContent {
+					t.Errorf("Unexpected content of %s; expected: \n%s\nactual: \n%s", name, expContent, content)
+				}
+			}
+		})
+	}
+}
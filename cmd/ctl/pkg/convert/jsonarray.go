@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// validateStdinJSONArray validates that --stdin-json-array is not used in
+// conjunction with the flags selecting other inputs, and reads the input from
+// stdin if it is set.
+func (o *Options) validateStdinJSONArray() error {
+	if !o.StdinJSONArray {
+		return nil
+	}
+	if len(o.Filenames) > 0 || len(o.Kustomize) > 0 || len(o.FromFileList) > 0 {
+		return errors.New("the --stdin-json-array flag cannot be used in conjunction with --filename, --kustomize or --from-file-list")
+	}
+	o.Filenames = []string{stdinFilename}
+	return nil
+}
+
+// validateJSONArray validates that --json-array is used with the json output
+// format, and not in conjunction with the flags writing the output elsewhere.
+func (o *Options) validateJSONArray() error {
+	if !o.JSONArray {
+		return nil
+	}
+	if format := o.outputFormat(); format != "json" {
+		return fmt.Errorf("the --json-array flag can only be used with the json output format, not %q", format)
+	}
+	if len(o.OutputDir) > 0 || o.InPlace || o.Diff {
+		return errors.New("the --json-array flag cannot be used in conjunction with the --output-dir, --in-place or --diff flags")
+	}
+	return nil
+}
+
+// readJSONArray returns a stream of the elements of the JSON array read from
+// in, one JSON document per element, which can be read in the same way as a
+// file containing the elements. A leading byte order mark is stripped. The
+// elements are read one at a time as the stream is consumed. The stream has to
+// be closed once it is no longer read.
+func readJSONArray(in io.Reader) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(copyJSONArray(w, in))
+	}()
+	return r
+}
+
+// copyJSONArray writes every element of the JSON array read from in to w.
+func copyJSONArray(w io.Writer, in io.Reader) error {
+	decoder := json.NewDecoder(transform.NewReader(in, unicode.BOMOverride(unicode.UTF8.NewDecoder())))
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error when decoding the JSON array read from stdin: %w", err)
+	}
+	if token != json.Delim('[') {
+		return errors.New("the input of --stdin-json-array must be a JSON array of objects")
+	}
+
+	for i := 0; decoder.More(); i++ {
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return fmt.Errorf("error when decoding element %d of the JSON array read from stdin: %w", i, err)
+		}
+		if len(element) == 0 || element[0] != '{' {
+			return fmt.Errorf("element %d of the JSON array read from stdin is not an object", i)
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", element); err != nil {
+			return err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("error when decoding the JSON array read from stdin: %w", err)
+	}
+	return nil
+}
+
+// printObjects prints the converted objects given as a JSON array or with
+// --json-array. In the json output format, they are printed as a JSON array,
+// otherwise every object is printed on its own, e.g. as a YAML document.
+func (o *Options) printObjects(objects []runtime.Object) error {
+	if o.outputFormat() != "json" {
+		for _, obj := range objects {
+			if err := o.Printer.PrintObj(obj, o.Out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	elements := make([]json.RawMessage, 0, len(objects))
+	for _, obj := range objects {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, data)
+	}
+	data, err := json.MarshalIndent(elements, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.Out, "%s\n", data)
+	return err
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestReadJSONArray(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expOutput string
+		expErrMsg string
+	}{
+		"every element is output as a document": {
+			input: ` [{"kind":"Certificate"},
+  {"kind":"Issuer","spec":{}}]`,
+			expOutput: `{"kind":"Certificate"}
+{"kind":"Issuer","spec":{}}
+`,
+		},
+		"byte order mark is stripped": {
+			input:     "\ufeff[{\"kind\":\"Certificate\"}]",
+			expOutput: "{\"kind\":\"Certificate\"}\n",
+		},
+		"empty array outputs nothing": {
+			input: `[]`,
+		},
+		"empty input outputs nothing": {
+			input: ``,
+		},
+		"input which is not an array returns an error": {
+			input:     `{"kind":"Certificate"}`,
+			expErrMsg: "the input of --stdin-json-array must be a JSON array of objects",
+		},
+		"element which is not an object returns an error": {
+			input:     `[{"kind":"Certificate"}, "Issuer"]`,
+			expErrMsg: "element 1 of the JSON array read from stdin is not an object",
+		},
+		"unterminated array returns an error": {
+			input:     `[{"kind":"Certificate"}`,
+			expErrMsg: "error when decoding element 1 of the JSON array read from stdin: unexpected end of JSON input",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			in := readJSONArray(strings.NewReader(test.input))
+			defer in.Close()
+
+			output, err := io.ReadAll(in)
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, output)
+			}
+		})
+	}
+}
+
+func TestValidateStdinJSONArray(t *testing.T) {
+	o := &Options{
+		StdinJSONArray:  true,
+		FilenameOptions: resource.FilenameOptions{Filenames: []string{"crt.yaml"}},
+	}
+	expErrMsg := "the --stdin-json-array flag cannot be used in conjunction with --filename, --kustomize or --from-file-list"
+	if err := o.validateStdinJSONArray(); err == nil || err.Error() != expErrMsg {
+		t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", expErrMsg, err)
+	}
+
+	o = &Options{StdinJSONArray: true}
+	if err := o.validateStdinJSONArray(); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Filenames) != 1 || o.Filenames[0] != "-" {
+		t.Errorf("Unexpected filenames; expected: \n[-]\nactual: \n%v", o.Filenames)
+	}
+}
+
+func TestValidateJSONArray(t *testing.T) {
+	tests := map[string]struct {
+		format    string
+		outputDir string
+		diff      bool
+
+		expErrMsg string
+	}{
+		"json output format": {
+			format: "json",
+		},
+		"yaml output format returns an error": {
+			format:    "yaml",
+			expErrMsg: `the --json-array flag can only be used with the json output format, not "yaml"`,
+		},
+		"output directory returns an error": {
+			format:    "json",
+			outputDir: "converted",
+			expErrMsg: "the --json-array flag cannot be used in conjunction with the --output-dir, --in-place or --diff flags",
+		},
+		"diff returns an error": {
+			format:    "json",
+			diff:      true,
+			expErrMsg: "the --json-array flag cannot be used in conjunction with the --output-dir, --in-place or --diff flags",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.JSONArray = true
+			o.PrintFlags.OutputFormat = &test.format
+			o.OutputDir = test.outputDir
+			o.Diff = test.diff
+
+			err := o.validateJSONArray()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// legacyHTTP01Path is the path of the HTTP-01 configuration of Issuers which
+// predate the ACME solvers, e.g.
+//
+//	spec:
+//	  acme:
+//	    http01:
+//	      ingressClass: nginx
+var legacyHTTP01Path = []string{"spec", "acme", "http01"}
+
+// legacyHTTP01Fields maps the fields of the legacy HTTP-01 configuration to
+// the fields of the ingress of an HTTP-01 solver they are migrated to.
+var legacyHTTP01Fields = map[string]string{
+	// The legacy ingressClass set the kubernetes.io/ingress.class
+	// annotation, the same as class does, rather than ingressClassName
+	"ingressClass": "class",
+	"ingress":      "name",
+	"serviceType":  "serviceType",
+}
+
+// migrateLegacyHTTP01 migrates the legacy HTTP-01 configuration of the Issuers
+// and ClusterIssuers in infos to an HTTP-01 solver, printing a warning for
+// every field which cannot be migrated. The legacy configuration isn't part of
+// any served version, so it would otherwise be dropped when decoding.
+func (o *Options) migrateLegacyHTTP01(infos []*resource.Info) {
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		for _, warning := range migrateLegacyHTTP01(u) {
+			fmt.Fprintf(o.ErrOut, "warning: %s %s: %s\n", u.GetKind(), objectName(u), warning)
+		}
+	}
+}
+
+// migrateLegacyHTTP01 replaces spec.acme.http01 of the Issuer or ClusterIssuer
+// u by an HTTP-01 solver in spec.acme.solvers, and returns warnings for the
+// fields which cannot be migrated. Other objects are left unchanged.
+func migrateLegacyHTTP01(u *unstructured.Unstructured) []string {
+	gvk := u.GroupVersionKind()
+	if gvk.Group != cmapi.SchemeGroupVersion.Group || (gvk.Kind != cmapi.IssuerKind && gvk.Kind != cmapi.ClusterIssuerKind) {
+		return nil
+	}
+	legacy, found, err := unstructured.NestedFieldNoCopy(u.Object, legacyHTTP01Path...)
+	if !found || err != nil {
+		return nil
+	}
+	unstructured.RemoveNestedField(u.Object, legacyHTTP01Path...)
+
+	// Solvers take precedence over the legacy configuration, which is
+	// ignored if any are configured
+	if solvers, _, _ := unstructured.NestedSlice(u.Object, "spec", "acme", "solvers"); len(solvers) > 0 {
+		return []string{"spec.acme.http01 was dropped, as it is ignored in favour of spec.acme.solvers"}
+	}
+
+	config, ok := legacy.(map[string]interface{})
+	if !ok && legacy != nil {
+		return []string{fmt.Sprintf("spec.acme.http01 was dropped, as it is not an object but %T", legacy)}
+	}
+
+	var warnings []string
+	ingress := map[string]interface{}{}
+	for _, field := range sortedKeys(config) {
+		target, ok := legacyHTTP01Fields[field]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("spec.acme.http01.%s was dropped, as it has no equivalent in the HTTP-01 solver", field))
+			continue
+		}
+		if _, ok := config[field].(string); !ok {
+			warnings = append(warnings, fmt.Sprintf("spec.acme.http01.%s was dropped, as it is not a string", field))
+			continue
+		}
+		ingress[target] = config[field]
+	}
+	// Only one of class and name may be set on a solver. A named Ingress was
+	// edited in place, which didn't use the class.
+	if _, ok := ingress["name"]; ok {
+		if _, ok := ingress["class"]; ok {
+			delete(ingress, "class")
+			warnings = append(warnings, "spec.acme.http01.ingressClass was dropped, as it cannot be used in conjunction with spec.acme.http01.ingress")
+		}
+	}
+
+	solver := map[string]interface{}{
+		"http01": map[string]interface{}{"ingress": ingress},
+	}
+	// Cannot fail as spec.acme exists
+	_ = unstructured.SetNestedSlice(u.Object, []interface{}{solver}, "spec", "acme", "solvers")
+	return warnings
+}
+
+// objectName returns the namespaced name of u.
+func objectName(u *unstructured.Unstructured) string {
+	if len(u.GetNamespace()) > 0 {
+		return u.GetNamespace() + "/" + u.GetName()
+	}
+	return u.GetName()
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
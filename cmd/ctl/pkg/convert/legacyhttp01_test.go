@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMigrateLegacyHTTP01(t *testing.T) {
+	issuer := func(kind string, acme map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1alpha2",
+			"kind":       kind,
+			"spec": map[string]interface{}{
+				"acme": acme,
+			},
+		}
+	}
+	solvers := func(ingress map[string]interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"http01": map[string]interface{}{"ingress": ingress},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		input map[string]interface{}
+
+		expObj      map[string]interface{}
+		expWarnings []string
+	}{
+		"ingressClass is migrated to the class of a solver": {
+			input: issuer("Issuer", map[string]interface{}{
+				"http01": map[string]interface{}{"ingressClass": "nginx"},
+			}),
+			expObj: issuer("Issuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{"class": "nginx"}),
+			}),
+		},
+		"ingress and serviceType are migrated to the name and serviceType of a solver": {
+			input: issuer("ClusterIssuer", map[string]interface{}{
+				"http01": map[string]interface{}{"ingress": "my-ingress", "serviceType": "ClusterIP"},
+			}),
+			expObj: issuer("ClusterIssuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{"name": "my-ingress", "serviceType": "ClusterIP"}),
+			}),
+		},
+		"an empty configuration is migrated to a solver using the default ingress": {
+			input: issuer("Issuer", map[string]interface{}{
+				"http01": map[string]interface{}{},
+			}),
+			expObj: issuer("Issuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{}),
+			}),
+		},
+		"ingressClass is dropped in conjunction with ingress": {
+			input: issuer("Issuer", map[string]interface{}{
+				"http01": map[string]interface{}{"ingress": "my-ingress", "ingressClass": "nginx"},
+			}),
+			expObj: issuer("Issuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{"name": "my-ingress"}),
+			}),
+			expWarnings: []string{"spec.acme.http01.ingressClass was dropped, as it cannot be used in conjunction with spec.acme.http01.ingress"},
+		},
+		"fields without equivalent and invalid values are dropped": {
+			input: issuer("Issuer", map[string]interface{}{
+				"http01": map[string]interface{}{"ingressClass": int64(1), "podTemplate": map[string]interface{}{}},
+			}),
+			expObj: issuer("Issuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{}),
+			}),
+			expWarnings: []string{
+				"spec.acme.http01.ingressClass was dropped, as it is not a string",
+				"spec.acme.http01.podTemplate was dropped, as it has no equivalent in the HTTP-01 solver",
+			},
+		},
+		"the legacy configuration is dropped if solvers are configured": {
+			input: issuer("Issuer", map[string]interface{}{
+				"http01":  map[string]interface{}{"ingressClass": "nginx"},
+				"solvers": solvers(map[string]interface{}{"class": "traefik"}),
+			}),
+			expObj: issuer("Issuer", map[string]interface{}{
+				"solvers": solvers(map[string]interface{}{"class": "traefik"}),
+			}),
+			expWarnings: []string{"spec.acme.http01 was dropped, as it is ignored in favour of spec.acme.solvers"},
+		},
+		"other kinds are left unchanged": {
+			input: issuer("Certificate", map[string]interface{}{
+				"http01": map[string]interface{}{"ingressClass": "nginx"},
+			}),
+			expObj: issuer("Certificate", map[string]interface{}{
+				"http01": map[string]interface{}{"ingressClass": "nginx"},
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: test.input}
+			warnings := migrateLegacyHTTP01(obj)
+			if !reflect.DeepEqual(obj.Object, test.expObj) {
+				t.Errorf("Unexpected object; expected: \n%v\nactual: \n%v", test.expObj, obj.Object)
+			}
+			if !reflect.DeepEqual(warnings, test.expWarnings) {
+				t.Errorf("Unexpected warnings; expected: %v, actual: %v", test.expWarnings, warnings)
+			}
+		})
+	}
+}
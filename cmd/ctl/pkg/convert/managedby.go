@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// Sources of the key value pairs a --managed-by-filter predicate is matched
+// against
+const (
+	managedByLabels      = "labels"
+	managedByAnnotations = "annotations"
+)
+
+// managedByPredicate is a parsed --managed-by-filter predicate.
+type managedByPredicate struct {
+	// source is either managedByLabels or managedByAnnotations
+	source   string
+	selector labels.Selector
+}
+
+// parseManagedByFilter parses the predicates given by --managed-by-filter.
+// Every predicate is of the form 'labels:<selector>' or
+// 'annotations:<selector>', where the selector uses the syntax of label
+// selectors.
+func (o *Options) parseManagedByFilter() error {
+	o.managedByPredicates = nil
+	for _, filter := range o.ManagedByFilter {
+		source, expr, ok := strings.Cut(filter, ":")
+		if !ok || (source != managedByLabels && source != managedByAnnotations) {
+			return fmt.Errorf("invalid --managed-by-filter %q: expected 'labels:<selector>' or 'annotations:<selector>'", filter)
+		}
+		selector, err := labels.Parse(expr)
+		if err != nil {
+			return fmt.Errorf("invalid --managed-by-filter %q: %w", filter, err)
+		}
+		o.managedByPredicates = append(o.managedByPredicates, managedByPredicate{source: source, selector: selector})
+	}
+	return nil
+}
+
+// filterManagedBy returns the infos whose objects match every
+// --managed-by-filter predicate. All infos are returned if no predicates are
+// given.
+func (o *Options) filterManagedBy(infos []*resource.Info) ([]*resource.Info, error) {
+	if len(o.managedByPredicates) == 0 {
+		return infos, nil
+	}
+
+	var matching []*resource.Info
+	for _, info := range infos {
+		if info.Object == nil {
+			continue
+		}
+		accessor, err := meta.Accessor(info.Object)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading the metadata of %q from %s: %w", info.Name, info.Source, err)
+		}
+
+		matches := true
+		for _, predicate := range o.managedByPredicates {
+			set := labels.Set(accessor.GetLabels())
+			if predicate.source == managedByAnnotations {
+				set = labels.Set(accessor.GetAnnotations())
+			}
+			if !predicate.selector.Matches(set) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			matching = append(matching, info)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestFilterManagedBy(t *testing.T) {
+	object := func(name string, labels, annotations map[string]interface{}) *resource.Info {
+		metadata := map[string]interface{}{"name": name}
+		if labels != nil {
+			metadata["labels"] = labels
+		}
+		if annotations != nil {
+			metadata["annotations"] = annotations
+		}
+		return &resource.Info{
+			Name: name,
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata":   metadata,
+			}},
+		}
+	}
+	infos := []*resource.Info{
+		object("user", nil, nil),
+		object("managed", map[string]interface{}{"app.kubernetes.io/managed-by": "cert-manager"}, nil),
+		object("owned", map[string]interface{}{"app.kubernetes.io/managed-by": "cert-manager"}, map[string]interface{}{"cert-manager.io/certificate-name": "crt"}),
+	}
+
+	tests := map[string]struct {
+		filter    []string
+		expNames  []string
+		expErrMsg string
+	}{
+		"no filter matches every object": {
+			expNames: []string{"user", "managed", "owned"},
+		},
+		"label equality predicate": {
+			filter:   []string{"labels:app.kubernetes.io/managed-by=cert-manager"},
+			expNames: []string{"managed", "owned"},
+		},
+		"annotation existence predicate": {
+			filter:   []string{"annotations:cert-manager.io/certificate-name"},
+			expNames: []string{"owned"},
+		},
+		"repeated predicates have to match all": {
+			filter:   []string{"labels:app.kubernetes.io/managed-by=cert-manager", "annotations:!cert-manager.io/certificate-name"},
+			expNames: []string{"managed"},
+		},
+		"predicate without source throws error": {
+			filter:    []string{"app.kubernetes.io/managed-by=cert-manager"},
+			expErrMsg: `invalid --managed-by-filter "app.kubernetes.io/managed-by=cert-manager": expected 'labels:<selector>' or 'annotations:<selector>'`,
+		},
+		"invalid selector throws error": {
+			filter:    []string{"labels:a=b=c"},
+			expErrMsg: `invalid --managed-by-filter "labels:a=b=c": found '=', expected: ',' or 'end of string'`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &Options{ManagedByFilter: test.filter}
+			err := o.parseManagedByFilter()
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			matching, err := o.filterManagedBy(infos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, info := range matching {
+				names = append(names, info.Name)
+			}
+			if !reflect.DeepEqual(names, test.expNames) {
+				t.Errorf("Unexpected objects; expected: \n%v\nactual: \n%v", test.expNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// mixedDocumentReader is a stream of the documents read from a file or the
+// input stream as YAML documents, which can be passed to the
+// resource.Builder in place of the file. The resource.Builder detects
+// whether a stream is JSON or YAML once, from its first bytes, so that a
+// JSON object followed by YAML documents fails to decode. Instead, every
+// document is detected on its own: a document starting with '{', either at
+// the start of the stream, after a '---' separator or on a line starting
+// with '{', is read as a JSON object, anything else is copied as YAML up to
+// the next separator. JSON objects are valid YAML, so they are written as
+// separate YAML documents. Documents starting with '{' which are not valid
+// JSON, e.g. YAML flow mappings, are copied as YAML.
+// As done by the resource.Builder for files, a leading byte order mark is
+// stripped, and input starting with a UTF-16 byte order mark is decoded as
+// UTF-16. The file is only opened once the stream is read, and closed once
+// it is read completely. The stream has to be closed once it is no longer
+// read.
+type mixedDocumentReader struct {
+	open func() (io.ReadCloser, error)
+
+	once sync.Once
+	r    *io.PipeReader
+}
+
+// newMixedDocumentReader returns a mixedDocumentReader reading r.
+func newMixedDocumentReader(r io.Reader) *mixedDocumentReader {
+	return &mixedDocumentReader{open: func() (io.ReadCloser, error) {
+		return io.NopCloser(r), nil
+	}}
+}
+
+// openMixedDocuments returns a mixedDocumentReader reading the file at path,
+// or the input stream if path is "-".
+func (o *Options) openMixedDocuments(path string) *mixedDocumentReader {
+	if path == stdinFilename {
+		return newMixedDocumentReader(o.In)
+	}
+	return &mixedDocumentReader{open: func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}}
+}
+
+func (m *mixedDocumentReader) start() {
+	var w *io.PipeWriter
+	m.r, w = io.Pipe()
+	go func() {
+		in, err := m.open()
+		if err != nil {
+			w.CloseWithError(err)
+			return
+		}
+		defer in.Close()
+		src := &unreadReader{r: transform.NewReader(in, unicode.BOMOverride(unicode.UTF8.NewDecoder()))}
+		w.CloseWithError(copyMixedDocuments(w, src))
+	}()
+}
+
+func (m *mixedDocumentReader) Read(p []byte) (int, error) {
+	m.once.Do(m.start)
+	if m.r == nil {
+		// Closed before it was read
+		return 0, io.ErrClosedPipe
+	}
+	return m.r.Read(p)
+}
+
+func (m *mixedDocumentReader) Close() error {
+	m.once.Do(func() {})
+	if m.r == nil {
+		return nil
+	}
+	return m.r.Close()
+}
+
+// copyMixedDocuments writes the documents read from src to w as YAML
+// documents, see mixedDocumentReader.
+func copyMixedDocuments(w io.Writer, src *unreadReader) error {
+	r := bufio.NewReader(src)
+	atDocumentStart := true
+	for {
+		if atDocumentStart && startsWithObject(r) {
+			copied, err := copyJSONDocument(w, r, src)
+			if err != nil {
+				return err
+			}
+			if copied {
+				continue
+			}
+		}
+
+		line, err := r.ReadString('\n')
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// JSON objects always start on a new line, which cannot start a
+		// YAML document with '{' without a separator
+		next, err := r.Peek(1)
+		atDocumentStart = isDocumentSeparator(line) || (err == nil && next[0] == '{')
+	}
+}
+
+// copyJSONDocument reads a JSON object from r and writes it to w as a
+// separate YAML document, returning true. If the object cannot be decoded,
+// nothing is written and false is returned, with r reading from the same
+// position again. r has to read from src, which the input read ahead by the
+// decoder is returned to.
+func copyJSONDocument(w io.Writer, r *bufio.Reader, src *unreadReader) (bool, error) {
+	// The bytes read by the decoder are recorded, to be read again as YAML
+	// if the document turns out not to be JSON
+	var consumed bytes.Buffer
+	decoder := json.NewDecoder(io.TeeReader(r, &consumed))
+	var doc json.RawMessage
+	if err := decoder.Decode(&doc); err != nil {
+		unread(r, src, consumed.Bytes())
+		return false, nil
+	}
+
+	if _, err := fmt.Fprintf(w, "\n---\n%s\n---\n", doc); err != nil {
+		return false, err
+	}
+	rest, err := io.ReadAll(decoder.Buffered())
+	if err != nil {
+		return false, err
+	}
+	unread(r, src, rest)
+	return true, nil
+}
+
+// unread makes r read data followed by the input buffered by r again.
+func unread(r *bufio.Reader, src *unreadReader, data []byte) {
+	buffered, _ := r.Peek(r.Buffered())
+	src.unread(buffered)
+	src.unread(data)
+	r.Reset(src)
+}
+
+// unreadReader is a reader which input can be returned to, to be read again.
+type unreadReader struct {
+	r       io.Reader
+	pending []byte
+}
+
+func (u *unreadReader) Read(p []byte) (int, error) {
+	if len(u.pending) > 0 {
+		n := copy(p, u.pending)
+		u.pending = u.pending[n:]
+		return n, nil
+	}
+	return u.r.Read(p)
+}
+
+// unread returns data to u, to be read before the rest of the input.
+func (u *unreadReader) unread(data []byte) {
+	u.pending = append(append([]byte(nil), data...), u.pending...)
+}
+
+// startsWithObject returns true if the first byte of r which is not
+// whitespace is '{', without consuming any input. Only the buffered input is
+// looked at, so that YAML documents are not reindented.
+func startsWithObject(r *bufio.Reader) bool {
+	for n := 1; n <= r.Size(); n++ {
+		next, err := r.Peek(n)
+		if err != nil {
+			return false
+		}
+		switch next[n-1] {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return next[n-1] == '{'
+		}
+	}
+	return false
+}
+
+// isDocumentSeparator returns true if line separates YAML documents, i.e. it
+// is '---', optionally followed by a comment.
+func isDocumentSeparator(line string) bool {
+	line = strings.TrimRight(line, " \t\r\n")
+	return line == "---" || strings.HasPrefix(line, "--- #")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMixedDocumentReader(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expOutput string
+	}{
+		"YAML documents are copied as read": {
+			input:     "  apiVersion: v1\n  kind: List\n---\nkind: Certificate\n",
+			expOutput: "  apiVersion: v1\n  kind: List\n---\nkind: Certificate\n",
+		},
+		"JSON object followed by YAML documents": {
+			input:     "{\n  \"kind\": \"Certificate\"\n}\n---\nkind: Issuer\n",
+			expOutput: "\n---\n{\n  \"kind\": \"Certificate\"\n}\n---\n\n---\nkind: Issuer\n",
+		},
+		"JSON objects following a YAML document without a separator": {
+			input:     "# comment\nkind: Issuer\n{\"kind\": \"Certificate\"}\n{\"kind\": \"ClusterIssuer\"}\n",
+			expOutput: "# comment\nkind: Issuer\n\n---\n{\"kind\": \"Certificate\"}\n---\n\n---\n{\"kind\": \"ClusterIssuer\"}\n---\n\n",
+		},
+		"YAML flow mappings are copied as YAML": {
+			input:     "{kind: Certificate}\n---\n{\"kind\": \"Issuer\"}",
+			expOutput: "{kind: Certificate}\n---\n\n---\n{\"kind\": \"Issuer\"}\n---\n",
+		},
+		"byte order mark is stripped": {
+			input:     "\ufeff{\"kind\": \"Certificate\"}\r\nkind: Issuer\r\n",
+			expOutput: "\n---\n{\"kind\": \"Certificate\"}\n---\n\r\nkind: Issuer\r\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := newMixedDocumentReader(strings.NewReader(test.input))
+			defer r.Close()
+
+			output, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != test.expOutput {
+				t.Errorf("Unexpected output; expected: %q, actual: %q", test.expOutput, output)
+			}
+		})
+	}
+}
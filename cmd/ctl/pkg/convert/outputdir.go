@@ -0,0 +1,297 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// outputFile is a file written to --output-dir and the objects it contains.
+type outputFile struct {
+	name    string
+	objects []outputObject
+}
+
+// outputObject is a converted object and the metadata used to name and sort
+// the files written to --output-dir.
+type outputObject struct {
+	object    runtime.Object
+	kind      string
+	resource  string
+	namespace string
+	name      string
+}
+
+// validateOutputDir validates the --output-dir, --split-by-kind and --flatten
+// flags.
+func (o *Options) validateOutputDir() error {
+	if o.SplitByKind && len(o.OutputDir) == 0 {
+		return errors.New("the --split-by-kind flag must be used in conjunction with --output-dir")
+	}
+	if o.Flatten && len(o.OutputDir) == 0 {
+		return errors.New("the --flatten flag must be used in conjunction with --output-dir")
+	}
+	if len(o.OutputDir) == 0 {
+		return nil
+	}
+	if format := o.outputFormat(); format != "yaml" && format != "json" {
+		return fmt.Errorf("the --output-dir flag can only be used with the yaml or json output formats, not %q", format)
+	}
+	return nil
+}
+
+// outputFormat returns the format given by --output.
+func (o *Options) outputFormat() string {
+	if o.PrintFlags.OutputFormat == nil {
+		return ""
+	}
+	return *o.PrintFlags.OutputFormat
+}
+
+// writeOutputDir writes objects to files in o.OutputDir, one file per object
+// or, with --split-by-kind, one file per kind. The paths of the written files
+// are printed.
+func (o *Options) writeOutputDir(objects []runtime.Object) error {
+	outputObjects, err := newOutputObjects(objects)
+	if err != nil {
+		return err
+	}
+
+	files, err := groupOutputFiles(outputObjects, o.SplitByKind, o.outputFormat())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(o.OutputDir, 0755); err != nil {
+		return fmt.Errorf("error when creating the output directory: %w", err)
+	}
+	for _, file := range files {
+		path := filepath.Join(o.OutputDir, file.name)
+		if err := o.writeOutputFile(path, file.objects); err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, path)
+	}
+	return nil
+}
+
+// recordOutputPath records the path the objects read from the local file are
+// written to relative to --output-dir, i.e. the path of the file relative to
+// the directory given as filename, or its base name if it was given itself.
+func (o *Options) recordOutputPath(file, dir string) error {
+	if o.outputPaths == nil {
+		o.outputPaths = map[string]string{}
+	}
+	if len(dir) == 0 {
+		o.outputPaths[file] = filepath.Base(file)
+		return nil
+	}
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return err
+	}
+	o.outputPaths[file] = rel
+	return nil
+}
+
+// writeOutputTree writes the converted objects to o.OutputDir, mirroring the
+// paths of the files they were read from relative to the directories given as
+// filename. The extension of the files is changed to match the output format
+// if needed. Objects which were not read from local files, e.g. from stdin,
+// are written to files named after them as with --flatten. The paths of the
+// written files are printed.
+func (o *Options) writeOutputTree(converted []convertedObject) error {
+	var (
+		paths     []string
+		flattened []runtime.Object
+	)
+	objectsByPath := map[string][]runtime.Object{}
+	sourcesByPath := map[string]string{}
+	for _, c := range converted {
+		rel, ok := o.outputPaths[c.source]
+		if !ok {
+			flattened = append(flattened, c.object)
+			continue
+		}
+		path := withOutputExtension(rel, o.outputFormat())
+		if source, ok := sourcesByPath[path]; ok && source != c.source {
+			return fmt.Errorf("refusing to write the objects of both %q and %q to %q, use --flatten instead", source, c.source, path)
+		}
+		if _, ok := objectsByPath[path]; !ok {
+			paths = append(paths, path)
+			sourcesByPath[path] = c.source
+		}
+		objectsByPath[path] = append(objectsByPath[path], c.object)
+	}
+
+	files := make([]outputFile, 0, len(paths))
+	for _, path := range paths {
+		outputObjects, err := newOutputObjects(objectsByPath[path])
+		if err != nil {
+			return err
+		}
+		files = append(files, outputFile{name: path, objects: outputObjects})
+	}
+	if len(flattened) > 0 {
+		outputObjects, err := newOutputObjects(flattened)
+		if err != nil {
+			return err
+		}
+		flattenedFiles, err := groupOutputFiles(outputObjects, false, o.outputFormat())
+		if err != nil {
+			return err
+		}
+		files = append(files, flattenedFiles...)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(o.OutputDir, file.name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("error when creating the output directory: %w", err)
+		}
+		if err := o.writeOutputFile(path, file.objects); err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, path)
+	}
+	return nil
+}
+
+// newOutputObjects returns the outputObjects of objects.
+func newOutputObjects(objects []runtime.Object) ([]outputObject, error) {
+	outputObjects := make([]outputObject, 0, len(objects))
+	for _, object := range objects {
+		outputObject, err := newOutputObject(object)
+		if err != nil {
+			return nil, err
+		}
+		outputObjects = append(outputObjects, outputObject)
+	}
+	return outputObjects, nil
+}
+
+// withOutputExtension returns path with its extension replaced by the
+// extension of format, unless it already matches the format.
+func withOutputExtension(path, format string) string {
+	ext := filepath.Ext(path)
+	switch {
+	case format == "json" && ext == ".json":
+		return path
+	case format == "yaml" && (ext == ".yaml" || ext == ".yml"):
+		return path
+	}
+	return strings.TrimSuffix(path, ext) + "." + format
+}
+
+func (o *Options) writeOutputFile(path string, objects []outputObject) error {
+	// Every file gets its own printer, so that YAML document separators are
+	// only written between the objects of the same file.
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error when creating %q: %w", path, err)
+	}
+	for _, object := range objects {
+		if err := printer.PrintObj(object.object, f); err != nil {
+			f.Close()
+			return fmt.Errorf("error when writing %q: %w", path, err)
+		}
+	}
+	return f.Close()
+}
+
+// newOutputObject returns object with the metadata used to name and sort the
+// files written to --output-dir. Objects which are not part of the scheme are
+// decoded to read their metadata.
+func newOutputObject(object runtime.Object) (outputObject, error) {
+	if unknown, ok := object.(*runtime.Unknown); ok {
+		decoded, err := runtime.Decode(unstructured.UnstructuredJSONScheme, unknown.Raw)
+		if err != nil {
+			return outputObject{}, err
+		}
+		object = decoded
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return outputObject{}, err
+	}
+	gvk := object.GetObjectKind().GroupVersionKind()
+	resource, _ := meta.UnsafeGuessKindToResource(gvk)
+	return outputObject{
+		object:    object,
+		kind:      strings.ToLower(gvk.Kind),
+		resource:  resource.Resource,
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+	}, nil
+}
+
+// groupOutputFiles returns the files objects are written to, sorted by name.
+// If splitByKind is true, objects of the same kind are grouped into a file
+// named after the resource, e.g. certificates.yaml, sorted by name and
+// namespace. Otherwise every object is written to its own file.
+func groupOutputFiles(objects []outputObject, splitByKind bool, extension string) ([]outputFile, error) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].name != objects[j].name {
+			return objects[i].name < objects[j].name
+		}
+		return objects[i].namespace < objects[j].namespace
+	})
+
+	filesByName := map[string]*outputFile{}
+	for _, object := range objects {
+		name := object.resource
+		if !splitByKind {
+			name = object.kind
+			if len(object.namespace) > 0 {
+				name += "-" + object.namespace
+			}
+			name += "-" + object.name
+		}
+		name += "." + extension
+
+		file, ok := filesByName[name]
+		if !ok {
+			file = &outputFile{name: name}
+			filesByName[name] = file
+		} else if !splitByKind {
+			return nil, fmt.Errorf("refusing to write more than one object to %q", name)
+		}
+		file.objects = append(file.objects, object)
+	}
+
+	files := make([]outputFile, 0, len(filesByName))
+	for _, file := range filesByName {
+		files = append(files, *file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+	return files, nil
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestGroupOutputFiles(t *testing.T) {
+	newCertificate := func(namespace, name string) runtime.Object {
+		return &cmapi.Certificate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       cmapi.CertificateSpec{SecretName: name + "-tls"},
+		}
+	}
+	clusterIssuer := &cmapi.ClusterIssuer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer"},
+		ObjectMeta: metav1.ObjectMeta{Name: "self-signed"},
+	}
+	objects := func() []runtime.Object {
+		return []runtime.Object{
+			newCertificate("ns-2", "crt-b"),
+			clusterIssuer,
+			newCertificate("ns-1", "crt-b"),
+			newCertificate("ns-1", "crt-a"),
+		}
+	}
+
+	tests := map[string]struct {
+		objects     []runtime.Object
+		splitByKind bool
+
+		expFiles  map[string][]string
+		expErrMsg string
+	}{
+		"every object is written to its own file": {
+			objects: objects(),
+			expFiles: map[string][]string{
+				"certificate-ns-1-crt-a.yaml":    {"ns-1/crt-a"},
+				"certificate-ns-1-crt-b.yaml":    {"ns-1/crt-b"},
+				"certificate-ns-2-crt-b.yaml":    {"ns-2/crt-b"},
+				"clusterissuer-self-signed.yaml": {"/self-signed"},
+			},
+		},
+		"objects are grouped by kind and sorted by name": {
+			objects:     objects(),
+			splitByKind: true,
+			expFiles: map[string][]string{
+				"certificates.yaml":   {"ns-1/crt-a", "ns-1/crt-b", "ns-2/crt-b"},
+				"clusterissuers.yaml": {"/self-signed"},
+			},
+		},
+		"objects written to the same file throw error": {
+			objects:   []runtime.Object{newCertificate("ns-1", "crt-a"), newCertificate("ns-1", "crt-a")},
+			expErrMsg: `refusing to write more than one object to "certificate-ns-1-crt-a.yaml"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			outputObjects := make([]outputObject, 0, len(test.objects))
+			for _, object := range test.objects {
+				outputObject, err := newOutputObject(object)
+				if err != nil {
+					t.Fatal(err)
+				}
+				outputObjects = append(outputObjects, outputObject)
+			}
+
+			files, err := groupOutputFiles(outputObjects, test.splitByKind, "yaml")
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actualFiles := map[string][]string{}
+			for _, file := range files {
+				for _, object := range file.objects {
+					actualFiles[file.name] = append(actualFiles[file.name], object.namespace+"/"+object.name)
+				}
+			}
+			if !reflect.DeepEqual(actualFiles, test.expFiles) {
+				t.Errorf("Unexpected files; expected: %v, actual: %v", test.expFiles, actualFiles)
+			}
+		})
+	}
+}
+
+func TestWriteOutputDir(t *testing.T) {
+	streams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+	o := NewOptions(streams)
+	o.OutputDir = filepath.Join(t.TempDir(), "converted")
+	o.SplitByKind = true
+
+	err := o.writeOutputDir([]runtime.Object{
+		&cmapi.Issuer{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Issuer"},
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+		},
+		&cmapi.Issuer{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Issuer"},
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(o.OutputDir, "issuers.yaml")
+	if outBuf.String() != path+"\n" {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", path, outBuf.String())
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expContent := `apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  creationTimestamp: null
+  name: a
+  namespace: default
+spec: {}
+status: {}
+---
+apiVersion: cert-manager.io/v1
+kind: Issuer
+metadata:
+  creationTimestamp: null
+  name: b
+  namespace: default
+spec: {}
+status: {}
+`
+	if string(content) != expContent {
+		t.Errorf("Unexpected content; expected: \n%s\nactual: \n%s", expContent, content)
+	}
+}
+
+func TestWriteOutputTree(t *testing.T) {
+	const (
+		issuerA = `apiVersion: cert-manager.io/v1alpha2
+kind: Issuer
+metadata:
+  name: a
+  namespace: default
+spec:
+  selfSigned: {}
+`
+		issuerB = `apiVersion: cert-manager.io/v1alpha2
+kind: Issuer
+metadata:
+  name: b
+  namespace: default
+spec:
+  selfSigned: {}
+`
+	)
+
+	tests := map[string]struct {
+		files     map[string]string
+		filenames []string
+		output    string
+		flatten   bool
+
+		expFiles  map[string]string
+		expErrMsg string
+	}{
+		"the paths relative to the given directory are mirrored": {
+			files: map[string]string{
+				"manifests/a/issuer.yaml": issuerA,
+				"manifests/b.yml":         issuerB,
+			},
+			filenames: []string{"manifests"},
+			expFiles: map[string]string{
+				"a/issuer.yaml": "name: a",
+				"b.yml":         "name: b",
+			},
+		},
+		"files given as filename are written by their name": {
+			files: map[string]string{
+				"manifests/a/issuer.yaml": issuerA,
+			},
+			filenames: []string{"manifests/a/issuer.yaml"},
+			expFiles: map[string]string{
+				"issuer.yaml": "name: a",
+			},
+		},
+		"the extension is changed to match the output format": {
+			files: map[string]string{
+				"manifests/a/issuer.yaml": issuerA,
+			},
+			filenames: []string{"manifests"},
+			output:    "json",
+			expFiles: map[string]string{
+				"a/issuer.json": `"name": "a"`,
+			},
+		},
+		"--flatten writes every object to its own file": {
+			files: map[string]string{
+				"manifests/a/issuer.yaml": issuerA,
+				"manifests/b.yml":         issuerB,
+			},
+			filenames: []string{"manifests"},
+			flatten:   true,
+			expFiles: map[string]string{
+				"issuer-default-a.yaml": "name: a",
+				"issuer-default-b.yaml": "name: b",
+			},
+		},
+		"files written to the same path throw error": {
+			files: map[string]string{
+				"a/issuer.yaml": issuerA,
+				"b/issuer.yaml": issuerB,
+			},
+			filenames: []string{"a", "b"},
+			expErrMsg: "use --flatten instead",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			for _, filename := range test.filenames {
+				o.Filenames = append(o.Filenames, filepath.Join(dir, filename))
+			}
+			o.Recursive = true
+			o.OutputDir = filepath.Join(dir, "converted")
+			o.Flatten = test.flatten
+			if len(test.output) > 0 {
+				o.PrintFlags.OutputFormat = &test.output
+			}
+			if err := o.Complete(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(context.TODO())
+			if test.expErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expErrMsg) {
+					t.Errorf("Unexpected error; expected to contain: %s, actual: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var files []string
+			err = filepath.Walk(o.OutputDir, func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					rel, _ := filepath.Rel(o.OutputDir, path)
+					files = append(files, rel)
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != len(test.expFiles) {
+				t.Errorf("Unexpected files; expected: %v, actual: %v", test.expFiles, files)
+			}
+			for name, expContent := range test.expFiles {
+				content, err := os.ReadFile(filepath.Join(o.OutputDir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(content), expContent) {
+					t.Errorf("Unexpected content of %s; expected to contain: %s, actual: \n%s", name, expContent, content)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOutputDir(t *testing.T) {
+	tests := map[string]struct {
+		outputDir   string
+		splitByKind bool
+		flatten     bool
+		output      string
+		expErrMsg   string
+	}{
+		"--flatten without --output-dir throws error": {
+			flatten:   true,
+			output:    "yaml",
+			expErrMsg: "the --flatten flag must be used in conjunction with --output-dir",
+		},
+		"--split-by-kind without --output-dir throws error": {
+			splitByKind: true,
+			output:      "yaml",
+			expErrMsg:   "the --split-by-kind flag must be used in conjunction with --output-dir",
+		},
+		"--output-dir with an unsupported output format throws error": {
+			outputDir: "converted",
+			output:    "name",
+			expErrMsg: `the --output-dir flag can only be used with the yaml or json output formats, not "name"`,
+		},
+		"--output-dir with json is valid": {
+			outputDir:   "converted",
+			splitByKind: true,
+			output:      "json",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.OutputDir = test.outputDir
+			o.SplitByKind = test.splitByKind
+			o.Flatten = test.flatten
+			*o.PrintFlags.OutputFormat = test.output
+
+			err := o.validateOutputDir()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("Unexpected error; expected: %s, actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
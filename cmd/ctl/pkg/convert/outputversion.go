@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// isBareVersion returns true if version is given without its group, e.g.
+// "v1" rather than "cert-manager.io/v1".
+func isBareVersion(version string) bool {
+	return len(version) > 0 && !strings.Contains(version, "/")
+}
+
+// validateBareOutputVersion validates a bare OutputVersion, which has to be
+// supported by both cert-manager API groups as it is used for the objects of
+// either group.
+func (o *Options) validateBareOutputVersion() error {
+	var supported []string
+	var groups []string
+	for _, group := range []string{cmapi.SchemeGroupVersion.Group, cmacme.SchemeGroupVersion.Group} {
+		gv := schema.GroupVersion{Group: group, Version: o.OutputVersion}
+		if scheme.IsVersionRegistered(gv) {
+			groups = append(groups, gv.String())
+		}
+	}
+	switch len(groups) {
+	case 2:
+		return nil
+	case 1:
+		return fmt.Errorf("invalid --output-version %q: the version is ambiguous as it is only supported by %s, use the group version instead", o.OutputVersion, groups[0])
+	}
+
+	for _, version := range scheme.PrioritizedVersionsForGroup(cmapi.SchemeGroupVersion.Group) {
+		supported = append(supported, version.Version)
+	}
+	return fmt.Errorf("invalid --output-version %q: the version is not supported, must be one of: %s, or a group version", o.OutputVersion, strings.Join(supported, ", "))
+}
+
+// resolveBareOutputVersion resolves a bare OutputVersion to the version of
+// the cert-manager.io group, and converts the kinds of the
+// acme.cert-manager.io group to the same version of their group, unless
+// they are given by --output-version-per-kind. Needs to be called after
+// parseOutputVersionPerKind.
+func (o *Options) resolveBareOutputVersion() {
+	if !isBareVersion(o.OutputVersion) {
+		return
+	}
+	version := o.OutputVersion
+	o.OutputVersion = schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: version}.String()
+
+	acme := schema.GroupVersion{Group: cmacme.SchemeGroupVersion.Group, Version: version}
+	for _, kind := range acmeKinds(acme) {
+		if _, ok := o.outputVersions[kind]; !ok {
+			o.outputVersions[kind] = acme
+		}
+	}
+}
+
+// acmeKinds returns the kinds registered in the given acme.cert-manager.io
+// version which are not registered in the cert-manager.io group, e.g. Order
+// and Challenge, leaving out the kinds every group registers, e.g.
+// ListOptions.
+func acmeKinds(gv schema.GroupVersion) []string {
+	shared := scheme.KnownTypes(schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: gv.Version})
+	var kinds []string
+	for kind := range scheme.KnownTypes(gv) {
+		if _, ok := shared[kind]; !ok {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// certManagerGroups are the API groups of the objects converted with
+// --skip-non-cert-manager, objects of all other groups are passed through.
+var certManagerGroups = map[string]bool{
+	cmapi.SchemeGroupVersion.Group:  true,
+	cmacme.SchemeGroupVersion.Group: true,
+}
+
+// passThroughNonCertManager replaces the objects in infos which are not part
+// of the cert-manager API groups by their JSON encoding, so that they are
+// output unchanged in their original position, e.g. the Deployments and
+// Services of a chart rendered with 'helm template'. The objects are neither
+// cleaned nor converted as they are not unstructured anymore.
+func passThroughNonCertManager(infos []*resource.Info) error {
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		gvk := u.GroupVersionKind()
+		if certManagerGroups[gvk.Group] {
+			continue
+		}
+		data, err := u.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		info.Object = &runtime.Unknown{
+			TypeMeta:    runtime.TypeMeta{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind},
+			Raw:         data,
+			ContentType: runtime.ContentTypeJSON,
+		}
+	}
+	return nil
+}
+
+// isPassedThrough returns true if obj was passed through by
+// passThroughNonCertManager.
+func isPassedThrough(obj runtime.Object) bool {
+	_, ok := obj.(*runtime.Unknown)
+	return ok
+}
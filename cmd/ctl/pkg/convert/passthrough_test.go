@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestPassThroughNonCertManager(t *testing.T) {
+	tests := map[string]struct {
+		apiVersion string
+		expPassed  bool
+	}{
+		"Certificates are converted": {
+			apiVersion: "cert-manager.io/v1alpha2",
+		},
+		"ACME Orders are converted": {
+			apiVersion: "acme.cert-manager.io/v1alpha2",
+		},
+		"core resources are passed through": {
+			apiVersion: "v1",
+			expPassed:  true,
+		},
+		"resources of other groups are passed through": {
+			apiVersion: "apps/v1",
+			expPassed:  true,
+		},
+		"resources of groups ending with cert-manager.io are passed through": {
+			apiVersion: "trust.cert-manager.io/v1alpha1",
+			expPassed:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": test.apiVersion,
+				"kind":       "Test",
+				"metadata":   map[string]interface{}{"name": "test"},
+			}}
+			info := &resource.Info{Object: u}
+			if err := passThroughNonCertManager([]*resource.Info{info}); err != nil {
+				t.Fatal(err)
+			}
+
+			if passed := isPassedThrough(info.Object); passed != test.expPassed {
+				t.Fatalf("got unexpected pass through, exp=%t got=%t", test.expPassed, passed)
+			}
+			if !test.expPassed {
+				return
+			}
+			if gvk := info.Object.GetObjectKind().GroupVersionKind(); gvk != u.GroupVersionKind() {
+				t.Errorf("got unexpected kind, exp=%s got=%s", u.GroupVersionKind(), gvk)
+			}
+		})
+	}
+}
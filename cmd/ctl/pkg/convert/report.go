@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// reportFormats are the supported values of the --report-format flag.
+var reportFormats = []string{"markdown", "json"}
+
+// markdownEscaper escapes the characters which would break a markdown table cell.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// conversionReport records the conversion of every object.
+type conversionReport struct {
+	sourceVersions map[runtime.Object]schema.GroupVersion
+	entries        []reportEntry
+}
+
+type reportEntry struct {
+	// Kind and namespaced name of the object
+	Object        string `json:"-"`
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace,omitempty"`
+	Name          string `json:"name"`
+	SourceVersion string `json:"sourceVersion"`
+	TargetVersion string `json:"targetVersion"`
+	// True if converting back from the target version doesn't result in the
+	// original object, i.e. fields were lost by the conversion
+	Lossy bool `json:"lossy"`
+	// Fields which differ between the original and the round tripped object
+	Changes  []reportChange `json:"changes,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+// Types of changes between the original and the round tripped object
+const (
+	// The field is not part of the round tripped object
+	reportChangeRemoved = "Removed"
+	// The field is only part of the round tripped object
+	reportChangeAdded = "Added"
+	// The field has a different value in the round tripped object
+	reportChangeModified = "Modified"
+)
+
+// reportChange is a field of an object which was changed by converting the
+// object to the target version and back.
+type reportChange struct {
+	// Path of the field, e.g. spec.dnsNames[0]
+	Path string `json:"path"`
+	// One of Removed, Added or Modified
+	Type string `json:"type"`
+	// Value of the field in the original and the round tripped object
+	Original     interface{} `json:"original,omitempty"`
+	RoundTripped interface{} `json:"roundTripped,omitempty"`
+}
+
+func newConversionReport(sourceVersions map[runtime.Object]schema.GroupVersion) *conversionReport {
+	return &conversionReport{sourceVersions: sourceVersions}
+}
+
+// validateReport validates that the report format is supported, and that a
+// report file is only given in conjunction with a format.
+func (o *Options) validateReport() error {
+	if len(o.ReportFile) > 0 && len(o.ReportFormat) == 0 {
+		return errors.New("the --report-file flag must be used in conjunction with --report-format")
+	}
+	if len(o.ReportFormat) == 0 {
+		return nil
+	}
+	for _, format := range reportFormats {
+		if o.ReportFormat == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported report format %q, must be one of: %s", o.ReportFormat, strings.Join(reportFormats, ", "))
+}
+
+// add records the conversion of the internal object original into converted.
+// Does nothing if r is nil.
+func (r *conversionReport) add(original, converted runtime.Object) {
+	if r == nil {
+		return
+	}
+
+	targetGVK := converted.GetObjectKind().GroupVersionKind()
+	entry := reportEntry{
+		Object:        targetGVK.Kind,
+		Kind:          targetGVK.Kind,
+		SourceVersion: r.sourceVersions[original].String(),
+		TargetVersion: targetGVK.GroupVersion().String(),
+	}
+	if accessor, err := meta.Accessor(original); err == nil {
+		entry.Name = accessor.GetName()
+		entry.Namespace = accessor.GetNamespace()
+		name := entry.Name
+		if len(entry.Namespace) > 0 {
+			name = entry.Namespace + "/" + name
+		}
+		entry.Object += " " + name
+	}
+
+	roundTripped, err := scheme.ConvertToVersion(converted, runtime.InternalGroupVersioner)
+	switch {
+	case err != nil:
+		entry.Warnings = append(entry.Warnings, fmt.Sprintf("unable to check whether the conversion is lossy: %v", err))
+	case !apiequality.Semantic.DeepEqual(original, roundTripped):
+		entry.Lossy = true
+		entry.Warnings = append(entry.Warnings, fmt.Sprintf("fields which are not supported by %s were dropped", entry.TargetVersion))
+		changes, err := roundTripChanges(original, roundTripped, r.sourceVersions[original])
+		if err != nil {
+			entry.Warnings = append(entry.Warnings, fmt.Sprintf("unable to list the fields which were dropped: %v", err))
+		}
+		entry.Changes = changes
+	}
+
+	if versions := scheme.PrioritizedVersionsForGroup(targetGVK.Group); len(versions) > 0 && versions[0] != targetGVK.GroupVersion() {
+		entry.Warnings = append(entry.Warnings, fmt.Sprintf("%s is not the latest version, consider converting to %s", entry.TargetVersion, versions[0]))
+	}
+
+	r.entries = append(r.entries, entry)
+}
+
+// roundTripChanges returns the fields which differ between the internal
+// objects original and roundTripped, sorted by path. The internal types have
+// no JSON representation, so the objects are compared in the version original
+// was read in, which can represent all its fields.
+func roundTripChanges(original, roundTripped runtime.Object, sourceVersion schema.GroupVersion) ([]reportChange, error) {
+	originalFields, err := fieldsInVersion(original, sourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	roundTrippedFields, err := fieldsInVersion(roundTripped, sourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	return diffFields("", originalFields, roundTrippedFields), nil
+}
+
+// fieldsInVersion returns the JSON fields of obj converted to version.
+func fieldsInVersion(obj runtime.Object, version schema.GroupVersion) (map[string]interface{}, error) {
+	versioned, err := scheme.ConvertToVersion(obj.DeepCopyObject(), version)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(versioned)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffFields returns the changes between the values original and
+// roundTripped of the field at path. Maps are compared key by key and lists
+// item by item, other values are compared as a whole.
+func diffFields(path string, original, roundTripped interface{}) []reportChange {
+	switch {
+	case original == nil && roundTripped == nil:
+		return nil
+	case roundTripped == nil:
+		return []reportChange{{Path: path, Type: reportChangeRemoved, Original: original}}
+	case original == nil:
+		return []reportChange{{Path: path, Type: reportChangeAdded, RoundTripped: roundTripped}}
+	}
+
+	var changes []reportChange
+	switch original := original.(type) {
+	case map[string]interface{}:
+		roundTripped, ok := roundTripped.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(original)+len(roundTripped))
+		for key := range original {
+			keys = append(keys, key)
+		}
+		for key := range roundTripped {
+			if _, ok := original[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := key
+			if len(path) > 0 {
+				fieldPath = path + "." + key
+			}
+			changes = append(changes, diffFields(fieldPath, original[key], roundTripped[key])...)
+		}
+		return changes
+	case []interface{}:
+		roundTripped, ok := roundTripped.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(original) || i < len(roundTripped); i++ {
+			var originalItem, roundTrippedItem interface{}
+			if i < len(original) {
+				originalItem = original[i]
+			}
+			if i < len(roundTripped) {
+				roundTrippedItem = roundTripped[i]
+			}
+			changes = append(changes, diffFields(fmt.Sprintf("%s[%d]", path, i), originalItem, roundTrippedItem)...)
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(original, roundTripped) {
+		return nil
+	}
+	return []reportChange{{Path: path, Type: reportChangeModified, Original: original, RoundTripped: roundTripped}}
+}
+
+// writeReport writes the report in the format given by --report-format to
+// stderr, or the report file if set.
+func (o *Options) writeReport(report *conversionReport) error {
+	write := writeMarkdownReport
+	if o.ReportFormat == "json" {
+		write = writeJSONReport
+	}
+
+	if len(o.ReportFile) == 0 {
+		return write(o.ErrOut, report)
+	}
+
+	f, err := os.Create(o.ReportFile)
+	if err != nil {
+		return fmt.Errorf("error when creating the report file: %w", err)
+	}
+	if err := write(f, report); err != nil {
+		f.Close()
+		return fmt.Errorf("error when writing the report file: %w", err)
+	}
+	return f.Close()
+}
+
+// writeMarkdownReport writes the report as a markdown table to w.
+func writeMarkdownReport(w io.Writer, report *conversionReport) error {
+	var b strings.Builder
+	b.WriteString("| Resource | Source Version | Target Version | Lossy | Warnings |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, entry := range report.entries {
+		lossy := "No"
+		if entry.Lossy {
+			lossy = "Yes"
+		}
+		cells := []string{entry.Object, entry.SourceVersion, entry.TargetVersion, lossy, strings.Join(entry.Warnings, "; ")}
+		for i := range cells {
+			cells[i] = markdownEscaper.Replace(cells[i])
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeJSONReport writes the report as a JSON object to w, listing the
+// changed fields of every lossy conversion, so that it can be processed by
+// other tools.
+func writeJSONReport(w io.Writer, report *conversionReport) error {
+	entries := report.entries
+	if entries == nil {
+		entries = []reportEntry{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Resources []reportEntry `json:"resources"`
+	}{entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager/v1alpha2"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestMarkdownReport(t *testing.T) {
+	original := &certmanager.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crt", Namespace: "default"},
+		Spec:       certmanager.CertificateSpec{SecretName: "test-crt", DNSNames: []string{"example.com"}},
+	}
+	mustConvert := func(gv schema.GroupVersion) runtime.Object {
+		obj, err := scheme.ConvertToVersion(original, gv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+
+	lossless := mustConvert(cmapi.SchemeGroupVersion)
+	deprecated := mustConvert(v1alpha2.SchemeGroupVersion)
+	// Simulate a target version which cannot represent the DNS names
+	lossy := mustConvert(cmapi.SchemeGroupVersion).(*cmapi.Certificate)
+	lossy.Spec.DNSNames = nil
+
+	report := newConversionReport(map[runtime.Object]schema.GroupVersion{original: v1alpha2.SchemeGroupVersion})
+	report.add(original, lossless)
+	report.add(original, deprecated)
+	report.add(original, lossy)
+
+	var out bytes.Buffer
+	if err := writeMarkdownReport(&out, report); err != nil {
+		t.Fatal(err)
+	}
+
+	expOutput := `| Resource | Source Version | Target Version | Lossy | Warnings |
+| --- | --- | --- | --- | --- |
+| Certificate default/test-crt | cert-manager.io/v1alpha2 | cert-manager.io/v1 | No |  |
+| Certificate default/test-crt | cert-manager.io/v1alpha2 | cert-manager.io/v1alpha2 | No | cert-manager.io/v1alpha2 is not the latest version, consider converting to cert-manager.io/v1 |
+| Certificate default/test-crt | cert-manager.io/v1alpha2 | cert-manager.io/v1 | Yes | fields which are not supported by cert-manager.io/v1 were dropped |
+`
+	if out.String() != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, out.String())
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	original := &certmanager.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crt", Namespace: "default"},
+		Spec:       certmanager.CertificateSpec{SecretName: "test-crt", DNSNames: []string{"example.com", "www.example.com"}, CommonName: "example.com"},
+	}
+	lossless, err := scheme.ConvertToVersion(original, cmapi.SchemeGroupVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a target version which cannot represent all DNS names, and
+	// changes the common name
+	lossy := lossless.DeepCopyObject().(*cmapi.Certificate)
+	lossy.Spec.DNSNames = lossy.Spec.DNSNames[:1]
+	lossy.Spec.CommonName = "www.example.com"
+
+	report := newConversionReport(map[runtime.Object]schema.GroupVersion{original: v1alpha2.SchemeGroupVersion})
+	report.add(original, lossless)
+	report.add(original, lossy)
+
+	var out bytes.Buffer
+	if err := writeJSONReport(&out, report); err != nil {
+		t.Fatal(err)
+	}
+
+	expOutput := `{
+  "resources": [
+    {
+      "kind": "Certificate",
+      "namespace": "default",
+      "name": "test-crt",
+      "sourceVersion": "cert-manager.io/v1alpha2",
+      "targetVersion": "cert-manager.io/v1",
+      "lossy": false
+    },
+    {
+      "kind": "Certificate",
+      "namespace": "default",
+      "name": "test-crt",
+      "sourceVersion": "cert-manager.io/v1alpha2",
+      "targetVersion": "cert-manager.io/v1",
+      "lossy": true,
+      "changes": [
+        {
+          "path": "spec.commonName",
+          "type": "Modified",
+          "original": "example.com",
+          "roundTripped": "www.example.com"
+        },
+        {
+          "path": "spec.dnsNames[1]",
+          "type": "Removed",
+          "original": "www.example.com"
+        }
+      ],
+      "warnings": [
+        "fields which are not supported by cert-manager.io/v1 were dropped"
+      ]
+    }
+  ]
+}
+`
+	if out.String() != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, out.String())
+	}
+}
+
+func TestReportPrivateKeyLossless(t *testing.T) {
+	privateKeys := map[string]*certmanager.CertificatePrivateKey{
+		"ECDSA": {
+			Algorithm:      certmanager.ECDSAKeyAlgorithm,
+			Size:           384,
+			Encoding:       certmanager.PKCS8,
+			RotationPolicy: certmanager.RotationPolicyAlways,
+		},
+		"RSA": {
+			Algorithm:      certmanager.RSAKeyAlgorithm,
+			Size:           4096,
+			Encoding:       certmanager.PKCS1,
+			RotationPolicy: certmanager.RotationPolicyNever,
+		},
+		"Ed25519": {
+			Algorithm: certmanager.Ed25519KeyAlgorithm,
+		},
+		"rotation policy only": {
+			RotationPolicy: certmanager.RotationPolicyAlways,
+		},
+	}
+
+	for name, privateKey := range privateKeys {
+		for _, gv := range scheme.PrioritizedVersionsForGroup(cmapi.SchemeGroupVersion.Group) {
+			t.Run(name+" "+gv.String(), func(t *testing.T) {
+				original := &certmanager.Certificate{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-crt", Namespace: "default"},
+					Spec:       certmanager.CertificateSpec{SecretName: "test-crt", PrivateKey: privateKey},
+				}
+				converted, err := scheme.ConvertToVersion(original, gv)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				report := newConversionReport(map[runtime.Object]schema.GroupVersion{original: cmapi.SchemeGroupVersion})
+				report.add(original, converted)
+				if report.entries[0].Lossy {
+					t.Errorf("Unexpected lossy conversion of the private key options to %s: %v", gv, report.entries[0].Warnings)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateReport(t *testing.T) {
+	tests := map[string]struct {
+		reportFormat string
+		reportFile   string
+		expErrMsg    string
+	}{
+		"no report is valid": {},
+		"markdown report is valid": {
+			reportFormat: "markdown",
+		},
+		"json report is valid": {
+			reportFormat: "json",
+		},
+		"markdown report written to a file is valid": {
+			reportFormat: "markdown",
+			reportFile:   "report.md",
+		},
+		"unsupported format throws error": {
+			reportFormat: "html",
+			expErrMsg:    `unsupported report format "html", must be one of: markdown, json`,
+		},
+		"report file without format throws error": {
+			reportFile: "report.md",
+			expErrMsg:  "the --report-file flag must be used in conjunction with --report-format",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := &Options{ReportFormat: test.reportFormat, ReportFile: test.reportFile}
+			err := opts.validateReport()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// requiredFields are the paths of the fields marked as required by the
+// OpenAPI schemas of the cert-manager CRDs. Parents are listed before their
+// children, so that only the parent is reported if it is missing. The paths
+// are those of v1, which are the same in all versions except for the fields
+// renamed in csrFieldVersions.
+var requiredFields = map[schema.GroupKind][][]string{
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.CertificateKind}: {
+		{"spec"},
+		{"spec", "secretName"},
+		{"spec", "issuerRef"},
+		{"spec", "issuerRef", "name"},
+	},
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.CertificateRequestKind}: {
+		{"spec"},
+		{"spec", "request"},
+		{"spec", "issuerRef"},
+		{"spec", "issuerRef", "name"},
+	},
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.IssuerKind}: {
+		{"spec"},
+	},
+	{Group: cmapi.SchemeGroupVersion.Group, Kind: cmapi.ClusterIssuerKind}: {
+		{"spec"},
+	},
+	{Group: cmacme.SchemeGroupVersion.Group, Kind: cmacme.OrderKind}: {
+		{"spec"},
+		{"spec", "request"},
+		{"spec", "issuerRef"},
+		{"spec", "issuerRef", "name"},
+	},
+	{Group: cmacme.SchemeGroupVersion.Group, Kind: cmacme.ChallengeKind}: {
+		{"spec"},
+		{"spec", "url"},
+		{"spec", "authorizationURL"},
+		{"spec", "dnsName"},
+		{"spec", "type"},
+		{"spec", "token"},
+		{"spec", "key"},
+		{"spec", "solver"},
+		{"spec", "issuerRef"},
+		{"spec", "issuerRef", "name"},
+	},
+}
+
+// csrFieldVersions are the versions in which the spec.request field of
+// CertificateRequests and Orders is named spec.csr.
+var csrFieldVersions = map[string]bool{"v1alpha2": true, "v1alpha3": true}
+
+// validateComplete returns an error listing the missing required fields of
+// every cert-manager object in objects, each of which is either a single
+// object or a List of objects. Fields set to their zero value are considered
+// missing.
+func validateComplete(objects ...runtime.Object) error {
+	var items []runtime.Object
+	for _, obj := range objects {
+		if !meta.IsListType(obj) {
+			items = append(items, obj)
+			continue
+		}
+		listItems, err := meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+		items = append(items, listItems...)
+	}
+
+	var incomplete []string
+	for _, object := range items {
+		missing, err := missingRequiredFields(object)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return err
+		}
+		name := accessor.GetName()
+		if len(accessor.GetNamespace()) > 0 {
+			name = accessor.GetNamespace() + "/" + name
+		}
+		incomplete = append(incomplete, fmt.Sprintf("\t%s %q is missing the required fields: %s",
+			object.GetObjectKind().GroupVersionKind().Kind, name, strings.Join(missing, ", ")))
+	}
+
+	if len(incomplete) > 0 {
+		return fmt.Errorf("refusing to output incomplete resources:\n%s", strings.Join(incomplete, "\n"))
+	}
+	return nil
+}
+
+// missingRequiredFields returns the paths of the required fields which are
+// not set in obj. Objects which are not cert-manager resources have no
+// required fields.
+func missingRequiredFields(obj runtime.Object) ([]string, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	paths := requiredFields[gvk.GroupKind()]
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, path := range paths {
+		if csrFieldVersions[gvk.Version] && strings.Join(path, ".") == "spec.request" {
+			path = []string{"spec", "csr"}
+		}
+		if hasMissingParent(missing, path) {
+			continue
+		}
+		if isZero(nestedValue(content, path)) {
+			missing = append(missing, strings.Join(path, "."))
+		}
+	}
+	return missing, nil
+}
+
+// hasMissingParent returns true if a parent of path is in missing.
+func hasMissingParent(missing []string, path []string) bool {
+	for i := 1; i < len(path); i++ {
+		parent := strings.Join(path[:i], ".")
+		for _, m := range missing {
+			if m == parent {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nestedValue returns the value at path in content, or nil if it isn't set.
+func nestedValue(content map[string]interface{}, path []string) interface{} {
+	var value interface{} = content
+	for _, field := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[field]
+	}
+	return value
+}
+
+// isZero returns true if value is not set, or set to an empty string, map
+// or list.
+func isZero(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
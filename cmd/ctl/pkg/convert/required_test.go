@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager/v1alpha2"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestValidateComplete(t *testing.T) {
+	completeCrt := &cmapi.Certificate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+		ObjectMeta: metav1.ObjectMeta{Name: "complete", Namespace: "default"},
+		Spec: cmapi.CertificateSpec{
+			SecretName: "complete-tls",
+			IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer"},
+		},
+	}
+	incompleteCrt := &cmapi.Certificate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+		ObjectMeta: metav1.ObjectMeta{Name: "incomplete", Namespace: "default"},
+		Spec:       cmapi.CertificateSpec{DNSNames: []string{"example.com"}},
+	}
+	incompleteCR := &v1alpha2.CertificateRequest{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1alpha2", Kind: "CertificateRequest"},
+		ObjectMeta: metav1.ObjectMeta{Name: "incomplete"},
+		Spec:       v1alpha2.CertificateRequestSpec{IssuerRef: cmmeta.ObjectReference{Name: "ca-issuer"}},
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+	}
+
+	tests := map[string]struct {
+		obj       runtime.Object
+		expErrMsg string
+	}{
+		"complete object is valid": {
+			obj: completeCrt,
+		},
+		"missing fields of a single object are listed": {
+			obj: incompleteCrt,
+			expErrMsg: `refusing to output incomplete resources:
+	Certificate "default/incomplete" is missing the required fields: spec.secretName, spec.issuerRef.name`,
+		},
+		"missing fields of every object in a list are listed using the field names of their version": {
+			obj: &metav1.List{Items: []runtime.RawExtension{
+				{Object: completeCrt}, {Object: incompleteCrt}, {Object: incompleteCR}, {Object: configMap},
+			}},
+			expErrMsg: `refusing to output incomplete resources:
+	Certificate "default/incomplete" is missing the required fields: spec.secretName, spec.issuerRef.name
+	CertificateRequest "incomplete" is missing the required fields: spec.csr`,
+		},
+		"objects which aren't cert-manager resources are valid": {
+			obj: configMap,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateComplete(test.obj)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// stdinFilename is the filename referring to the input stream.
+const stdinFilename = "-"
+
+// validateStdin validates that the input stream is given as filename at most
+// once, as it can only be read once.
+func (o *Options) validateStdin() error {
+	count := 0
+	for _, filename := range o.Filenames {
+		if filename == stdinFilename {
+			count++
+		}
+	}
+	if count > 1 {
+		return errors.New("the filename \"-\" can only be given once")
+	}
+	return nil
+}
+
+// readsStdin returns true if the input stream is given as filename.
+func (o *Options) readsStdin() bool {
+	for _, filename := range o.Filenames {
+		if filename == stdinFilename {
+			return true
+		}
+	}
+	return false
+}
+
+// filenameParam adds the files given by the filename options to builder.
+// The filename "-" is read from the input stream of the command instead of
+// the stdin of the process, and the files are read in the order given. Local
+// files and the input stream are read as a mixedDocumentReader, so that JSON
+// and YAML documents can be mixed, with the default apiVersion and kind set if
+// --default-kind is given. As the builder only implies a single item for a
+// single file it reads itself, true is returned if a single local file or
+// only the input stream is read, so that a single object is printed as such
+// for either. The returned function closes the files, and has to be called
+// once the builder is done.
+func (o *Options) filenameParam(builder *resource.Builder) (*resource.Builder, bool, func(), error) {
+	if len(o.Kustomize) > 0 {
+		return builder.FilenameParam(false, &o.FilenameOptions), false, func() {}, nil
+	}
+
+	var readers []*mixedDocumentReader
+	closeFiles := func() {
+		for _, r := range readers {
+			r.Close()
+		}
+	}
+	for _, filename := range o.Filenames {
+		if filename == stdinFilename || isLocalFile(filename) {
+			source := filename
+			if filename == stdinFilename {
+				source = "stdin"
+			}
+			r := o.openMixedDocuments(filename)
+			readers = append(readers, r)
+			var in io.Reader = r
+			if len(o.DefaultKind) > 0 {
+				// Objects without a type cannot be decoded by the builder,
+				// so the defaults have to be applied before handing the
+				// input over
+				defaulted, err := o.withDefaultType(r, filename)
+				if err != nil {
+					closeFiles()
+					return nil, false, nil, err
+				}
+				in = defaulted
+			}
+			builder = builder.Stream(in, source)
+			continue
+		}
+		// URLs and missing files are reported by the builder
+		options := o.FilenameOptions
+		options.Filenames = []string{filename}
+		builder = builder.FilenameParam(false, &options)
+	}
+	singleFile := len(o.Filenames) == 1 && len(readers) == 1
+	return builder, singleFile, closeFiles, nil
+}
+
+// isLocalFile returns true if filename refers to an existing regular file.
+func isLocalFile(filename string) bool {
+	if strings.HasPrefix(filename, "http://") || strings.HasPrefix(filename, "https://") {
+		return false
+	}
+	info, err := os.Stat(filename)
+	return err == nil && info.Mode().IsRegular()
+}
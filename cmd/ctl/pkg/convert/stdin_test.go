@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRunStdin(t *testing.T) {
+	const (
+		issuer = `apiVersion: cert-manager.io/v1alpha2
+kind: Issuer
+metadata:
+  name: ca-issuer
+  namespace: default
+spec:
+  ca:
+    secretName: ca-key-pair
+`
+		certificate = `apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: crt
+  namespace: default
+spec:
+  secretName: crt
+  issuerRef:
+    name: ca-issuer
+`
+	)
+	file := filepath.Join(t.TempDir(), "certificate.yaml")
+	if err := os.WriteFile(file, []byte(certificate), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		input         string
+		filenames     []string
+		outputVersion string
+
+		expKinds  []string
+		expErrMsg string
+	}{
+		"every document read from stdin is converted": {
+			input:     "---\n" + issuer + "---\n" + certificate,
+			filenames: []string{"-"},
+			expKinds:  []string{"kind: Issuer", "kind: Certificate"},
+		},
+		"stdin is converted to the output version": {
+			input:         issuer,
+			filenames:     []string{"-"},
+			outputVersion: "cert-manager.io/v1beta1",
+			expKinds:      []string{"apiVersion: cert-manager.io/v1beta1"},
+		},
+		"stdin and files are read in the order given": {
+			input:     issuer,
+			filenames: []string{file, "-"},
+			expKinds:  []string{"kind: Certificate", "kind: Issuer"},
+		},
+		"empty stdin throws error": {
+			filenames: []string{"-"},
+			expErrMsg: "no objects passed to convert, no objects were read from stdin",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewOptions(genericclioptions.IOStreams{In: strings.NewReader(test.input), Out: new(bytes.Buffer), ErrOut: new(bytes.Buffer)})
+			o.Filenames = test.filenames
+			o.OutputVersion = test.outputVersion
+			if err := o.Complete(); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Run(context.TODO())
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output := o.Out.(*bytes.Buffer).String()
+			last := -1
+			for _, expKind := range test.expKinds {
+				i := strings.Index(output, expKind)
+				if i <= last {
+					t.Errorf("Unexpected output; expected %q after the previous objects in: \n%s", expKind, output)
+				}
+				last = i
+			}
+		})
+	}
+}
+
+func TestValidateStdin(t *testing.T) {
+	o := &Options{}
+	o.Filenames = []string{"-", "cert.yaml", "-"}
+	expErrMsg := `the filename "-" can only be given once`
+	if err := o.validateStdin(); err == nil || err.Error() != expErrMsg {
+		t.Errorf("Unexpected error; expected: \n%s\nactual: \n%v", expErrMsg, err)
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Create cert-manager Certificate resources matching the TLS configuration of an existing Ingress.
+
+One Certificate is generated for every secretName listed in the Ingress's TLS section,
+requesting all of the hosts listed for that secret. This makes it possible to move from
+ingress-shim managed Certificates to explicitly defined ones.
+
+If no issuer is given using the --issuer flag, the issuer referenced by the Ingress's
+'cert-manager.io/issuer' or 'cert-manager.io/cluster-issuer' annotation is used. As
+for ingress-shim, the 'cert-manager.io/issuer-kind' and 'cert-manager.io/issuer-group'
+annotations select the kind and group of an external issuer.
+
+By default the generated Certificates are printed without being created,
+use the --apply flag to create them in the cluster.
+
+With --validate, the generated Certificates are submitted to the cluster as a
+server-side dry-run, so that they are validated by the cert-manager webhook
+without being created. The Certificates are printed as returned by the API
+server, and the admission errors of all invalid Certificates are reported.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Print the Certificates matching the TLS configuration of the Ingress 'my-ingress' in namespace 'my-namespace'.
+{{.BuildName}} create certificate --from-ingress my-ingress --namespace my-namespace --issuer my-issuer
+
+# Create the Certificates matching the Ingress 'my-ingress', issued by the ClusterIssuer 'letsencrypt'.
+{{.BuildName}} create certificate --from-ingress my-ingress --issuer letsencrypt --issuer-kind ClusterIssuer --apply
+
+# Check that the Certificates matching the Ingress 'my-ingress' would be accepted by the cluster, without creating them.
+{{.BuildName}} create certificate --from-ingress my-ingress --issuer letsencrypt --issuer-kind ClusterIssuer --validate
+`)))
+)
+
+// Options is a struct to support create certificate command
+type Options struct {
+	// Name of the Ingress the Certificates are generated from
+	// Required
+	IngressName string
+	// Name of the issuer referenced by the generated Certificates
+	// If not specified, the issuer annotations of the Ingress are used
+	IssuerName string
+	// Kind of the issuer referenced by the generated Certificates
+	IssuerKind string
+	// Group of the issuer referenced by the generated Certificates
+	IssuerGroup string
+	// If true, the generated Certificates are created in the cluster
+	// rather than only being printed
+	Apply bool
+	// If true, the generated Certificates are submitted to the cluster as a
+	// server-side dry-run to be validated, without being created
+	DryRunValidate bool
+
+	PrintFlags *genericclioptions.PrintFlags
+	Printer    printers.ResourcePrinter
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams:  ioStreams,
+		PrintFlags: genericclioptions.NewPrintFlags("created").WithDefaultOutput("yaml"),
+	}
+}
+
+// NewCmdCreateCertificate returns a cobra command for create Certificate
+func NewCmdCreateCertificate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "certificate",
+		Aliases: []string{"cert"},
+		Short:   "Create cert-manager Certificate resources matching the TLS configuration of an Ingress",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Complete())
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.Flags().StringVar(&o.IngressName, "from-ingress", o.IngressName,
+		"Name of the Ingress whose TLS configuration is used to generate the Certificates")
+	cmd.Flags().StringVar(&o.IssuerName, "issuer", o.IssuerName,
+		"Name of the issuer referenced by the generated Certificates, defaults to the issuer annotated on the Ingress")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", cmapi.IssuerKind,
+		"Kind of the issuer referenced by the generated Certificates, e.g. Issuer or ClusterIssuer")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", "cert-manager.io",
+		"Group of the issuer referenced by the generated Certificates")
+	cmd.Flags().BoolVar(&o.Apply, "apply", o.Apply,
+		"If set to true, the generated Certificates are created in the cluster instead of only being printed")
+	cmd.Flags().BoolVar(&o.DryRunValidate, "validate", o.DryRunValidate,
+		"If set to true, the generated Certificates are validated by the cluster with a server-side dry-run, without being created")
+
+	o.PrintFlags.AddFlags(cmd)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are allowed, please specify the Ingress by using --from-ingress flag")
+	}
+
+	if o.IngressName == "" {
+		return errors.New("the name of the Ingress cannot be empty, please specify by using --from-ingress flag")
+	}
+
+	if o.Apply && o.DryRunValidate {
+		return errors.New("the --validate flag cannot be used in conjunction with the --apply flag")
+	}
+
+	return nil
+}
+
+// Complete takes the command arguments and factory and infers any remaining options.
+func (o *Options) Complete() error {
+	switch {
+	case o.DryRunValidate:
+		o.PrintFlags.NamePrintFlags.Operation = "validated"
+	case !o.Apply:
+		o.PrintFlags.NamePrintFlags.Operation = "generated"
+	}
+
+	var err error
+	o.Printer, err = o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run executes create certificate command
+func (o *Options) Run(ctx context.Context) error {
+	ing, err := o.KubeClient.NetworkingV1().Ingresses(o.Namespace).Get(ctx, o.IngressName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Ingress resource: %w", err)
+	}
+
+	issuerRef, err := o.issuerRef(ing)
+	if err != nil {
+		return err
+	}
+
+	crts, err := buildCertificates(ing, issuerRef)
+	if err != nil {
+		return fmt.Errorf("error when building Certificates from Ingress %s: %w", ing.Name, err)
+	}
+
+	if o.DryRunValidate {
+		return o.validateCertificates(ctx, crts)
+	}
+
+	for _, crt := range crts {
+		if o.Apply {
+			crt, err = o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{})
+			if err != nil {
+				return fmt.Errorf("error creating Certificate: %w", err)
+			}
+			// The returned object has no TypeMeta, which the printers need.
+			crt.SetGroupVersionKind(cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))
+		}
+
+		if err := o.Printer.PrintObj(crt, o.Out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateCertificates submits crts to the cluster as a server-side dry-run,
+// and prints the Certificates returned by the API server. The admission errors
+// of all invalid Certificates are printed, and result in an error.
+func (o *Options) validateCertificates(ctx context.Context, crts []*cmapi.Certificate) error {
+	invalid := 0
+	for _, crt := range crts {
+		validated, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Create(ctx, crt, metav1.CreateOptions{
+			DryRun: []string{metav1.DryRunAll},
+		})
+		if err != nil {
+			if _, ok := err.(apierrors.APIStatus); !ok {
+				return fmt.Errorf("error validating Certificate, unable to reach the cluster: %w", err)
+			}
+			invalid++
+			fmt.Fprintf(o.ErrOut, "error: Certificate %s/%s is invalid: %v\n", crt.Namespace, crt.Name, err)
+			continue
+		}
+		// The returned object has no TypeMeta, which the printers need.
+		validated.SetGroupVersionKind(cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind))
+
+		if err := o.Printer.PrintObj(validated, o.Out); err != nil {
+			return err
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d Certificates failed validation", invalid, len(crts))
+	}
+	return nil
+}
+
+// issuerRef returns the issuer the generated Certificates should reference.
+// The issuer passed by flag takes precedence over the issuer annotations
+// of the Ingress, which are read the same way ingress-shim does.
+func (o *Options) issuerRef(ing *networkingv1.Ingress) (cmmeta.ObjectReference, error) {
+	if o.IssuerName != "" {
+		return cmmeta.ObjectReference{
+			Name:  o.IssuerName,
+			Kind:  o.IssuerKind,
+			Group: o.IssuerGroup,
+		}, nil
+	}
+
+	issuerName, issuerNameOK := ing.Annotations[cmapi.IngressIssuerNameAnnotationKey]
+	clusterIssuerName, clusterIssuerNameOK := ing.Annotations[cmapi.IngressClusterIssuerNameAnnotationKey]
+	kind, kindOK := ing.Annotations[cmapi.IssuerKindAnnotationKey]
+	group, groupOK := ing.Annotations[cmapi.IssuerGroupAnnotationKey]
+
+	switch {
+	case issuerNameOK && clusterIssuerNameOK:
+		return cmmeta.ObjectReference{}, fmt.Errorf("the Ingress %s has both the %q and %q annotations set, please specify the issuer by using --issuer flag",
+			ing.Name, cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey)
+	case clusterIssuerNameOK && kindOK:
+		return cmmeta.ObjectReference{}, fmt.Errorf("the Ingress %s has both the %q and %q annotations set, please specify the issuer by using --issuer flag",
+			ing.Name, cmapi.IngressClusterIssuerNameAnnotationKey, cmapi.IssuerKindAnnotationKey)
+	case clusterIssuerNameOK && groupOK:
+		return cmmeta.ObjectReference{}, fmt.Errorf("the Ingress %s has both the %q and %q annotations set, please specify the issuer by using --issuer flag",
+			ing.Name, cmapi.IngressClusterIssuerNameAnnotationKey, cmapi.IssuerGroupAnnotationKey)
+	}
+
+	ref := cmmeta.ObjectReference{Group: o.IssuerGroup}
+	switch {
+	case issuerName != "":
+		ref.Name, ref.Kind = issuerName, cmapi.IssuerKind
+	case clusterIssuerName != "":
+		ref.Name, ref.Kind = clusterIssuerName, cmapi.ClusterIssuerKind
+	default:
+		return cmmeta.ObjectReference{}, fmt.Errorf("the Ingress %s does not reference an issuer, please specify the issuer by using --issuer flag", ing.Name)
+	}
+
+	// External issuers are referenced with the issuer-kind and issuer-group
+	// annotations next to the issuer annotation.
+	if kindOK {
+		ref.Kind = kind
+	}
+	if groupOK {
+		ref.Group = group
+	}
+
+	return ref, nil
+}
+
+// buildCertificates builds one Certificate for every secretName in the TLS
+// section of the Ingress. Hosts of TLS entries sharing the same secretName
+// are merged into a single Certificate, matching the behaviour of ingress-shim.
+func buildCertificates(ing *networkingv1.Ingress, issuerRef cmmeta.ObjectReference) ([]*cmapi.Certificate, error) {
+	if len(ing.Spec.TLS) == 0 {
+		return nil, errors.New("the Ingress has no TLS entries")
+	}
+
+	var crts []*cmapi.Certificate
+	bySecretName := make(map[string]*cmapi.Certificate)
+	for i, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			return nil, fmt.Errorf("TLS entry %d has no secretName", i)
+		}
+		if len(tls.Hosts) == 0 {
+			return nil, fmt.Errorf("TLS entry %d for secret %q has no hosts", i, tls.SecretName)
+		}
+
+		crt, ok := bySecretName[tls.SecretName]
+		if !ok {
+			crt = &cmapi.Certificate{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: cmapi.SchemeGroupVersion.String(),
+					Kind:       cmapi.CertificateKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tls.SecretName,
+					Namespace: ing.Namespace,
+				},
+				Spec: cmapi.CertificateSpec{
+					SecretName: tls.SecretName,
+					IssuerRef:  issuerRef,
+				},
+			}
+			bySecretName[tls.SecretName] = crt
+			crts = append(crts, crt)
+		}
+
+		for _, host := range tls.Hosts {
+			if !containsString(crt.Spec.DNSNames, host) {
+				crt.Spec.DNSNames = append(crt.Spec.DNSNames, host)
+			}
+		}
+	}
+
+	return crts, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
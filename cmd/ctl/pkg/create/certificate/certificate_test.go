@@ -0,0 +1,312 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		ingressName string
+		inputArgs   []string
+		apply       bool
+		validate    bool
+
+		expErr    bool
+		expErrMsg string
+	}{
+		"passing an argument throws error": {
+			ingressName: "my-ingress",
+			inputArgs:   []string{"my-ingress"},
+			expErr:      true,
+			expErrMsg:   "no arguments are allowed, please specify the Ingress by using --from-ingress flag",
+		},
+		"not specifying the Ingress throws error": {
+			ingressName: "",
+			expErr:      true,
+			expErrMsg:   "the name of the Ingress cannot be empty, please specify by using --from-ingress flag",
+		},
+		"--validate in conjunction with --apply throws error": {
+			ingressName: "my-ingress",
+			apply:       true,
+			validate:    true,
+			expErr:      true,
+			expErrMsg:   "the --validate flag cannot be used in conjunction with the --apply flag",
+		},
+		"specifying the Ingress is valid": {
+			ingressName: "my-ingress",
+			expErr:      false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := &Options{
+				IngressName:    test.ingressName,
+				Apply:          test.apply,
+				DryRunValidate: test.validate,
+			}
+
+			err := opts.Validate(test.inputArgs)
+			if err != nil {
+				if !test.expErr {
+					t.Fatalf("got unexpected error when validating args and flags: %v", err)
+				}
+				if err.Error() != test.expErrMsg {
+					t.Fatalf("got unexpected error when validating args and flags, expected: %v; actual: %v", test.expErrMsg, err)
+				}
+			} else if test.expErr {
+				t.Errorf("expected but got no error validating args and flags")
+			}
+		})
+	}
+}
+
+func TestIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		opts        *Options
+		annotations map[string]string
+
+		expIssuerRef cmmeta.ObjectReference
+		expErr       bool
+	}{
+		"issuer flag takes precedence over annotations": {
+			opts:         &Options{IssuerName: "flag-issuer", IssuerKind: cmapi.ClusterIssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations:  map[string]string{cmapi.IngressIssuerNameAnnotationKey: "annotated-issuer"},
+			expIssuerRef: cmmeta.ObjectReference{Name: "flag-issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+		},
+		"issuer annotation is used if no issuer flag is given": {
+			opts:         &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations:  map[string]string{cmapi.IngressIssuerNameAnnotationKey: "annotated-issuer"},
+			expIssuerRef: cmmeta.ObjectReference{Name: "annotated-issuer", Kind: cmapi.IssuerKind, Group: "cert-manager.io"},
+		},
+		"cluster-issuer annotation is used if no issuer flag is given": {
+			opts:         &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations:  map[string]string{cmapi.IngressClusterIssuerNameAnnotationKey: "annotated-cluster-issuer"},
+			expIssuerRef: cmmeta.ObjectReference{Name: "annotated-cluster-issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"},
+		},
+		"issuer-kind and issuer-group annotations select an external issuer": {
+			opts: &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations: map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "annotated-issuer",
+				cmapi.IssuerKindAnnotationKey:        "AWSPCAClusterIssuer",
+				cmapi.IssuerGroupAnnotationKey:       "awspca.cert-manager.io",
+			},
+			expIssuerRef: cmmeta.ObjectReference{Name: "annotated-issuer", Kind: "AWSPCAClusterIssuer", Group: "awspca.cert-manager.io"},
+		},
+		"issuer-group annotation defaults the kind to Issuer": {
+			opts: &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations: map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey: "annotated-issuer",
+				cmapi.IssuerGroupAnnotationKey:       "example.com",
+			},
+			expIssuerRef: cmmeta.ObjectReference{Name: "annotated-issuer", Kind: cmapi.IssuerKind, Group: "example.com"},
+		},
+		"cluster-issuer annotation with issuer-kind annotation throws error": {
+			opts: &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations: map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey: "annotated-cluster-issuer",
+				cmapi.IssuerKindAnnotationKey:               "Issuer",
+			},
+			expErr: true,
+		},
+		"cluster-issuer annotation with issuer-group annotation throws error": {
+			opts: &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations: map[string]string{
+				cmapi.IngressClusterIssuerNameAnnotationKey: "annotated-cluster-issuer",
+				cmapi.IssuerGroupAnnotationKey:              "example.com",
+			},
+			expErr: true,
+		},
+		"both issuer annotations throws error": {
+			opts: &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			annotations: map[string]string{
+				cmapi.IngressIssuerNameAnnotationKey:        "annotated-issuer",
+				cmapi.IngressClusterIssuerNameAnnotationKey: "annotated-cluster-issuer",
+			},
+			expErr: true,
+		},
+		"no issuer flag or annotation throws error": {
+			opts:   &Options{IssuerKind: cmapi.IssuerKind, IssuerGroup: "cert-manager.io"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ing := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-ingress", Annotations: test.annotations},
+			}
+
+			issuerRef, err := test.opts.issuerRef(ing)
+			if err != nil {
+				if !test.expErr {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+				return
+			}
+			if test.expErr {
+				t.Fatalf("expected but got no error")
+			}
+			if !reflect.DeepEqual(issuerRef, test.expIssuerRef) {
+				t.Errorf("unexpected issuer reference, expected: %+v; actual: %+v", test.expIssuerRef, issuerRef)
+			}
+		})
+	}
+}
+
+func TestBuildCertificates(t *testing.T) {
+	issuerRef := cmmeta.ObjectReference{Name: "my-issuer", Kind: cmapi.IssuerKind, Group: "cert-manager.io"}
+	certificate := func(secretName string, dnsNames ...string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: cmapi.SchemeGroupVersion.String(),
+				Kind:       cmapi.CertificateKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "my-namespace"},
+			Spec: cmapi.CertificateSpec{
+				SecretName: secretName,
+				DNSNames:   dnsNames,
+				IssuerRef:  issuerRef,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		tls []networkingv1.IngressTLS
+
+		expCertificates []*cmapi.Certificate
+		expErr          bool
+	}{
+		"one Certificate is generated for every secretName": {
+			tls: []networkingv1.IngressTLS{
+				{Hosts: []string{"example.com", "www.example.com"}, SecretName: "example-tls"},
+				{Hosts: []string{"foo.example.com"}, SecretName: "foo-tls"},
+			},
+			expCertificates: []*cmapi.Certificate{
+				certificate("example-tls", "example.com", "www.example.com"),
+				certificate("foo-tls", "foo.example.com"),
+			},
+		},
+		"hosts of entries sharing a secretName are merged": {
+			tls: []networkingv1.IngressTLS{
+				{Hosts: []string{"example.com", "www.example.com"}, SecretName: "example-tls"},
+				{Hosts: []string{"www.example.com", "foo.example.com"}, SecretName: "example-tls"},
+			},
+			expCertificates: []*cmapi.Certificate{
+				certificate("example-tls", "example.com", "www.example.com", "foo.example.com"),
+			},
+		},
+		"Ingress without TLS entries throws error": {
+			expErr: true,
+		},
+		"TLS entry without secretName throws error": {
+			tls:    []networkingv1.IngressTLS{{Hosts: []string{"example.com"}}},
+			expErr: true,
+		},
+		"TLS entry without hosts throws error": {
+			tls:    []networkingv1.IngressTLS{{SecretName: "example-tls"}},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ing := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-ingress", Namespace: "my-namespace"},
+				Spec:       networkingv1.IngressSpec{TLS: test.tls},
+			}
+
+			crts, err := buildCertificates(ing, issuerRef)
+			if err != nil {
+				if !test.expErr {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+				return
+			}
+			if test.expErr {
+				t.Fatalf("expected but got no error")
+			}
+			if !reflect.DeepEqual(crts, test.expCertificates) {
+				t.Errorf("unexpected Certificates, expected: %+v; actual: %+v", test.expCertificates, crts)
+			}
+		})
+	}
+}
+
+func TestValidateCertificates(t *testing.T) {
+	crt := func(name string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: cmapi.SchemeGroupVersion.String(), Kind: cmapi.CertificateKind},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       cmapi.CertificateSpec{SecretName: name, DNSNames: []string{"example.com"}},
+		}
+	}
+
+	// The fake clientset doesn't support dry-runs, so the reactor handles
+	// every create to stand in for the API server
+	cmClient := cmfake.NewSimpleClientset()
+	cmClient.PrependReactor("create", "certificates", func(action coretesting.Action) (bool, runtime.Object, error) {
+		obj := action.(coretesting.CreateAction).GetObject().(*cmapi.Certificate)
+		if obj.Name == "invalid" {
+			return true, nil, apierrors.NewInvalid(cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateKind).GroupKind(), obj.Name,
+				field.ErrorList{field.Required(field.NewPath("spec", "issuerRef", "name"), "must be specified")})
+		}
+		return true, obj, nil
+	})
+
+	var out, errOut bytes.Buffer
+	opts := &Options{
+		PrintFlags: genericclioptions.NewPrintFlags("validated"),
+		IOStreams:  genericclioptions.IOStreams{Out: &out, ErrOut: &errOut},
+		Factory:    &factory.Factory{CMClient: cmClient},
+	}
+	var err error
+	if opts.Printer, err = opts.PrintFlags.ToPrinter(); err != nil {
+		t.Fatal(err)
+	}
+
+	err = opts.validateCertificates(context.TODO(), []*cmapi.Certificate{crt("valid"), crt("invalid")})
+	if err == nil || err.Error() != "1 of 2 Certificates failed validation" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expOut := "certificate.cert-manager.io/valid validated\n"; out.String() != expOut {
+		t.Errorf("unexpected output; expected: %q, actual: %q", expOut, out.String())
+	}
+	expErrOut := `error: Certificate default/invalid is invalid: Certificate.cert-manager.io "invalid" is invalid: spec.issuerRef.name: Required value: must be specified` + "\n"
+	if errOut.String() != expErrOut {
+		t.Errorf("unexpected error output; expected: %q, actual: %q", expErrOut, errOut.String())
+	}
+}
@@ -22,13 +22,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -36,7 +37,9 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/privatekey"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/wait"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -46,26 +49,50 @@ import (
 
 var (
 	long = templates.LongDesc(i18n.T(`
-Create a new CertificateRequest resource based on a Certificate resource, by generating a private key locally and create a 'certificate signing request' to be submitted to a cert-manager Issuer.`))
+Create a new CertificateRequest resource based on a Certificate resource, by generating a private key locally and create a 'certificate signing request' to be submitted to a cert-manager Issuer.
+
+The CSR is built from the spec of the Certificate the same way the controller does, e.g. using its commonName,
+dnsNames, ipAddresses and usages. This allows an Issuer to be exercised without creating the Certificate itself.
+
+With --fetch-certificate, the command waits until the CertificateRequest has been signed and writes the certificate
+to --output-certificate-file. Waiting stops early with an error if the CertificateRequest is denied or fails.`))
 
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Create a CertificateRequest with the name 'my-cr', saving the private key in a file named 'my-cr.key'.
 {{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml
 
+# Create a CertificateRequest from the Certificate in file 'my-certificate.yaml', using the short form of --from-certificate-file.
+{{.BuildName}} create certificaterequest my-cr -f my-certificate.yaml
+
 # Create a CertificateRequest in namespace default, provided no conflict with namespace defined in file.
 {{.BuildName}} create certificaterequest my-cr --namespace default --from-certificate-file my-certificate.yaml
 
 # Create a CertificateRequest and store private key in file 'new.key'.
 {{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --output-key-file new.key
 
+# Create a CertificateRequest and store the private key in file 'new.key', encrypted with the passphrase in file 'passphrase.txt'.
+# The Certificate has to use the PKCS8 private key encoding.
+{{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --output-key-file new.key --key-passphrase-file passphrase.txt
+
 # Create a CertificateRequest, wait for it to be signed for up to 5 minutes (default) and store the x509 certificate in file 'new.crt'.
-{{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --output-cert-file new.crt
+{{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --output-certificate-file new.crt
 
 # Create a CertificateRequest, wait for it to be signed for up to 20 minutes and store the x509 certificate in file 'my-cr.crt'.
 {{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --fetch-certificate --timeout 20m
+
+# Create a CertificateRequest, wait for it to be signed and store the private key, CSR, x509 certificate and CA in directory 'my-cr'.
+{{.BuildName}} create certificaterequest my-cr --from-certificate-file my-certificate.yaml --dump-all my-cr
 `)))
 )
 
+// Names of the files written to the --dump-all directory
+const (
+	dumpKeyFile  = "tls.key"
+	dumpCSRFile  = "request.csr"
+	dumpCertFile = "tls.crt"
+	dumpCAFile   = "ca.crt"
+)
+
 var (
 	// Dedicated scheme used by the ctl tool that has the internal cert-manager types,
 	// and their conversion functions registered
@@ -90,6 +117,12 @@ type Options struct {
 	// Length of time the command blocks to wait on CertificateRequest to be ready if --fetch-certificate flag is set
 	// If not specified, default value is 5 minutes
 	Timeout time.Duration
+	// Directory the private key, CSR, x509 certificate and CA are written to
+	// If set, the command waits for the CertificateRequest to be signed as if --fetch-certificate was set
+	DumpDir string
+
+	// Options to encrypt the private key written to disk with a passphrase
+	KeyEncryption privatekey.EncryptionOptions
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -118,7 +151,7 @@ func NewCmdCreateCR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 			cmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
-	cmd.Flags().StringVar(&o.InputFilename, "from-certificate-file", o.InputFilename,
+	cmd.Flags().StringVarP(&o.InputFilename, "from-certificate-file", "f", o.InputFilename,
 		"Path to a file containing a Certificate resource used as a template when generating the CertificateRequest resource")
 	cmd.Flags().StringVar(&o.KeyFilename, "output-key-file", o.KeyFilename,
 		"Name of file that the generated private key will be written to")
@@ -128,6 +161,10 @@ func NewCmdCreateCR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 		"If set to true, command will wait for CertificateRequest to be signed to store x509 certificate in a file")
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
 		"Time before timeout when waiting for CertificateRequest to be signed, must include unit, e.g. 10m or 1h")
+	cmd.Flags().StringVar(&o.DumpDir, "dump-all", o.DumpDir,
+		"Directory to write the private key, CSR, x509 certificate and CA to, as "+dumpKeyFile+", "+dumpCSRFile+", "+dumpCertFile+" and "+dumpCAFile+". Waits for the CertificateRequest to be signed as if --fetch-certificate was set")
+
+	o.KeyEncryption.AddFlags(cmd)
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -155,6 +192,14 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate flag")
 	}
 
+	if o.DumpDir != "" && (o.KeyFilename != "" || o.CertFileName != "") {
+		return errors.New("the --dump-all flag cannot be used in conjunction with the --output-key-file or --output-certificate-file flags")
+	}
+
+	if err := o.KeyEncryption.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -216,12 +261,23 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 
 	crName := args[0]
 
+	keyFileData, err := o.KeyEncryption.EncodePrivateKey(signer, crt.Spec.PrivateKey.Encoding, keyData)
+	if err != nil {
+		return err
+	}
+
 	// Storing private key to file
 	keyFileName := crName + ".key"
 	if o.KeyFilename != "" {
 		keyFileName = o.KeyFilename
 	}
-	if err := os.WriteFile(keyFileName, keyData, 0600); err != nil {
+	if o.DumpDir != "" {
+		if err := os.MkdirAll(o.DumpDir, 0755); err != nil {
+			return fmt.Errorf("error when creating directory %s: %w", o.DumpDir, err)
+		}
+		keyFileName = filepath.Join(o.DumpDir, dumpKeyFile)
+	}
+	if err := os.WriteFile(keyFileName, keyFileData, 0600); err != nil {
 		return fmt.Errorf("error when writing private key to file: %w", err)
 	}
 	fmt.Fprintf(o.ErrOut, "Private key written to file %s\n", keyFileName)
@@ -232,6 +288,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when building CertificateRequest: %w", err)
 	}
 
+	if o.DumpDir != "" {
+		if err := o.dumpFile(dumpCSRFile, "CSR", req.Spec.Request); err != nil {
+			return err
+		}
+	}
+
 	ns := crt.Namespace
 	if ns == "" {
 		ns = o.Namespace
@@ -242,24 +304,31 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	}
 	fmt.Fprintf(o.ErrOut, "CertificateRequest %s has been created in namespace %s\n", req.Name, req.Namespace)
 
-	if o.FetchCert {
+	if o.FetchCert || o.DumpDir != "" {
 		fmt.Fprintf(o.ErrOut, "CertificateRequest %v in namespace %v has not been signed yet. Wait until it is signed...\n",
 			req.Name, req.Namespace)
-		err = wait.PollUntilContextTimeout(ctx, time.Second, o.Timeout, false, func(ctx context.Context) (done bool, err error) {
-			req, err = o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
-			if err != nil {
-				return false, nil
-			}
-			return apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
-				Type:   cmapi.CertificateRequestConditionReady,
-				Status: cmmeta.ConditionTrue,
-			}) && len(req.Status.Certificate) > 0, nil
-		})
+		crs := o.CMClient.CertmanagerV1().CertificateRequests(req.Namespace)
+		obj, err := wait.For(ctx, o.Timeout, wait.Object{
+			Name:        req.Name,
+			Description: fmt.Sprintf("CertificateRequest %s/%s to be signed", req.Namespace, req.Name),
+			Get: func(ctx context.Context) (runtime.Object, error) {
+				return crs.Get(ctx, req.Name, metav1.GetOptions{})
+			},
+			Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+				return crs.Watch(ctx, opts)
+			},
+			Describe: describeReady,
+		}, isSigned)
 		if err != nil {
 			return fmt.Errorf("error when waiting for CertificateRequest to be signed: %w", err)
 		}
+		req = obj.(*cmapi.CertificateRequest)
 		fmt.Fprintf(o.ErrOut, "CertificateRequest %v in namespace %v has been signed\n", req.Name, req.Namespace)
 
+		if o.DumpDir != "" {
+			return o.dumpSigned(req)
+		}
+
 		// Fetch x509 certificate and store to file
 		actualCertFileName := req.Name + ".crt"
 		if o.CertFileName != "" {
@@ -275,6 +344,34 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	return nil
 }
 
+// isSigned returns true once the CertificateRequest is Ready and has a
+// certificate, or an error if it has been denied or has failed, as it will
+// never be signed then.
+func isSigned(obj runtime.Object) (bool, error) {
+	cr := obj.(*cmapi.CertificateRequest)
+	if apiutil.CertificateRequestIsDenied(cr) {
+		cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied)
+		return false, fmt.Errorf("CertificateRequest %s/%s has been denied: Reason: %s, Message: %s", cr.Namespace, cr.Name, cond.Reason, cond.Message)
+	}
+	if apiutil.CertificateRequestReadyReason(cr) == cmapi.CertificateRequestReasonFailed {
+		return false, fmt.Errorf("CertificateRequest %s/%s has failed: %s", cr.Namespace, cr.Name, describeReady(cr))
+	}
+	return apiutil.CertificateRequestHasCondition(cr, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionReady,
+		Status: cmmeta.ConditionTrue,
+	}) && len(cr.Status.Certificate) > 0, nil
+}
+
+// describeReady describes the Ready condition of the CertificateRequest,
+// which explains why it has not been signed yet.
+func describeReady(obj runtime.Object) string {
+	cond := apiutil.GetCertificateRequestCondition(obj.(*cmapi.CertificateRequest), cmapi.CertificateRequestConditionReady)
+	if cond == nil {
+		return "the Ready condition is not set"
+	}
+	return fmt.Sprintf("Ready: %s, Reason: %s, Message: %s", cond.Status, cond.Reason, cond.Message)
+}
+
 // Builds a CertificateRequest
 func buildCertificateRequest(crt *cmapi.Certificate, pk []byte, crName string) (*cmapi.CertificateRequest, error) {
 	csrPEM, err := generateCSR(crt, pk)
@@ -343,3 +440,31 @@ func fetchCertificateFromCR(req *cmapi.CertificateRequest, certFileName string)
 
 	return nil
 }
+
+// dumpSigned writes the x509 certificate and CA of the signed CertificateRequest
+// req to the --dump-all directory. If the issuer did not provide a CA, no CA
+// file is written.
+func (o *Options) dumpSigned(req *cmapi.CertificateRequest) error {
+	if len(req.Status.Certificate) == 0 {
+		return errors.New("CertificateRequest is not ready yet, unable to fetch certificate")
+	}
+	if err := o.dumpFile(dumpCertFile, "Certificate", req.Status.Certificate); err != nil {
+		return err
+	}
+
+	if len(req.Status.CA) == 0 {
+		fmt.Fprintf(o.ErrOut, "CertificateRequest %v in namespace %v does not contain a CA, %s not written\n", req.Name, req.Namespace, dumpCAFile)
+		return nil
+	}
+	return o.dumpFile(dumpCAFile, "CA", req.Status.CA)
+}
+
+// dumpFile writes data to the file name in the --dump-all directory.
+func (o *Options) dumpFile(name, description string, data []byte) error {
+	fileName := filepath.Join(o.DumpDir, name)
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		return fmt.Errorf("error when writing %s to file: %w", description, err)
+	}
+	fmt.Fprintf(o.ErrOut, "%s written to file %s\n", description, fileName)
+	return nil
+}
@@ -19,9 +19,15 @@ package certificaterequest
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func TestValidate(t *testing.T) {
@@ -31,6 +37,7 @@ func TestValidate(t *testing.T) {
 		keyFilename  string
 		certFilename string
 		fetchCert    bool
+		dumpDir      string
 
 		expErr    bool
 		expErrMsg string
@@ -76,6 +83,20 @@ func TestValidate(t *testing.T) {
 			expErr:       true,
 			expErrMsg:    "cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate flag",
 		},
+		"dump directory without fetch-certificate flag is valid": {
+			inputFile: "example.yaml",
+			inputArgs: []string{"hello"},
+			dumpDir:   "hello",
+			expErr:    false,
+		},
+		"dump directory in conjunction with key filename throws error": {
+			inputFile:   "example.yaml",
+			inputArgs:   []string{"hello"},
+			keyFilename: "hello.key",
+			dumpDir:     "hello",
+			expErr:      true,
+			expErrMsg:   "the --dump-all flag cannot be used in conjunction with the --output-key-file or --output-certificate-file flags",
+		},
 	}
 
 	for name, test := range tests {
@@ -85,6 +106,7 @@ func TestValidate(t *testing.T) {
 				KeyFilename:   test.keyFilename,
 				CertFileName:  test.certFilename,
 				FetchCert:     test.fetchCert,
+				DumpDir:       test.dumpDir,
 			}
 
 			// Validating args and flags
@@ -244,3 +266,113 @@ spec:
 		})
 	}
 }
+
+func TestDumpSigned(t *testing.T) {
+	tests := map[string]struct {
+		status cmapi.CertificateRequestStatus
+
+		expFiles  map[string]string
+		expErrMsg string
+	}{
+		"certificate and CA are written": {
+			status:   cmapi.CertificateRequestStatus{Certificate: []byte("cert"), CA: []byte("ca")},
+			expFiles: map[string]string{"tls.crt": "cert", "ca.crt": "ca"},
+		},
+		"CA is not written if not provided": {
+			status:   cmapi.CertificateRequestStatus{Certificate: []byte("cert")},
+			expFiles: map[string]string{"tls.crt": "cert"},
+		},
+		"missing certificate throws error": {
+			status:    cmapi.CertificateRequestStatus{CA: []byte("ca")},
+			expFiles:  map[string]string{},
+			expErrMsg: "CertificateRequest is not ready yet, unable to fetch certificate",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			opts := &Options{DumpDir: dir, IOStreams: streams}
+
+			err := opts.dumpSigned(&cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "testcr", Namespace: "testns"},
+				Status:     test.status,
+			})
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("got unexpected error when dumping CR, expected: %v; actual: %v", test.expErrMsg, err)
+				}
+			} else if err != nil {
+				t.Fatalf("got unexpected error when dumping CR: %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(test.expFiles) {
+				t.Errorf("expected %d files to be written, got %d", len(test.expFiles), len(entries))
+			}
+			for name, expData := range test.expFiles {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatalf("expected file %s to be written: %v", name, err)
+				}
+				if string(data) != expData {
+					t.Errorf("unexpected content of %s, expected: %q; actual: %q", name, expData, data)
+				}
+			}
+		})
+	}
+}
+
+func TestIsSigned(t *testing.T) {
+	cr := func(cert string, conditions ...cmapi.CertificateRequestCondition) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cr", Namespace: "my-namespace"},
+			Status:     cmapi.CertificateRequestStatus{Certificate: []byte(cert), Conditions: conditions},
+		}
+	}
+
+	tests := map[string]struct {
+		cr        *cmapi.CertificateRequest
+		expSigned bool
+		expErrMsg string
+	}{
+		"no conditions set": {
+			cr: cr(""),
+		},
+		"pending": {
+			cr: cr("", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonPending}),
+		},
+		"ready with certificate": {
+			cr:        cr("cert", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: cmapi.CertificateRequestReasonIssued}),
+			expSigned: true,
+		},
+		"denied": {
+			cr:        cr("", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "Foo", Message: "not allowed"}),
+			expErrMsg: "CertificateRequest my-namespace/my-cr has been denied: Reason: Foo, Message: not allowed",
+		},
+		"failed": {
+			cr:        cr("", cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: cmapi.CertificateRequestReasonFailed, Message: "issuer error"}),
+			expErrMsg: "CertificateRequest my-namespace/my-cr has failed: Ready: False, Reason: Failed, Message: issuer error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signed, err := isSigned(test.cr)
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Errorf("expected error %q, got %v", test.expErrMsg, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if signed != test.expSigned {
+				t.Errorf("expected signed to be %t, got %t", test.expSigned, signed)
+			}
+		})
+	}
+}
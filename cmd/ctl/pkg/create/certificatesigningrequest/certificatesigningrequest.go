@@ -31,7 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
@@ -40,7 +40,9 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/privatekey"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/wait"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -62,6 +64,10 @@ Create a new CertificateSigningRequest resource based on a Certificate resource,
 # Create a CertificateSigningRequest and store private key in file 'new.key'.
 {{.BuildName}} x create certificatesigningrequest my-csr --from-certificate-file my-certificate.yaml --output-key-file new.key
 
+# Create a CertificateSigningRequest and store the private key in file 'new.key', encrypted with the passphrase in file 'passphrase.txt'.
+# The Certificate has to use the PKCS8 private key encoding.
+{{.BuildName}} x create certificatesigningrequest my-csr --from-certificate-file my-certificate.yaml --output-key-file new.key --key-passphrase-file passphrase.txt
+
 # Create a CertificateSigningRequest, wait for it to be signed for up to 5 minutes (default) and store the x509 certificate in file 'new.crt'.
 {{.BuildName}} x create csr my-cr -f my-certificate.yaml -c new.crt -w
 
@@ -103,6 +109,9 @@ type Options struct {
 	// value is 5 minutes.
 	Timeout time.Duration
 
+	// Options to encrypt the private key written to disk with a passphrase
+	KeyEncryption privatekey.EncryptionOptions
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -141,6 +150,8 @@ func NewCmdCreateCSR(ctx context.Context, ioStreams genericclioptions.IOStreams)
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
 		"Time before timeout when waiting for CertificateSigningRequest to be signed, must include unit, e.g. 10m or 1h")
 
+	o.KeyEncryption.AddFlags(cmd)
+
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -167,6 +178,10 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("cannot specify file to store certificate if not waiting for and fetching certificate, please set --fetch-certificate or -w flag")
 	}
 
+	if err := o.KeyEncryption.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -233,17 +248,26 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 
 	csrName := args[0]
 
+	keyFileData, err := o.KeyEncryption.EncodePrivateKey(signer, crt.Spec.PrivateKey.Encoding, keyPEM)
+	if err != nil {
+		return err
+	}
+
 	// Storing private key to file
 	keyFileName := csrName + ".key"
 	if o.KeyFilename != "" {
 		keyFileName = o.KeyFilename
 	}
-	if err := os.WriteFile(keyFileName, keyPEM, 0600); err != nil {
+	if err := os.WriteFile(keyFileName, keyFileData, 0600); err != nil {
 		return fmt.Errorf("error when writing private key to file: %s", err)
 	}
 	fmt.Fprintf(o.Out, "Private key written to file %s\n", keyFileName)
 
-	signerName, err := buildSignerName(o.KubeClient.Discovery(), crt)
+	discoveryClient, err := o.Discovery()
+	if err != nil {
+		return fmt.Errorf("failed to build signerName from Certificate: %s", err)
+	}
+	signerName, err := buildSignerName(discoveryClient, crt)
 	if err != nil {
 		return fmt.Errorf("failed to build signerName from Certificate: %s", err)
 	}
@@ -263,16 +287,23 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	if o.FetchCert {
 		fmt.Fprintf(o.Out, "CertificateSigningRequest %s has not been signed yet. Wait until it is signed...\n", req.Name)
 
-		err = wait.PollUntilContextTimeout(ctx, time.Second, o.Timeout, false, func(ctx context.Context) (done bool, err error) {
-			req, err = o.KubeClient.CertificatesV1().CertificateSigningRequests().Get(ctx, req.Name, metav1.GetOptions{})
-			if err != nil {
-				return false, err
-			}
-			return len(req.Status.Certificate) > 0, nil
+		csrs := o.KubeClient.CertificatesV1().CertificateSigningRequests()
+		obj, err := wait.For(ctx, o.Timeout, wait.Object{
+			Name:        req.Name,
+			Description: fmt.Sprintf("CertificateSigningRequest %s to be signed", req.Name),
+			Get: func(ctx context.Context) (runtime.Object, error) {
+				return csrs.Get(ctx, req.Name, metav1.GetOptions{})
+			},
+			Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+				return csrs.Watch(ctx, opts)
+			},
+		}, func(obj runtime.Object) (bool, error) {
+			return len(obj.(*certificatesv1.CertificateSigningRequest).Status.Certificate) > 0, nil
 		})
 		if err != nil {
 			return fmt.Errorf("error when waiting for CertificateSigningRequest to be signed: %s", err)
 		}
+		req = obj.(*certificatesv1.CertificateSigningRequest)
 
 		fmt.Fprintf(o.Out, "CertificateSigningRequest %s has been signed\n", req.Name)
 
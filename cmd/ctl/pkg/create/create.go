@@ -22,11 +22,13 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificate"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificaterequest"
 )
 
 func NewCmdCreate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	cmds := NewCmdCreateBare()
+	cmds.AddCommand(certificate.NewCmdCreateCertificate(ctx, ioStreams))
 	cmds.AddCommand(certificaterequest.NewCmdCreateCR(ctx, ioStreams))
 
 	return cmds
@@ -37,6 +39,6 @@ func NewCmdCreateBare() *cobra.Command {
 	return &cobra.Command{
 		Use:   "create",
 		Short: "Create cert-manager resources",
-		Long:  `Create cert-manager resources e.g. a CertificateRequest`,
+		Long:  `Create cert-manager resources e.g. a Certificate or a CertificateRequest`,
 	}
 }
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatekey
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/pbkdf2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// The generated private keys are encrypted using PBES2 as defined in RFC 8018,
+// with the key derived by PBKDF2-HMAC-SHA256 and encrypted using AES-256-CBC.
+// This is the scheme used by 'openssl pkcs8 -topk8 -v2 aes-256-cbc', so the
+// keys can be decrypted by OpenSSL and most other tools.
+const pbkdf2Iterations = 100000
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier
+}
+
+// EncryptionOptions are the options to encrypt the private keys generated by
+// the create commands with a passphrase.
+type EncryptionOptions struct {
+	// Passphrase used to encrypt the private key
+	Passphrase string
+	// Path to a file containing the passphrase used to encrypt the private key
+	PassphraseFile string
+
+	flags *pflag.FlagSet
+}
+
+// AddFlags adds the --key-passphrase and --key-passphrase-file flags to cmd.
+func (o *EncryptionOptions) AddFlags(cmd *cobra.Command) {
+	o.flags = cmd.Flags()
+	cmd.Flags().StringVar(&o.Passphrase, "key-passphrase", o.Passphrase,
+		"Passphrase used to encrypt the generated private key, which is written as an encrypted PKCS#8 key (PBES2 with PBKDF2-HMAC-SHA256 and AES-256-CBC). The Certificate must use the PKCS8 private key encoding. Prefer --key-passphrase-file, as command line arguments may be visible to other users")
+	cmd.Flags().StringVar(&o.PassphraseFile, "key-passphrase-file", o.PassphraseFile,
+		"Path to a file containing the passphrase used to encrypt the generated private key, which requires the PKCS8 private key encoding. A trailing newline is ignored")
+}
+
+// Validate validates that at most one source of the passphrase is given, and
+// that a passphrase given on the command line is not empty.
+func (o *EncryptionOptions) Validate() error {
+	passphraseSet := len(o.Passphrase) > 0 || (o.flags != nil && o.flags.Changed("key-passphrase"))
+	if passphraseSet && len(o.PassphraseFile) > 0 {
+		return errors.New("the --key-passphrase and --key-passphrase-file flags cannot be used in conjunction")
+	}
+	if passphraseSet && len(o.Passphrase) == 0 {
+		return errors.New("the passphrase given by --key-passphrase cannot be empty")
+	}
+	return nil
+}
+
+// Enabled returns true if the private key should be encrypted.
+func (o *EncryptionOptions) Enabled() bool {
+	return len(o.Passphrase) > 0 || len(o.PassphraseFile) > 0
+}
+
+// EncodePrivateKey returns the PEM encoded private key to be written to disk.
+// If encryption is enabled, signer is encrypted with the passphrase,
+// otherwise keyPEM is returned as is.
+func (o *EncryptionOptions) EncodePrivateKey(signer crypto.Signer, encoding cmapi.PrivateKeyEncoding, keyPEM []byte) ([]byte, error) {
+	if !o.Enabled() {
+		return keyPEM, nil
+	}
+	// An empty encoding means PKCS1, so only keys explicitly encoded as
+	// PKCS8 are encrypted.
+	if encoding != cmapi.PKCS8 {
+		return nil, errors.New("only private keys with the PKCS8 encoding can be encrypted, please set spec.privateKey.encoding to PKCS8 in the Certificate")
+	}
+
+	passphrase, err := o.passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := EncryptPKCS8PrivateKey(signer, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error when encrypting private key: %w", err)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func (o *EncryptionOptions) passphrase() ([]byte, error) {
+	if len(o.PassphraseFile) == 0 {
+		return []byte(o.Passphrase), nil
+	}
+
+	data, err := os.ReadFile(o.PassphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading the passphrase file: %w", err)
+	}
+	data = bytes.TrimSuffix(bytes.TrimSuffix(data, []byte("\n")), []byte("\r"))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("the passphrase file %q is empty", o.PassphraseFile)
+	}
+	return data, nil
+}
+
+// EncryptPKCS8PrivateKey returns an "ENCRYPTED PRIVATE KEY" PEM block of key
+// encrypted with passphrase.
+func EncryptPKCS8PrivateKey(key crypto.PrivateKey, passphrase []byte) (*pem.Block, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(passphrase, salt, pbkdf2Iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	// PKCS#7 padding, which always adds at least one byte
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	encrypted := append(der, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(encrypted, encrypted)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData:       encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: data}, nil
+}
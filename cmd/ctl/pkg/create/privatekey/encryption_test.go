@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatekey
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/pbkdf2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		setFlags  map[string]string
+		expErrMsg string
+	}{
+		"no passphrase is valid": {},
+		"passphrase is valid": {
+			setFlags: map[string]string{"key-passphrase": "secret"},
+		},
+		"passphrase file is valid": {
+			setFlags: map[string]string{"key-passphrase-file": "passphrase.txt"},
+		},
+		"empty passphrase throws error": {
+			setFlags:  map[string]string{"key-passphrase": ""},
+			expErrMsg: "the passphrase given by --key-passphrase cannot be empty",
+		},
+		"passphrase in conjunction with passphrase file throws error": {
+			setFlags:  map[string]string{"key-passphrase": "secret", "key-passphrase-file": "passphrase.txt"},
+			expErrMsg: "the --key-passphrase and --key-passphrase-file flags cannot be used in conjunction",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := &EncryptionOptions{}
+			cmd := &cobra.Command{}
+			o.AddFlags(cmd)
+			for flag, value := range test.setFlags {
+				if err := cmd.Flags().Set(flag, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := o.Validate()
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("expected error %q, got: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestEncodePrivateKey(t *testing.T) {
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := []byte("plaintext key")
+
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase.txt")
+	if err := os.WriteFile(passphraseFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyPassphraseFile := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(emptyPassphraseFile, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		opts          EncryptionOptions
+		encoding      cmapi.PrivateKeyEncoding
+		expPassphrase string
+		expErr        bool
+	}{
+		"without passphrase the key is returned as is": {},
+		"key is encrypted with the passphrase": {
+			opts:          EncryptionOptions{Passphrase: "secret"},
+			encoding:      cmapi.PKCS8,
+			expPassphrase: "secret",
+		},
+		"key is encrypted with the passphrase from the file without trailing newline": {
+			opts:          EncryptionOptions{PassphraseFile: passphraseFile},
+			encoding:      cmapi.PKCS8,
+			expPassphrase: "from-file",
+		},
+		"empty passphrase file throws error": {
+			opts:     EncryptionOptions{PassphraseFile: emptyPassphraseFile},
+			encoding: cmapi.PKCS8,
+			expErr:   true,
+		},
+		"PKCS1 encoding throws error": {
+			opts:     EncryptionOptions{Passphrase: "secret"},
+			encoding: cmapi.PKCS1,
+			expErr:   true,
+		},
+		"empty encoding, which means PKCS1, throws error": {
+			opts:   EncryptionOptions{Passphrase: "secret"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := test.opts.EncodePrivateKey(signer, test.encoding, keyPEM)
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t got=%v", test.expErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if test.expPassphrase == "" {
+				if string(data) != string(keyPEM) {
+					t.Errorf("expected the key to be returned as is, got: %s", data)
+				}
+				return
+			}
+
+			block, _ := pem.Decode(data)
+			if block == nil || block.Type != "ENCRYPTED PRIVATE KEY" {
+				t.Fatalf("expected an encrypted private key PEM block, got: %s", data)
+			}
+			key, err := decryptPKCS8PrivateKey(block.Bytes, []byte(test.expPassphrase))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !signer.Equal(key) {
+				t.Errorf("decrypted private key doesn't match the encrypted one")
+			}
+		})
+	}
+}
+
+// decryptPKCS8PrivateKey decrypts a key encrypted by EncryptPKCS8PrivateKey.
+func decryptPKCS8PrivateKey(der, passphrase []byte) (interface{}, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, err
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key(passphrase, kdfParams.Salt, kdfParams.IterationCount, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+	decrypted = decrypted[:len(decrypted)-int(decrypted[len(decrypted)-1])]
+
+	return x509.ParsePKCS8PrivateKey(decrypted)
+}
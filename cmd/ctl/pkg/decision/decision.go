@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decision implements checking whether a CertificateRequest has
+// already been approved or denied, shared by the approve and deny commands.
+package decision
+
+import (
+	"fmt"
+	"strings"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// AlreadyDecidedError is returned by CheckUndecided for a CertificateRequest
+// which is already Approved or Denied.
+type AlreadyDecidedError struct {
+	CertificateRequest *cmapi.CertificateRequest
+	Condition          *cmapi.CertificateRequestCondition
+}
+
+func (e *AlreadyDecidedError) Error() string {
+	return fmt.Sprintf("CertificateRequest '%s/%s' is already %s: Reason: %s, Message: %s",
+		e.CertificateRequest.Namespace, e.CertificateRequest.Name, strings.ToLower(string(e.Condition.Type)),
+		e.Condition.Reason, e.Condition.Message)
+}
+
+// CheckUndecided returns an *AlreadyDecidedError reporting the existing
+// condition if cr is already Approved or Denied. Approval and denial are
+// final, so neither can be set once either of them is.
+func CheckUndecided(cr *cmapi.CertificateRequest) error {
+	for _, conditionType := range []cmapi.CertificateRequestConditionType{cmapi.CertificateRequestConditionApproved, cmapi.CertificateRequestConditionDenied} {
+		if cond := apiutil.GetCertificateRequestCondition(cr, conditionType); cond != nil && cond.Status == cmmeta.ConditionTrue {
+			return &AlreadyDecidedError{CertificateRequest: cr, Condition: cond}
+		}
+	}
+	return nil
+}
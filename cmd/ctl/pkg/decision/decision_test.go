@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decision
+
+import (
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCheckUndecided(t *testing.T) {
+	tests := map[string]struct {
+		conditions []cmapi.CertificateRequestCondition
+
+		expErrMsg string
+	}{
+		"CertificateRequest without conditions is undecided": {},
+		"CertificateRequest which is not Approved is undecided": {
+			conditions: []cmapi.CertificateRequestCondition{
+				{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionFalse},
+				{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue},
+			},
+		},
+		"Approved CertificateRequest reports the Approved condition": {
+			conditions: []cmapi.CertificateRequestCondition{
+				{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "approved by policy"},
+			},
+			expErrMsg: "CertificateRequest 'default/cr-1' is already approved: Reason: policy, Message: approved by policy",
+		},
+		"Denied CertificateRequest reports the Denied condition": {
+			conditions: []cmapi.CertificateRequestCondition{
+				{Type: cmapi.CertificateRequestConditionDenied, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "denied by policy"},
+			},
+			expErrMsg: "CertificateRequest 'default/cr-1' is already denied: Reason: policy, Message: denied by policy",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default"))
+			cr.Status.Conditions = test.conditions
+
+			err := CheckUndecided(cr)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("got unexpected error, expected: %v; actual: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/prompt"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Delete a cert-manager Certificate resource, and optionally the Secret it stores the signed certificate in.
+
+The Secret of a Certificate is not garbage collected when the Certificate is deleted, unless cert-manager
+is configured to do so. With --with-secret, the Secret is deleted after the Certificate, so that it is not
+re-issued in between. Secrets which are neither owned by the Certificate nor annotated as belonging to it
+are only deleted with --force.
+
+The resources to be deleted are listed and confirmation is requested before deleting them, unless --yes
+is set.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Delete the Certificate 'my-crt' in namespace 'my-namespace', after confirmation
+{{.BuildName}} delete certificate my-crt --namespace my-namespace
+
+# Delete the Certificate 'my-crt' and its Secret without confirmation
+{{.BuildName}} delete certificate my-crt --with-secret --yes
+
+# Delete the Certificate 'my-crt' and orphan the CertificateRequests owned by it
+{{.BuildName}} delete certificate my-crt --cascade=orphan
+`)))
+)
+
+// Options is a struct to support delete certificate command
+type Options struct {
+	// If true, the Secret of the Certificate is deleted as well
+	WithSecret bool
+	// If true, the Secret is deleted even if it doesn't belong to the Certificate
+	Force bool
+	// If true, the resources are deleted without asking for confirmation
+	Yes bool
+	// Deletion propagation policy for the dependents of the deleted resources,
+	// one of "background", "foreground" or "orphan"
+	Cascade string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+		Cascade:   "background",
+	}
+}
+
+// NewCmdDeleteCertificate returns a cobra command for delete certificate
+func NewCmdDeleteCertificate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "certificate",
+		Aliases:           []string{"cert"},
+		Short:             "Delete a cert-manager Certificate resource, and optionally its Secret",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.WithSecret, "with-secret", o.WithSecret, "If true, delete the Secret of the Certificate as well.")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force, "If true, delete the Secret of the Certificate even if it is neither owned by the Certificate nor annotated as belonging to it. Must be used in conjunction with --with-secret.")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", o.Yes, "If true, do not ask for confirmation before deleting.")
+	cmd.Flags().StringVar(&o.Cascade, "cascade", o.Cascade, `Must be "background", "foreground", or "orphan". Selects the deletion cascading strategy for the dependents (e.g. CertificateRequests) of the deleted resources.`)
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+
+	if o.Force && !o.WithSecret {
+		return errors.New("the --force flag must be used in conjunction with --with-secret")
+	}
+
+	if _, err := o.propagationPolicy(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run executes delete certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %v", err)
+	}
+
+	var secret *corev1.Secret
+	if o.WithSecret {
+		secret, err = o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			fmt.Fprintf(o.ErrOut, "Secret %q of Certificate %s/%s does not exist, skipping it\n", crt.Spec.SecretName, crt.Namespace, crt.Name)
+			secret = nil
+		case err != nil:
+			return fmt.Errorf("error when getting Secret resource: %v", err)
+		case !secretBelongsToCertificate(secret, crt) && !o.Force:
+			return fmt.Errorf("refusing to delete Secret %s/%s as it is neither owned by the Certificate nor annotated as belonging to it, use --force to delete it anyway",
+				secret.Namespace, secret.Name)
+		}
+	}
+
+	fmt.Fprintln(o.Out, "The following resources will be deleted:")
+	fmt.Fprintf(o.Out, "  certificate.cert-manager.io/%s in namespace %s\n", crt.Name, crt.Namespace)
+	if secret != nil {
+		fmt.Fprintf(o.Out, "  secret/%s in namespace %s\n", secret.Name, secret.Namespace)
+	}
+
+	if !o.Yes && !prompt.Confirm(o.In, o.Out, "Do you want to continue?") {
+		fmt.Fprintln(o.Out, "Nothing was deleted.")
+		return nil
+	}
+
+	policy, err := o.propagationPolicy()
+	if err != nil {
+		return err
+	}
+	deleteOptions := metav1.DeleteOptions{PropagationPolicy: &policy}
+
+	// The Certificate is deleted first, so cert-manager doesn't re-issue
+	// the Secret after it has been deleted.
+	if err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Delete(ctx, crt.Name, deleteOptions); err != nil {
+		return fmt.Errorf("error when deleting Certificate resource: %v", err)
+	}
+	fmt.Fprintf(o.Out, "certificate.cert-manager.io/%s deleted\n", crt.Name)
+
+	if secret != nil {
+		if err := o.KubeClient.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, deleteOptions); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error when deleting Secret resource: %v", err)
+		}
+		fmt.Fprintf(o.Out, "secret/%s deleted\n", secret.Name)
+	}
+
+	return nil
+}
+
+// propagationPolicy returns the deletion propagation policy for the value of --cascade.
+func (o *Options) propagationPolicy() (metav1.DeletionPropagation, error) {
+	switch o.Cascade {
+	case "background":
+		return metav1.DeletePropagationBackground, nil
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	default:
+		return "", fmt.Errorf(`invalid --cascade value %q, must be "background", "foreground", or "orphan"`, o.Cascade)
+	}
+}
+
+// secretBelongsToCertificate returns true if secret is owned by crt, or
+// annotated by cert-manager as storing the certificate of crt.
+func secretBelongsToCertificate(secret *corev1.Secret, crt *cmapi.Certificate) bool {
+	for _, ref := range secret.OwnerReferences {
+		if ref.UID == crt.UID && ref.Kind == cmapi.CertificateKind {
+			return true
+		}
+	}
+	return secret.Annotations[cmapi.CertificateNameKey] == crt.Name
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		opts      *Options
+		args      []string
+		expErrMsg string
+	}{
+		"no name passed as arg throws error": {
+			opts:      NewOptions(genericclioptions.IOStreams{}),
+			expErrMsg: "the name of the Certificate has to be provided as argument",
+		},
+		"more than one arg throws error": {
+			opts:      NewOptions(genericclioptions.IOStreams{}),
+			args:      []string{"crt-1", "crt-2"},
+			expErrMsg: "only one argument can be passed in: the name of the Certificate",
+		},
+		"--force without --with-secret throws error": {
+			opts:      &Options{Force: true, Cascade: "background"},
+			args:      []string{"crt-1"},
+			expErrMsg: "the --force flag must be used in conjunction with --with-secret",
+		},
+		"invalid --cascade throws error": {
+			opts:      &Options{Cascade: "true"},
+			args:      []string{"crt-1"},
+			expErrMsg: `invalid --cascade value "true", must be "background", "foreground", or "orphan"`,
+		},
+		"single name is valid": {
+			opts: &Options{WithSecret: true, Force: true, Cascade: "orphan"},
+			args: []string{"crt-1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.opts.Validate(test.args)
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Errorf("expected error %q, got: %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	const ns = "test-ns"
+	crt := gen.Certificate("test-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateUID("test-uid"),
+	)
+	ownedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "test-secret", Namespace: ns,
+		OwnerReferences: []metav1.OwnerReference{{Kind: cmapi.CertificateKind, Name: crt.Name, UID: crt.UID}},
+	}}
+	annotatedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "test-secret", Namespace: ns,
+		Annotations: map[string]string{cmapi.CertificateNameKey: crt.Name},
+	}}
+	foreignSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: ns}}
+
+	tests := map[string]struct {
+		opts   Options
+		secret *corev1.Secret
+		input  string
+
+		expErrMsg        string
+		expOutput        string
+		expCrtDeleted    bool
+		expSecretDeleted bool
+	}{
+		"Certificate is deleted after confirmation, Secret is kept": {
+			secret: ownedSecret,
+			input:  "y\n",
+			expOutput: `The following resources will be deleted:
+  certificate.cert-manager.io/test-crt in namespace test-ns
+Do you want to continue? [y/N]: certificate.cert-manager.io/test-crt deleted
+`,
+			expCrtDeleted: true,
+		},
+		"nothing is deleted without confirmation": {
+			opts:   Options{WithSecret: true},
+			secret: ownedSecret,
+			input:  "\n",
+			expOutput: `The following resources will be deleted:
+  certificate.cert-manager.io/test-crt in namespace test-ns
+  secret/test-secret in namespace test-ns
+Do you want to continue? [y/N]: Nothing was deleted.
+`,
+		},
+		"owned Secret is deleted with --yes": {
+			opts:   Options{WithSecret: true, Yes: true},
+			secret: ownedSecret,
+			expOutput: `The following resources will be deleted:
+  certificate.cert-manager.io/test-crt in namespace test-ns
+  secret/test-secret in namespace test-ns
+certificate.cert-manager.io/test-crt deleted
+secret/test-secret deleted
+`,
+			expCrtDeleted:    true,
+			expSecretDeleted: true,
+		},
+		"annotated Secret is deleted": {
+			opts:             Options{WithSecret: true, Yes: true},
+			secret:           annotatedSecret,
+			expCrtDeleted:    true,
+			expSecretDeleted: true,
+		},
+		"Secret which doesn't belong to the Certificate is refused": {
+			opts:      Options{WithSecret: true, Yes: true},
+			secret:    foreignSecret,
+			expErrMsg: "refusing to delete Secret test-ns/test-secret as it is neither owned by the Certificate nor annotated as belonging to it, use --force to delete it anyway",
+		},
+		"Secret which doesn't belong to the Certificate is deleted with --force": {
+			opts:             Options{WithSecret: true, Yes: true, Force: true},
+			secret:           foreignSecret,
+			expCrtDeleted:    true,
+			expSecretDeleted: true,
+		},
+		"missing Secret is skipped": {
+			opts: Options{WithSecret: true, Yes: true},
+			expOutput: `The following resources will be deleted:
+  certificate.cert-manager.io/test-crt in namespace test-ns
+certificate.cert-manager.io/test-crt deleted
+`,
+			expCrtDeleted: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			kubeClient := kubefake.NewSimpleClientset()
+			if test.secret != nil {
+				kubeClient = kubefake.NewSimpleClientset(test.secret)
+			}
+			cmClient := cmfake.NewSimpleClientset(crt)
+
+			streams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.input)
+			opts := test.opts
+			opts.Cascade = "background"
+			opts.IOStreams = streams
+			opts.Factory = &factory.Factory{Namespace: ns, CMClient: cmClient, KubeClient: kubeClient}
+
+			err := opts.Run(ctx, []string{crt.Name})
+			if test.expErrMsg == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+			} else if err == nil || err.Error() != test.expErrMsg {
+				t.Fatalf("expected error %q, got: %v", test.expErrMsg, err)
+			}
+
+			if test.expOutput != "" && out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+
+			_, err = cmClient.CertmanagerV1().Certificates(ns).Get(ctx, crt.Name, metav1.GetOptions{})
+			if crtDeleted := apierrors.IsNotFound(err); crtDeleted != test.expCrtDeleted {
+				t.Errorf("expected Certificate deleted=%t, got=%t", test.expCrtDeleted, crtDeleted)
+			}
+			if test.secret != nil {
+				_, err = kubeClient.CoreV1().Secrets(ns).Get(ctx, test.secret.Name, metav1.GetOptions{})
+				if secretDeleted := apierrors.IsNotFound(err); secretDeleted != test.expSecretDeleted {
+					t.Errorf("expected Secret deleted=%t, got=%t", test.expSecretDeleted, secretDeleted)
+				}
+			}
+		})
+	}
+}
@@ -29,7 +29,9 @@ import (
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/decision"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/prompt"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -44,7 +46,10 @@ var (
 {{.BuildName}} deny my-cr --namespace default
 
 # Deny a CertificateRequest giving a custom reason and message
-{{.BuildName}} deny my-cr --reason "ManualDenial" --reason "Denied by PKI department"
+{{.BuildName}} deny my-cr --reason "ManualDenial" --message "Denied by PKI department"
+
+# Deny a CertificateRequest without asking for confirmation, e.g. in scripts
+{{.BuildName}} deny my-cr --force
 `)))
 )
 
@@ -56,6 +61,9 @@ type Options struct {
 	// Message is the string that will be set on the Message field of the
 	// Denied condition.
 	Message string
+	// If true, the CertificateRequest is denied without asking for
+	// confirmation
+	Force bool
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -72,9 +80,14 @@ func NewCmdDeny(ctx context.Context, ioStreams genericclioptions.IOStreams) *cob
 	o := NewOptions(ioStreams)
 
 	cmd := &cobra.Command{
-		Use:               "deny",
-		Short:             "Deny a CertificateRequest",
-		Long:              `Mark a CertificateRequest as Denied, so it may never be signed by a configured Issuer.`,
+		Use:   "deny",
+		Short: "Deny a CertificateRequest",
+		Long: templates.LongDesc(i18n.T(`
+Mark a CertificateRequest as Denied, so it may never be signed by a configured Issuer.
+
+Denial is final, so confirmation is requested before denying the CertificateRequest,
+unless --force is specified. CertificateRequests which are already Approved or Denied
+are not changed.`)),
 		Example:           example,
 		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -87,6 +100,8 @@ func NewCmdDeny(ctx context.Context, ioStreams genericclioptions.IOStreams) *cob
 		"The reason to give as to what denied this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually denied by %q", build.Name()),
 		"The message to give as to why this CertificateRequest was denied.")
+	cmd.Flags().BoolVar(&o.Force, "force", o.Force,
+		"If true, deny the CertificateRequest without asking for confirmation.")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -120,12 +135,14 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
-	if apiutil.CertificateRequestIsApproved(cr) {
-		return errors.New("CertificateRequest is already approved")
+	if err := decision.CheckUndecided(cr); err != nil {
+		return err
 	}
 
-	if apiutil.CertificateRequestIsDenied(cr) {
-		return errors.New("CertificateRequest is already denied")
+	question := fmt.Sprintf("Denying CertificateRequest '%s/%s' cannot be undone. Do you want to continue?", cr.Namespace, cr.Name)
+	if !o.Force && !prompt.Confirm(o.In, o.Out, question) {
+		fmt.Fprintf(o.Out, "CertificateRequest '%s/%s' was not denied.\n", cr.Namespace, cr.Name)
+		return nil
 	}
 
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied,
@@ -17,7 +17,20 @@ limitations under the License.
 package deny
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestValidate(t *testing.T) {
@@ -82,3 +95,91 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRun(t *testing.T) {
+	const prompt = "Denying CertificateRequest 'default/cr-1' cannot be undone. Do you want to continue? [y/N]: "
+
+	tests := map[string]struct {
+		crName    string
+		condition *cmapi.CertificateRequestCondition
+		force     bool
+		input     string
+
+		expDenied bool
+		expOutput string
+		expErrMsg string
+	}{
+		"confirmed denial denies the CertificateRequest": {
+			input:     "y\n",
+			expDenied: true,
+			expOutput: prompt + "Denied CertificateRequest 'default/cr-1'\n",
+		},
+		"declined denial leaves the CertificateRequest unchanged": {
+			input:     "n\n",
+			expOutput: prompt + "CertificateRequest 'default/cr-1' was not denied.\n",
+		},
+		"missing input leaves the CertificateRequest unchanged": {
+			expOutput: prompt + "\nCertificateRequest 'default/cr-1' was not denied.\n",
+		},
+		"--force denies without confirmation": {
+			force:     true,
+			expDenied: true,
+			expOutput: "Denied CertificateRequest 'default/cr-1'\n",
+		},
+		"approved CertificateRequest throws error": {
+			condition: &cmapi.CertificateRequestCondition{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "approved by policy"},
+			force:     true,
+			expErrMsg: "CertificateRequest 'default/cr-1' is already approved: Reason: policy, Message: approved by policy",
+		},
+		"missing CertificateRequest throws error": {
+			crName:    "cr-2",
+			force:     true,
+			expErrMsg: `certificaterequests.cert-manager.io "cr-2" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default"))
+			if test.condition != nil {
+				cr.Status.Conditions = []cmapi.CertificateRequestCondition{*test.condition}
+			}
+			cmClient := cmfake.NewSimpleClientset(cr)
+			crName := test.crName
+			if crName == "" {
+				crName = cr.Name
+			}
+
+			var out bytes.Buffer
+			opts := &Options{
+				Reason:    "KubectlCertManager",
+				Message:   "denied",
+				Force:     test.force,
+				IOStreams: genericclioptions.IOStreams{In: strings.NewReader(test.input), Out: &out, ErrOut: &out},
+				Factory:   &factory.Factory{CMClient: cmClient, Namespace: "default"},
+			}
+
+			err := opts.Run(context.TODO(), []string{crName})
+			switch {
+			case test.expErrMsg == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.expErrMsg != "":
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("expected error %q, got %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output; expected: %q, actual: %q", test.expOutput, out.String())
+			}
+
+			got, err := cmClient.CertmanagerV1().CertificateRequests("default").Get(context.TODO(), cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if denied := apiutil.CertificateRequestIsDenied(got); denied != test.expDenied {
+				t.Errorf("expected denied=%t, got=%t", test.expDenied, denied)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+)
+
+// crds are copies of the cert-manager CRDs in deploy/crds, kept in sync by
+// 'make update-crds'.
+//
+//go:embed crds/*.yaml
+var crds embed.FS
+
+// descriptionWidth is the width at which descriptions are wrapped.
+const descriptionWidth = 80
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Describe the fields of the cert-manager resources.
+
+The resource is given by its kind, singular or plural name, or short name,
+followed by the path of the field to describe, e.g. certificate.spec.renewBefore.
+The type and description of the field are printed, followed by the fields it
+contains. Without a field path, the top-level fields of the resource are listed.
+
+The descriptions are bundled with the tool, so no cluster needs to be reachable.
+They match the CRDs of the cert-manager version the tool was built for.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# List the top-level fields of the Certificate resource
+{{.BuildName}} explain certificate
+
+# Describe the renewBefore field of the Certificate spec
+{{.BuildName}} explain certificate.spec.renewBefore
+
+# Describe the fields of the ACME solvers of a ClusterIssuer
+{{.BuildName}} explain clusterissuer.spec.acme.solvers
+`)))
+)
+
+// Options is a struct to support explain command
+type Options struct {
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdExplain returns a cobra command for explain
+func NewCmdExplain(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "explain",
+		Short:   "Describe the fields of the cert-manager resources",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(args))
+		},
+	}
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the resource or field to explain has to be provided as argument, e.g. certificate.spec.renewBefore")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the resource or field to explain")
+	}
+	return nil
+}
+
+// Run executes explain command
+func (o *Options) Run(args []string) error {
+	path := strings.Split(args[0], ".")
+
+	crd, err := findCRD(path[0])
+	if err != nil {
+		return err
+	}
+
+	version := storageVersion(crd)
+	if version == nil || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return fmt.Errorf("no schema is bundled for the resource %q", crd.Spec.Names.Kind)
+	}
+
+	schema := version.Schema.OpenAPIV3Schema
+	var required bool
+	for _, name := range path[1:] {
+		schema = itemSchema(schema)
+		field, ok := schema.Properties[name]
+		if !ok {
+			return fmt.Errorf("field %q does not exist", name)
+		}
+		required = isRequired(schema, name)
+		schema = &field
+	}
+
+	fmt.Fprintf(o.Out, "KIND:     %s\n", crd.Spec.Names.Kind)
+	fmt.Fprintf(o.Out, "VERSION:  %s/%s\n\n", crd.Spec.Group, version.Name)
+	if len(path) > 1 {
+		fmt.Fprintf(o.Out, "FIELD:    %s <%s>%s\n\n", path[len(path)-1], typeName(schema), requiredMarker(required))
+	}
+
+	fmt.Fprintln(o.Out, "DESCRIPTION:")
+	writeDescription(o.Out, "     ", schema.Description)
+
+	fields := itemSchema(schema)
+	if len(fields.Properties) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields.Properties))
+	for name := range fields.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(o.Out)
+	fmt.Fprintln(o.Out, "FIELDS:")
+	for _, name := range names {
+		field := fields.Properties[name]
+		fmt.Fprintf(o.Out, "   %s\t<%s>%s\n", name, typeName(&field), requiredMarker(isRequired(fields, name)))
+		writeDescription(o.Out, "     ", field.Description)
+		fmt.Fprintln(o.Out)
+	}
+
+	return nil
+}
+
+// findCRD returns the bundled CRD whose kind, singular or plural name, or
+// short name matches name, ignoring case.
+func findCRD(name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	files, err := crds.ReadDir("crds")
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.ToLower(name)
+	var resources []string
+	for _, file := range files {
+		data, err := crds.ReadFile("crds/" + file.Name())
+		if err != nil {
+			return nil, err
+		}
+		crd := new(apiextensionsv1.CustomResourceDefinition)
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return nil, fmt.Errorf("error when decoding the bundled CRD %q: %w", file.Name(), err)
+		}
+
+		names := crd.Spec.Names
+		if name == strings.ToLower(names.Kind) || name == names.Singular || name == names.Plural {
+			return crd, nil
+		}
+		for _, shortName := range names.ShortNames {
+			if name == shortName {
+				return crd, nil
+			}
+		}
+		resources = append(resources, names.Singular)
+	}
+
+	sort.Strings(resources)
+	return nil, fmt.Errorf("%q is not a cert-manager resource, one of: (%s)", name, strings.Join(resources, ", "))
+}
+
+// storageVersion returns the version of crd which is persisted, which is the
+// latest version of the resource.
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Storage {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+// itemSchema returns the schema of the items of schema if it is an array, so
+// that the fields of a list of objects can be described like those of an object.
+func itemSchema(schema *apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	for schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil {
+		schema = schema.Items.Schema
+	}
+	return schema
+}
+
+func isRequired(schema *apiextensionsv1.JSONSchemaProps, name string) bool {
+	for _, required := range schema.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+func requiredMarker(required bool) string {
+	if required {
+		return " -required-"
+	}
+	return ""
+}
+
+// typeName returns the type of schema in the notation used by kubectl explain,
+// e.g. []string or Object.
+func typeName(schema *apiextensionsv1.JSONSchemaProps) string {
+	switch {
+	case schema.XIntOrString:
+		return "string"
+	case schema.Type == "array" && schema.Items != nil && schema.Items.Schema != nil:
+		return "[]" + typeName(schema.Items.Schema)
+	case schema.Type == "object" && len(schema.Properties) == 0 &&
+		schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		return "map[string]" + typeName(schema.AdditionalProperties.Schema)
+	case schema.Type == "object", schema.Type == "":
+		return "Object"
+	default:
+		return schema.Type
+	}
+}
+
+// writeDescription writes description to w, wrapping every paragraph at
+// descriptionWidth and indenting every line with indent.
+func writeDescription(w io.Writer, indent, description string) {
+	if len(strings.TrimSpace(description)) == 0 {
+		fmt.Fprintf(w, "%s<empty>\n", indent)
+		return
+	}
+
+	for _, paragraph := range strings.Split(strings.TrimSpace(description), "\n") {
+		line := indent
+		for _, word := range strings.Fields(paragraph) {
+			if len(line) > len(indent) && len(line)+1+len(word) > descriptionWidth {
+				fmt.Fprintln(w, line)
+				line = indent
+			}
+			if len(line) > len(indent) {
+				line += " "
+			}
+			line += word
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		path         string
+		expOutput    string
+		expContained []string
+		expErrMsg    string
+	}{
+		"a field is described": {
+			path: "certificate.spec.renewBefore",
+			expOutput: `KIND:     Certificate
+VERSION:  cert-manager.io/v1
+
+FIELD:    renewBefore <string>
+
+DESCRIPTION:
+     How long before the currently issued certificate's expiry cert-manager
+     should renew the certificate. The default is 2/3 of the issued
+     certificate's duration. Minimum accepted value is 5 minutes. Value must be
+     in units accepted by Go time.ParseDuration
+     https://golang.org/pkg/time/#ParseDuration
+`,
+		},
+		"the top-level fields of a resource given by its short name are listed": {
+			path: "CERT",
+			expContained: []string{
+				"KIND:     Certificate\nVERSION:  cert-manager.io/v1\n\nDESCRIPTION:\n",
+				"FIELDS:\n   apiVersion\t<string>\n",
+				"   spec\t<Object> -required-\n     Desired state of the Certificate resource.\n",
+			},
+		},
+		"the fields of the items of a list are listed": {
+			path: "clusterissuers.spec.acme.solvers",
+			expContained: []string{
+				"KIND:     ClusterIssuer\n",
+				"FIELD:    solvers <[]Object>\n",
+				"   dns01\t<Object>\n",
+				"   http01\t<Object>\n",
+			},
+		},
+		"required fields of nested objects are marked": {
+			path: "certificaterequest.spec",
+			expContained: []string{
+				"   usages\t<[]string>\n",
+				"   issuerRef\t<Object> -required-\n",
+				"   request\t<string> -required-\n",
+			},
+		},
+		"unknown resource throws error": {
+			path:      "deployment.spec",
+			expErrMsg: `"deployment" is not a cert-manager resource, one of: (certificate, certificaterequest, challenge, clusterissuer, issuer, order)`,
+		},
+		"unknown field throws error": {
+			path:      "certificate.spec.renewAfter",
+			expErrMsg: `field "renewAfter" does not exist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			err := NewOptions(streams).Run([]string{test.path})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			if test.expOutput != "" && out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+			for _, exp := range test.expContained {
+				if !strings.Contains(out.String(), exp) {
+					t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", exp, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	diskcached "k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+)
+
+// discoveryCacheTTL is the duration for which the discovery information of a
+// cluster is cached on disk. A TTL of 0 disables the on-disk cache.
+var discoveryCacheTTL = 6 * time.Hour
+
+// overlyCautiousIllegalFileCharacters matches characters that *might* not be
+// supported in file names, in the same way as kubectl does for its cache.
+var overlyCautiousIllegalFileCharacters = regexp.MustCompile(`[^(\w/.)]`)
+
+func addDiscoveryFlags(flags *pflag.FlagSet) {
+	flags.DurationVar(&discoveryCacheTTL, "discovery-cache-ttl", discoveryCacheTTL, ""+
+		"Duration for which the API discovery information of a cluster is cached on disk below --cache-dir. "+
+		"Set to 0 to disable the discovery cache.")
+}
+
+// Discovery returns a discovery client for the cluster. Unless disabled with
+// the --discovery-cache-ttl flag, discovery information is cached on disk below
+// --cache-dir so that it can be reused across invocations. The client is
+// created on first use and reused for the lifetime of the Factory.
+func (f *Factory) Discovery() (discovery.DiscoveryInterface, error) {
+	if f.DiscoveryClient != nil {
+		return f.DiscoveryClient, nil
+	}
+
+	if f.RESTConfig == nil {
+		if f.KubeClient == nil {
+			return nil, fmt.Errorf("no cluster configured")
+		}
+		return f.KubeClient.Discovery(), nil
+	}
+
+	client, err := newDiscoveryClient(f.RESTConfig, *kubeConfigFlags.CacheDir, discoveryCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	f.DiscoveryClient = client
+
+	return client, nil
+}
+
+// newDiscoveryClient returns a discovery client which caches in memory or, if
+// ttl is not 0, on disk below cacheDir. The server version is requested
+// uncached and is part of the cache directory, so that the cached information
+// is not reused once the cluster has been upgraded. The client returns that
+// version from ServerVersion rather than requesting it again.
+func newDiscoveryClient(config *rest.Config, cacheDir string, ttl time.Duration) (discovery.CachedDiscoveryInterface, error) {
+	config = rest.CopyConfig(config)
+	// Discovery of large clusters needs many requests, so the default client
+	// side rate limit is raised in the same way as kubectl does.
+	config.Burst = 300
+
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 || cacheDir == "" {
+		return memory.NewMemCacheClient(client), nil
+	}
+
+	version, err := client.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("error when getting the server version: %w", err)
+	}
+
+	cached, err := diskcached.NewCachedDiscoveryClientForConfig(
+		config,
+		discoveryCacheDir(cacheDir, config.Host, version.GitVersion),
+		filepath.Join(cacheDir, "http"),
+		ttl,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &versionedDiscoveryClient{CachedDiscoveryInterface: cached, version: version}, nil
+}
+
+// versionedDiscoveryClient is a cached discovery client which also caches the
+// server version, until the cache is invalidated.
+type versionedDiscoveryClient struct {
+	discovery.CachedDiscoveryInterface
+
+	lock    sync.Mutex
+	version *version.Info
+}
+
+// ServerVersion returns the cached server version, requesting it from the
+// server if the cache has been invalidated since.
+func (c *versionedDiscoveryClient) ServerVersion() (*version.Info, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.version != nil {
+		return c.version, nil
+	}
+	info, err := c.CachedDiscoveryInterface.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	c.version = info
+	return info, nil
+}
+
+// Invalidate drops the cached server version along with the discovery
+// information.
+func (c *versionedDiscoveryClient) Invalidate() {
+	c.lock.Lock()
+	c.version = nil
+	c.lock.Unlock()
+	c.CachedDiscoveryInterface.Invalidate()
+}
+
+// discoveryCacheDir returns the directory in which the discovery information
+// of the server with the given host and version is cached.
+func discoveryCacheDir(cacheDir, host, version string) string {
+	// Strip the scheme as it is not a relevant part of the key.
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	key := overlyCautiousIllegalFileCharacters.ReplaceAllString(host+"_"+version, "_")
+	return filepath.Join(cacheDir, "cmctl", "discovery", key)
+}
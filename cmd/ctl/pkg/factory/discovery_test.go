@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestDiscoveryCacheDir(t *testing.T) {
+	tests := map[string]struct {
+		host    string
+		version string
+		expDir  string
+	}{
+		"scheme is stripped and port is sanitized": {
+			host:    "https://10.0.0.1:6443",
+			version: "v1.27.2",
+			expDir:  filepath.Join("cache", "cmctl", "discovery", "10.0.0.1_6443_v1.27.2"),
+		},
+		"version build metadata is sanitized": {
+			host:    "http://example.com",
+			version: "v1.27.2+k3s1",
+			expDir:  filepath.Join("cache", "cmctl", "discovery", "example.com_v1.27.2_k3s1"),
+		},
+		"different server versions use different directories": {
+			host:    "https://10.0.0.1:6443",
+			version: "v1.28.0",
+			expDir:  filepath.Join("cache", "cmctl", "discovery", "10.0.0.1_6443_v1.28.0"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := discoveryCacheDir("cache", test.host, test.version)
+			if dir != test.expDir {
+				t.Errorf("Unexpected cache directory; expected: \n%s\nactual: \n%s", test.expDir, dir)
+			}
+		})
+	}
+}
+
+func TestDiscoveryClientServerVersion(t *testing.T) {
+	var versionRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			http.NotFound(w, r)
+			return
+		}
+		versionRequests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"gitVersion":"v1.27.2"}`))
+	}))
+	defer server.Close()
+
+	client, err := newDiscoveryClient(&rest.Config{Host: server.URL}, t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		info, err := client.ServerVersion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.GitVersion != "v1.27.2" {
+			t.Errorf("Unexpected server version; expected: v1.27.2, actual: %s", info.GitVersion)
+		}
+	}
+	if versionRequests != 1 {
+		t.Errorf("Expected the server version to be requested once to look up the cache, actual: %d", versionRequests)
+	}
+
+	client.Invalidate()
+	if _, err := client.ServerVersion(); err != nil {
+		t.Fatal(err)
+	}
+	if versionRequests != 2 {
+		t.Errorf("Expected the server version to be requested again once the cache is invalidated, actual: %d", versionRequests)
+	}
+}
@@ -18,11 +18,15 @@ package factory
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/kubectl/pkg/cmd/util"
 
 	// Load all auth plugins
@@ -58,6 +62,15 @@ type Factory struct {
 	// KubeClient is a Kubernetes clientset for interacting with the base
 	// Kubernetes APIs.
 	KubeClient kubernetes.Interface
+
+	// DiscoveryClient is used to discover the APIs served by the cluster. It
+	// is populated on first use by Discovery.
+	DiscoveryClient discovery.DiscoveryInterface
+
+	// APIExtensionsClient is a clientset for interacting with the
+	// CustomResourceDefinitions of the cluster. It is populated on first use
+	// by APIExtensions.
+	APIExtensionsClient apiextensionsclient.Interface
 }
 
 // New returns a new Factory. The supplied command will have flags registered
@@ -66,16 +79,37 @@ type Factory struct {
 // is already defined, it will be executed _after_ Factory has been populated,
 // making it available.
 func New(ctx context.Context, cmd *cobra.Command) *Factory {
+	return newFactory(ctx, cmd, false)
+}
+
+// NewOptional returns a new Factory in the same way as New, but a missing or
+// invalid cluster configuration will not fail the command. Instead, the
+// Factory is left empty so that commands which are able to run without a
+// cluster can check whether one was configured.
+func NewOptional(ctx context.Context, cmd *cobra.Command) *Factory {
+	return newFactory(ctx, cmd, true)
+}
+
+func newFactory(ctx context.Context, cmd *cobra.Command, optional bool) *Factory {
 	f := new(Factory)
 
 	kubeConfigFlags.AddFlags(cmd.Flags())
+	addDiscoveryFlags(cmd.Flags())
 	cmd.RegisterFlagCompletionFunc("namespace", validArgsListNamespaces(ctx, f))
 
 	// Setup a PreRun to populate the Factory. Catch the existing PreRun command
 	// if one was defined, and execute it second.
 	existingPreRun := cmd.PreRun
 	cmd.PreRun = func(cmd *cobra.Command, args []string) {
-		util.CheckErr(f.complete())
+		// Flags given explicitly are invalid regardless of whether a cluster
+		// is required
+		util.CheckErr(validateTokenFlags())
+		if err := f.complete(); err != nil && optional {
+			// Leave the Factory empty to signal that no cluster is available.
+			*f = Factory{}
+		} else {
+			util.CheckErr(err)
+		}
 		if existingPreRun != nil {
 			existingPreRun(cmd, args)
 		}
@@ -111,3 +145,53 @@ func (f *Factory) complete() error {
 
 	return nil
 }
+
+// validateTokenFlags validates the --server and --token flags, which allow
+// accessing a cluster without a kubeconfig, e.g. in CI. If no kubeconfig is
+// loaded, both flags have to be given. The kubeconfig is only loaded if one of
+// the flags is given, so that commands with an optional Factory don't fail on
+// an invalid kubeconfig.
+func validateTokenFlags() error {
+	if len(*kubeConfigFlags.APIServer) == 0 && len(*kubeConfigFlags.BearerToken) == 0 {
+		return nil
+	}
+	raw, err := factory.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return err
+	}
+	return validateServerToken(*kubeConfigFlags.APIServer, *kubeConfigFlags.BearerToken, raw)
+}
+
+// validateServerToken returns an error if only one of server and token is
+// given while the kubeconfig raw does not configure any cluster or user to
+// complete it.
+func validateServerToken(server, token string, raw clientcmdapi.Config) error {
+	if (len(server) > 0) == (len(token) > 0) {
+		return nil
+	}
+	if len(raw.Clusters) > 0 || len(raw.AuthInfos) > 0 {
+		return nil
+	}
+	return fmt.Errorf("the --server and --token flags must be provided together when no kubeconfig is available")
+}
+
+// APIExtensions returns a clientset for the CustomResourceDefinitions of the
+// cluster. The clientset is created on first use and reused for the lifetime
+// of the Factory.
+func (f *Factory) APIExtensions() (apiextensionsclient.Interface, error) {
+	if f.APIExtensionsClient != nil {
+		return f.APIExtensionsClient, nil
+	}
+
+	if f.RESTConfig == nil {
+		return nil, fmt.Errorf("no cluster configured")
+	}
+
+	client, err := apiextensionsclient.NewForConfig(f.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	f.APIExtensionsClient = client
+
+	return client, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestValidateServerToken(t *testing.T) {
+	kubeconfig := clientcmdapi.Config{
+		Clusters:  map[string]*clientcmdapi.Cluster{"my-cluster": {Server: "https://10.0.0.1:6443"}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"my-user": {Token: "my-token"}},
+	}
+	tests := map[string]struct {
+		server string
+		token  string
+		raw    clientcmdapi.Config
+
+		expErr bool
+	}{
+		"neither flag without a kubeconfig": {},
+		"server and token without a kubeconfig": {
+			server: "https://10.0.0.1:6443",
+			token:  "my-token",
+		},
+		"server without token without a kubeconfig": {
+			server: "https://10.0.0.1:6443",
+			expErr: true,
+		},
+		"token without server without a kubeconfig": {
+			token:  "my-token",
+			expErr: true,
+		},
+		"server overriding the kubeconfig": {
+			server: "https://10.0.0.2:6443",
+			raw:    kubeconfig,
+		},
+		"token overriding the kubeconfig": {
+			token: "my-other-token",
+			raw:   kubeconfig,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateServerToken(test.server, test.token, test.raw)
+			if test.expErr != (err != nil) {
+				t.Fatalf("got unexpected error, expected error: %t; actual: %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateTokenFlagsWithoutFlags(t *testing.T) {
+	// An invalid kubeconfig must not fail commands with an optional Factory
+	// if neither --server nor --token is given
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte("not a kubeconfig"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	kubeconfig := *kubeConfigFlags.KubeConfig
+	*kubeConfigFlags.KubeConfig = path
+	defer func() { *kubeConfigFlags.KubeConfig = kubeconfig }()
+
+	if err := validateTokenFlags(); err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+}
@@ -75,6 +75,33 @@ func ValidArgsListSecrets(ctx context.Context, factory **Factory) func(_ *cobra.
 	}
 }
 
+// ValidArgsListConfigMaps returns a cobra ValidArgsFunction for listing
+// ConfigMaps.
+func ValidArgsListConfigMaps(ctx context.Context, factory **Factory) func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		f := (*factory)
+		if err := f.complete(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		configMapsList, err := f.KubeClient.CoreV1().ConfigMaps(f.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var names []string
+		for _, configMap := range configMapsList.Items {
+			names = append(names, configMap.Name)
+		}
+
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 // ValidArgsListCertificateSigningRequests returns a cobra ValidArgsFunction for
 // listing CertificateSigningRequests.
 func ValidArgsListCertificateSigningRequests(ctx context.Context, factory **Factory) func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/secret"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get details about the certificates in a CA bundle stored in a ConfigMap.
+
+CA bundles distributed by trust-manager or injected by cainjector may contain
+many concatenated PEM encoded certificates. Every certificate in the given key
+of the ConfigMap is listed with its subject and expiry, followed by a summary
+of the bundle. PEM blocks which are not certificates are ignored.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Summarize the certificates in the 'ca.crt' key of the ConfigMap 'my-bundle' in namespace 'my-namespace'
+{{.BuildName}} inspect configmap my-bundle --namespace my-namespace
+
+# Summarize the certificates in the 'trust-bundle.pem' key of the ConfigMap 'my-bundle'
+{{.BuildName}} inspect configmap my-bundle --key trust-bundle.pem
+`)))
+)
+
+// Options is a struct to support inspect configmap command
+type Options struct {
+	// Key is the ConfigMap key containing the CA bundle
+	Key string
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		Key:       cmmeta.TLSCAKey,
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdInspectConfigMap returns a cobra command for inspect configmap
+func NewCmdInspectConfigMap(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "configmap",
+		Short:             "Get details about the certificates in a CA bundle stored in a ConfigMap",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListConfigMaps(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Key, "key", o.Key, "The key of the ConfigMap containing the PEM encoded CA bundle")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the ConfigMap has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the ConfigMap")
+	}
+	if len(o.Key) == 0 {
+		return errors.New("the --key flag must not be empty")
+	}
+	return nil
+}
+
+// Run executes inspect configmap command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	configMap, err := o.KubeClient.CoreV1().ConfigMaps(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when finding ConfigMap %q: %w", args[0], err)
+	}
+
+	bundle, err := bundleData(configMap, o.Key)
+	if err != nil {
+		return err
+	}
+
+	certs, err := secret.SplitPEMs(bundle)
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("the %q key of ConfigMap %q does not contain any PEM encoded certificate", o.Key, configMap.Name)
+	}
+
+	headers, rows, summary := bundleTable(certs, clock.Now())
+	if err := util.WriteTable(o.Out, headers, rows); err != nil {
+		return err
+	}
+	fmt.Fprintln(o.Out)
+	fmt.Fprintln(o.Out, summary)
+
+	return nil
+}
+
+// bundleData returns the value of key in the ConfigMap, which may either be
+// stored as data or binary data.
+func bundleData(configMap *corev1.ConfigMap, key string) ([]byte, error) {
+	if data, ok := configMap.Data[key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[key]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("the ConfigMap %q has no key %q", configMap.Name, key)
+}
+
+// bundleTable returns the headers and rows of a table listing the subject and
+// expiry of every certificate in certs in the order of the bundle, and a
+// summary of the bundle. Certificates which cannot be parsed are listed as
+// invalid.
+func bundleTable(certs [][]byte, now time.Time) ([]string, [][]string, string) {
+	headers := []string{"#", "SUBJECT", "ISSUER", "CA", "NOT AFTER", "EXPIRES IN"}
+
+	var rows [][]string
+	var invalid, expired int
+	var earliest time.Time
+	for i, certPEM := range certs {
+		cert, err := pki.DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			invalid++
+			rows = append(rows, []string{strconv.Itoa(i + 1), "<invalid>", "<invalid>", "<unknown>", "<unknown>", "<unknown>"})
+			continue
+		}
+
+		expiresIn := "expired"
+		if remaining := cert.NotAfter.Sub(now); remaining > 0 {
+			expiresIn = duration.HumanDuration(remaining)
+		} else {
+			expired++
+		}
+		if earliest.IsZero() || cert.NotAfter.Before(earliest) {
+			earliest = cert.NotAfter
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			printOrNone(cert.Subject.String()),
+			printOrNone(cert.Issuer.String()),
+			strconv.FormatBool(cert.IsCA),
+			cert.NotAfter.UTC().Format(time.RFC3339),
+			expiresIn,
+		})
+	}
+
+	summary := fmt.Sprintf("%d certificate(s), %d expired, %d invalid", len(certs), expired, invalid)
+	if !earliest.IsZero() {
+		summary += fmt.Sprintf(", earliest expires %s", earliest.UTC().Format(time.RFC3339))
+	}
+
+	return headers, rows, summary
+}
+
+func printOrNone(in string) string {
+	if in == "" {
+		return "<none>"
+	}
+	return in
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configmap
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func selfSignedCA(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"cert-manager"}},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPEM
+}
+
+func TestRun(t *testing.T) {
+	const ns = "test-ns"
+	now := time.Date(2022, 9, 16, 0, 0, 0, 0, time.UTC)
+
+	realClock := clock
+	clock = fakeclock.NewFakeClock(now)
+	defer func() { clock = realClock }()
+
+	rootA := selfSignedCA(t, "Root A", now.Add(30*24*time.Hour))
+	rootB := selfSignedCA(t, "Root B", now.Add(-time.Hour))
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("not a certificate")})
+	invalid := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")})
+
+	bundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: ns},
+		Data: map[string]string{
+			"ca.crt":     string(rootA) + string(privateKey) + string(rootB) + string(invalid),
+			"single.pem": string(rootA),
+			"empty.pem":  "",
+		},
+		BinaryData: map[string][]byte{"binary.pem": rootB},
+	}
+
+	tests := map[string]struct {
+		name      string
+		key       string
+		expOutput string
+		expErrMsg string
+	}{
+		"every certificate in a bundle is listed in order": {
+			name: "bundle",
+			key:  "ca.crt",
+			expOutput: `#  SUBJECT                   ISSUER                    CA         NOT AFTER             EXPIRES IN
+1  CN=Root A,O=cert-manager  CN=Root A,O=cert-manager  true       2022-10-16T00:00:00Z  30d
+2  CN=Root B,O=cert-manager  CN=Root B,O=cert-manager  true       2022-09-15T23:00:00Z  expired
+3  <invalid>                 <invalid>                 <unknown>  <unknown>             <unknown>
+
+3 certificate(s), 1 expired, 1 invalid, earliest expires 2022-09-15T23:00:00Z
+`,
+		},
+		"a single certificate is listed": {
+			name: "bundle",
+			key:  "single.pem",
+			expOutput: `#  SUBJECT                   ISSUER                    CA    NOT AFTER             EXPIRES IN
+1  CN=Root A,O=cert-manager  CN=Root A,O=cert-manager  true  2022-10-16T00:00:00Z  30d
+
+1 certificate(s), 0 expired, 0 invalid, earliest expires 2022-10-16T00:00:00Z
+`,
+		},
+		"binary data keys are listed": {
+			name: "bundle",
+			key:  "binary.pem",
+			expOutput: `#  SUBJECT                   ISSUER                    CA    NOT AFTER             EXPIRES IN
+1  CN=Root B,O=cert-manager  CN=Root B,O=cert-manager  true  2022-09-15T23:00:00Z  expired
+
+1 certificate(s), 1 expired, 0 invalid, earliest expires 2022-09-15T23:00:00Z
+`,
+		},
+		"key without certificates throws error": {
+			name:      "bundle",
+			key:       "empty.pem",
+			expErrMsg: `the "empty.pem" key of ConfigMap "bundle" does not contain any PEM encoded certificate`,
+		},
+		"missing key throws error": {
+			name:      "bundle",
+			key:       "tls.crt",
+			expErrMsg: `the ConfigMap "bundle" has no key "tls.crt"`,
+		},
+		"missing ConfigMap throws error": {
+			name:      "missing",
+			key:       "ca.crt",
+			expErrMsg: `error when finding ConfigMap "missing": configmaps "missing" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.Key = test.key
+			opts.Factory = &factory.Factory{
+				Namespace:  ns,
+				KubeClient: kubefake.NewSimpleClientset(bundle),
+			}
+
+			err := opts.Run(context.TODO(), []string{test.name})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
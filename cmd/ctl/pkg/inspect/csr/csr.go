@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/describe"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get details about the CSR of a CertificateRequest, to verify what was requested.
+
+The PEM encoded CSR in spec.request of the CertificateRequest is decoded, and its
+subject, key type, DNS names, IP addresses, URIs and email addresses, the key
+usages and extended key usages requested by its extensions and its signature
+algorithm are printed. The subject, key type and subject alternative names are
+printed in the same way as by 'status certificate --show-csr'. The Approved and Denied conditions of the CertificateRequest are
+printed first, to see whether the request was approved.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Print the CSR of the CertificateRequest 'my-cr' and whether it was approved
+{{.BuildName}} inspect csr my-cr
+
+# Print the CSR of the CertificateRequest 'my-cr' in namespace 'my-namespace'
+{{.BuildName}} inspect csr my-cr --namespace my-namespace
+`)))
+)
+
+// Options is a struct to support inspect csr command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdInspectCSR returns a cobra command for inspect csr
+func NewCmdInspectCSR(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "csr",
+		Short:             "Get details about the CSR of a CertificateRequest",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the CertificateRequest has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the CertificateRequest")
+	}
+	return nil
+}
+
+// Run executes inspect csr command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	req, err := o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when finding CertificateRequest %q: %w", args[0], err)
+	}
+
+	if len(req.Spec.Request) == 0 {
+		return fmt.Errorf("the CertificateRequest %q has no CSR in spec.request", req.Name)
+	}
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return fmt.Errorf("error when decoding the CSR of CertificateRequest %q: %w", req.Name, err)
+	}
+	usages, extUsages, err := requestedUsages(csr)
+	if err != nil {
+		return fmt.Errorf("error when decoding the CSR of CertificateRequest %q: %w", req.Name, err)
+	}
+
+	var buf bytes.Buffer
+	w := util.NewPrefixWriter(&buf)
+	w.Write(0, "CertificateRequest: %s/%s\n", req.Namespace, req.Name)
+	describeApproval(req, w, util.ColorEnabled(o.Out))
+	w.Write(0, "CSR:\n")
+	// The summary of the CSR is shared with 'status certificate --show-csr'
+	certificate.CSRStatusFromRequest(csr).Describe(w, 1)
+	util.DescribeList("Key Usages", usages, w, 1)
+	util.DescribeList("Extended Key Usages", extUsages, w, 1)
+	w.Write(1, "Signature Algorithm: %s\n", csr.SignatureAlgorithm)
+
+	_, err = o.Out.Write(buf.Bytes())
+	return err
+}
+
+// describeApproval writes the Approved and Denied conditions of req, or that
+// it is neither approved nor denied yet.
+func describeApproval(req *cmapi.CertificateRequest, w describe.PrefixWriter, color bool) {
+	w.Write(0, "Approval:\n")
+	found := false
+	for _, conditionType := range []cmapi.CertificateRequestConditionType{cmapi.CertificateRequestConditionApproved, cmapi.CertificateRequestConditionDenied} {
+		if cond := apiutil.GetCertificateRequestCondition(req, conditionType); cond != nil {
+			found = true
+			w.Write(1, "%s: %s, Reason: %s, Message: %s\n", cond.Type,
+				util.ColoredText(util.ColorizeConditionStatus(string(cond.Status), color)), cond.Reason, cond.Message)
+		}
+	}
+	if !found {
+		w.Write(1, "The CertificateRequest is neither Approved nor Denied yet\n")
+	}
+}
+
+// requestedUsages returns the key usages and extended key usages requested
+// by the extensions of csr, with the names used in the usages of
+// Certificates. Extended key usages which are unknown are returned as their
+// OID.
+func requestedUsages(csr *x509.CertificateRequest) ([]string, []string, error) {
+	var keyUsage x509.KeyUsage
+	var extKeyUsage []x509.ExtKeyUsage
+	var unknown []string
+	for _, ext := range csr.Extensions {
+		switch {
+		case ext.Id.Equal(pki.OIDExtensionKeyUsage):
+			var bits asn1.BitString
+			if _, err := asn1.Unmarshal(ext.Value, &bits); err != nil {
+				return nil, nil, fmt.Errorf("error when decoding the key usage extension: %w", err)
+			}
+			for i := 0; i < 9; i++ {
+				if bits.At(i) != 0 {
+					keyUsage |= 1 << uint(i)
+				}
+			}
+		case ext.Id.Equal(pki.OIDExtensionExtendedKeyUsage):
+			var oids []asn1.ObjectIdentifier
+			if _, err := asn1.Unmarshal(ext.Value, &oids); err != nil {
+				return nil, nil, fmt.Errorf("error when decoding the extended key usage extension: %w", err)
+			}
+			for _, oid := range oids {
+				if eku, ok := pki.ExtKeyUsageFromOID(oid); ok {
+					extKeyUsage = append(extKeyUsage, eku)
+				} else {
+					unknown = append(unknown, oid.String())
+				}
+			}
+		}
+	}
+
+	var usages, extUsages []string
+	for _, usage := range apiutil.KeyUsageStrings(keyUsage) {
+		usages = append(usages, string(usage))
+	}
+	for _, usage := range apiutil.ExtKeyUsageStrings(extKeyUsage) {
+		extUsages = append(extUsages, string(usage))
+	}
+	return usages, append(extUsages, unknown...), nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csr
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args      []string
+		expErrMsg string
+	}{
+		"no name throws error": {
+			expErrMsg: "the name of the CertificateRequest has to be provided as argument",
+		},
+		"multiple names throw error": {
+			args:      []string{"cr-1", "cr-2"},
+			expErrMsg: "only one argument can be passed in: the name of the CertificateRequest",
+		},
+		"single name is valid": {
+			args: []string{"cr-1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewOptions(genericclioptions.NewTestIOStreamsDiscard()).Validate(test.args)
+			if test.expErrMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.expErrMsg != "" && (err == nil || err.Error() != test.expErrMsg) {
+				t.Errorf("expected error %q, got %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyUsage, err := pki.MarshalKeyUsage(x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extKeyUsage, err := pki.MarshalExtKeyUsage([]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, []asn1.ObjectIdentifier{{1, 2, 3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spiffe, _ := url.Parse("spiffe://example.com/workload")
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:         pkix.Name{CommonName: "example.com", Organization: []string{"Example"}},
+		DNSNames:        []string{"example.com", "www.example.com"},
+		IPAddresses:     []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:            []*url.URL{spiffe},
+		ExtraExtensions: []pkix.Extension{keyUsage, extKeyUsage},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	tests := map[string]struct {
+		cr *cmapi.CertificateRequest
+
+		expOutput string
+		expErrMsg string
+	}{
+		"CSR of an approved CertificateRequest": {
+			cr: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestNamespace("default"),
+				gen.SetCertificateRequestCSR(csrPEM),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue, Reason: "policy", Message: "approved by policy",
+				}),
+			),
+			expOutput: `CertificateRequest: default/cr-1
+Approval:
+  Approved: True, Reason: policy, Message: approved by policy
+CSR:
+  Subject: CN=example.com,O=Example
+  Key Type: ECDSA P-256
+  DNS Names:
+  - example.com
+  - www.example.com
+  IP Addresses:
+  - 10.0.0.1
+  URIs:
+  - spiffe://example.com/workload
+  Email Addresses: <none>
+  Key Usages:
+  - digital signature
+  - key encipherment
+  Extended Key Usages:
+  - server auth
+  - client auth
+  - 1.2.3.4
+  Signature Algorithm: ECDSA-SHA256
+`,
+		},
+		"CSR of a pending CertificateRequest": {
+			cr: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestNamespace("default"),
+				gen.SetCertificateRequestCSR(csrPEM),
+			),
+			expOutput: `CertificateRequest: default/cr-1
+Approval:
+  The CertificateRequest is neither Approved nor Denied yet
+CSR:
+  Subject: CN=example.com,O=Example
+  Key Type: ECDSA P-256
+  DNS Names:
+  - example.com
+  - www.example.com
+  IP Addresses:
+  - 10.0.0.1
+  URIs:
+  - spiffe://example.com/workload
+  Email Addresses: <none>
+  Key Usages:
+  - digital signature
+  - key encipherment
+  Extended Key Usages:
+  - server auth
+  - client auth
+  - 1.2.3.4
+  Signature Algorithm: ECDSA-SHA256
+`,
+		},
+		"malformed PEM throws error": {
+			cr: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestNamespace("default"),
+				gen.SetCertificateRequestCSR([]byte("not a PEM encoded CSR")),
+			),
+			expErrMsg: `error when decoding the CSR of CertificateRequest "cr-1": error decoding certificate request PEM block`,
+		},
+		"missing CSR throws error": {
+			cr:        gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace("default")),
+			expErrMsg: `the CertificateRequest "cr-1" has no CSR in spec.request`,
+		},
+		"missing CertificateRequest throws error": {
+			cr:        gen.CertificateRequest("cr-2", gen.SetCertificateRequestNamespace("default")),
+			expErrMsg: `error when finding CertificateRequest "cr-1": certificaterequests.cert-manager.io "cr-1" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			o := &Options{
+				IOStreams: genericclioptions.IOStreams{Out: &out, ErrOut: &out},
+				Factory:   &factory.Factory{CMClient: cmfake.NewSimpleClientset(test.cr), Namespace: "default"},
+			}
+
+			err := o.Run(context.TODO(), []string{"cr-1"})
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("expected error %q, got %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/configmap"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/csr"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/secret"
 )
 
@@ -29,10 +31,12 @@ func NewCmdInspect(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	cmds := &cobra.Command{
 		Use:   "inspect",
 		Short: "Get details on certificate related resources",
-		Long:  `Get details on certificate related resources, e.g. secrets`,
+		Long:  `Get details on certificate related resources, e.g. secrets, CA bundles stored in configmaps or the CSRs of certificaterequests`,
 	}
 
 	cmds.AddCommand(secret.NewCmdInspectSecret(ctx, ioStreams))
+	cmds.AddCommand(configmap.NewCmdInspectConfigMap(ctx, ioStreams))
+	cmds.AddCommand(csr.NewCmdInspectCSR(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// matchHostnames verifies the certificate for each of hostnames the way TLS
+// clients do, using x509.Certificate.VerifyHostname, so wildcard DNS names
+// match a single label only and the Common Name is ignored. It returns one
+// result per hostname, naming the subject alternative name it matched or the
+// reason it did not, and the number of hostnames which did not match.
+func matchHostnames(cert *x509.Certificate, hostnames []string) ([]string, int) {
+	var results []string
+	mismatches := 0
+	for _, hostname := range hostnames {
+		if err := cert.VerifyHostname(hostname); err != nil {
+			mismatches++
+			results = append(results, fmt.Sprintf("%s: no match (%s)", hostname, err))
+			continue
+		}
+		results = append(results, fmt.Sprintf("%s: match (%s)", hostname, matchedName(cert, hostname)))
+	}
+	return results, mismatches
+}
+
+// matchedName returns the subject alternative name of the certificate which
+// hostname was verified against.
+func matchedName(cert *x509.Certificate, hostname string) string {
+	if ip := net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")); ip != nil {
+		return "IP Address " + ip.String()
+	}
+	for _, name := range cert.DNSNames {
+		if (&x509.Certificate{DNSNames: []string{name}}).VerifyHostname(hostname) == nil {
+			return "DNS Name " + name
+		}
+	}
+	return "<unknown>"
+}
+
+// describeHostnameMatches lists the results of matchHostnames.
+func describeHostnameMatches(results []string) string {
+	return "Hostname Matches:\n\t- " + strings.Join(results, "\n\t- ")
+}
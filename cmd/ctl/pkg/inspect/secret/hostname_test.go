@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/x509"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func Test_matchHostnames(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames:    []string{"example.com", "*.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}
+
+	tests := []struct {
+		name           string
+		hostnames      []string
+		want           []string
+		wantMismatches int
+	}{
+		{
+			name:      "Exact and wildcard DNS names and IP addresses match",
+			hostnames: []string{"example.com", "API.example.com", "10.0.0.1"},
+			want: []string{
+				"example.com: match (DNS Name example.com)",
+				"API.example.com: match (DNS Name *.example.com)",
+				"10.0.0.1: match (IP Address 10.0.0.1)",
+			},
+		},
+		{
+			name:      "Wildcards only match a single label",
+			hostnames: []string{"api.example.com", "v1.api.example.com"},
+			want: []string{
+				"api.example.com: match (DNS Name *.example.com)",
+				"v1.api.example.com: no match (x509: certificate is valid for example.com, *.example.com, not v1.api.example.com)",
+			},
+			wantMismatches: 1,
+		},
+		{
+			name:      "IP addresses not in the certificate do not match",
+			hostnames: []string{"10.0.0.2"},
+			want: []string{
+				"10.0.0.2: no match (x509: certificate is valid for 10.0.0.1, not 10.0.0.2)",
+			},
+			wantMismatches: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, mismatches := matchHostnames(cert, tt.hostnames)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchHostnames() = %v, want %v", got, tt.want)
+			}
+			if mismatches != tt.wantMismatches {
+				t.Errorf("matchHostnames() mismatches = %d, want %d", mismatches, tt.wantMismatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// asn1TagNames are the names of the universal ASN.1 tags, as printed by
+// 'openssl asn1parse'.
+var asn1TagNames = map[int]string{
+	asn1.TagBoolean:         "BOOLEAN",
+	asn1.TagInteger:         "INTEGER",
+	asn1.TagBitString:       "BIT STRING",
+	asn1.TagOctetString:     "OCTET STRING",
+	asn1.TagNull:            "NULL",
+	asn1.TagOID:             "OBJECT",
+	asn1.TagEnum:            "ENUMERATED",
+	asn1.TagUTF8String:      "UTF8STRING",
+	asn1.TagSequence:        "SEQUENCE",
+	asn1.TagSet:             "SET",
+	asn1.TagNumericString:   "NUMERICSTRING",
+	asn1.TagPrintableString: "PRINTABLESTRING",
+	asn1.TagT61String:       "T61STRING",
+	asn1.TagIA5String:       "IA5STRING",
+	asn1.TagUTCTime:         "UTCTIME",
+	asn1.TagGeneralizedTime: "GENERALIZEDTIME",
+	asn1.TagGeneralString:   "GENERALSTRING",
+	asn1.TagBMPString:       "BMPSTRING",
+}
+
+// describeRaw returns the ASN.1 structure of every PEM encoded certificate in
+// certs, in the style of 'openssl asn1parse', together with the error parsing
+// the certificate, if any. The structure is printed up to the first element
+// which cannot be decoded.
+func describeRaw(certs [][]byte) string {
+	var b strings.Builder
+	b.WriteString("Raw certificates:")
+	for i, certPEM := range certs {
+		block, _ := pem.Decode(certPEM)
+		if block == nil {
+			// Cannot happen as certs are re-encoded by SplitPEMs
+			continue
+		}
+		fmt.Fprintf(&b, "\n\tCertificate %d (%d bytes):\n", i, len(block.Bytes))
+		if _, err := pki.DecodeX509CertificateBytes(certPEM); err != nil {
+			fmt.Fprintf(&b, "\tParse error: %s\n", err)
+		}
+		var structure bytes.Buffer
+		if err := writeASN1Structure(&structure, block.Bytes, 0, 0); err != nil {
+			fmt.Fprintf(&structure, "error decoding ASN.1: %s\n", err)
+		}
+		b.WriteString("\t\t" + strings.ReplaceAll(strings.TrimRight(structure.String(), "\n"), "\n", "\n\t\t"))
+	}
+	return b.String()
+}
+
+// writeASN1Structure writes one line per ASN.1 element of der to w, descending
+// into constructed elements. offset is the offset of der in the outermost
+// element, and depth the nesting depth of its elements.
+func writeASN1Structure(w *bytes.Buffer, der []byte, offset, depth int) error {
+	for len(der) > 0 {
+		var value asn1.RawValue
+		rest, err := asn1.Unmarshal(der, &value)
+		if err != nil {
+			return fmt.Errorf("at offset %d: %w", offset, err)
+		}
+		headerLength := len(value.FullBytes) - len(value.Bytes)
+
+		form := "prim"
+		if value.IsCompound {
+			form = "cons"
+		}
+		fmt.Fprintf(w, "%5d:d=%d  hl=%d l=%4d %s: %s", offset, depth, headerLength, len(value.Bytes), form, asn1TagName(value))
+		if value.IsCompound {
+			w.WriteString("\n")
+			if err := writeASN1Structure(w, value.Bytes, offset+headerLength, depth+1); err != nil {
+				return err
+			}
+		} else {
+			if formatted := asn1PrimitiveValue(value); len(formatted) > 0 {
+				w.WriteString(" :" + formatted)
+			}
+			w.WriteString("\n")
+		}
+
+		offset += len(value.FullBytes)
+		der = rest
+	}
+	return nil
+}
+
+// asn1TagName returns the name of the tag of value, e.g. "SEQUENCE" or
+// "cont [ 3 ]" for context-specific tags.
+func asn1TagName(value asn1.RawValue) string {
+	switch value.Class {
+	case asn1.ClassUniversal:
+		if name, ok := asn1TagNames[value.Tag]; ok {
+			return name
+		}
+		return fmt.Sprintf("univ [ %d ]", value.Tag)
+	case asn1.ClassApplication:
+		return fmt.Sprintf("appl [ %d ]", value.Tag)
+	case asn1.ClassContextSpecific:
+		return fmt.Sprintf("cont [ %d ]", value.Tag)
+	default:
+		return fmt.Sprintf("priv [ %d ]", value.Tag)
+	}
+}
+
+// asn1PrimitiveValue returns the value of the primitive universal element
+// value in a human readable form, or an empty string for values which are
+// not printed, e.g. bit and octet strings.
+func asn1PrimitiveValue(value asn1.RawValue) string {
+	if value.Class != asn1.ClassUniversal {
+		return ""
+	}
+	switch value.Tag {
+	case asn1.TagBoolean:
+		var b bool
+		if _, err := asn1.Unmarshal(value.FullBytes, &b); err != nil {
+			return "<invalid>"
+		}
+		return fmt.Sprint(b)
+	case asn1.TagInteger, asn1.TagEnum:
+		// Large integers such as serial numbers are printed in hex, the
+		// same way openssl does
+		i := new(big.Int).SetBytes(value.Bytes)
+		if len(value.Bytes) > 0 && value.Bytes[0]&0x80 != 0 {
+			i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(value.Bytes)*8)))
+		}
+		if len(value.Bytes) > 8 {
+			return fmt.Sprintf("%X", value.Bytes)
+		}
+		return i.String()
+	case asn1.TagOID:
+		var oid asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(value.FullBytes, &oid); err != nil {
+			return "<invalid>"
+		}
+		return oid.String()
+	case asn1.TagUTF8String, asn1.TagNumericString, asn1.TagPrintableString, asn1.TagT61String,
+		asn1.TagIA5String, asn1.TagUTCTime, asn1.TagGeneralizedTime, asn1.TagGeneralString:
+		return printers.EscapeTerminal(string(value.Bytes))
+	default:
+		return ""
+	}
+}
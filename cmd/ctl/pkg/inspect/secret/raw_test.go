@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+// testSequence is SEQUENCE { INTEGER 5, OBJECT 1.2.3, PRINTABLESTRING "abc" }
+var testSequence = []byte{0x30, 0x0c, 0x02, 0x01, 0x05, 0x06, 0x02, 0x2a, 0x03, 0x13, 0x03, 'a', 'b', 'c'}
+
+func Test_writeASN1Structure(t *testing.T) {
+	tests := []struct {
+		name    string
+		der     []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Nested elements are indented by depth with their values",
+			der:  testSequence,
+			want: `    0:d=0  hl=2 l=  12 cons: SEQUENCE
+    2:d=1  hl=2 l=   1 prim: INTEGER :5
+    5:d=1  hl=2 l=   2 prim: OBJECT :1.2.3
+    9:d=1  hl=2 l=   3 prim: PRINTABLESTRING :abc
+`,
+		},
+		{
+			name: "Elements up to the first undecodable one are printed",
+			der:  []byte{0x30, 0x03, 0x02, 0x05, 0x01},
+			want: `    0:d=0  hl=2 l=   3 cons: SEQUENCE
+`,
+			wantErr: true,
+		},
+		{
+			name:    "Truncated outer element",
+			der:     testSequence[:5],
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bytes.Buffer
+			err := writeASN1Structure(&got, tt.der, 0, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("writeASN1Structure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("writeASN1Structure() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_describeRaw(t *testing.T) {
+	certs := [][]byte{
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testSequence}),
+		[]byte(testCert),
+	}
+	got := describeRaw(certs)
+
+	want := `Raw certificates:
+	Certificate 0 (14 bytes):
+	Parse error: `
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("describeRaw() = %q, want prefix %q", got, want)
+	}
+	if !strings.Contains(got, "\n\t\t    9:d=1  hl=2 l=   3 prim: PRINTABLESTRING :abc\n\tCertificate 1 (") {
+		t.Errorf("describeRaw() = %q, want the indented structure of every certificate", got)
+	}
+	if strings.Count(got, "Parse error") != 1 {
+		t.Errorf("describeRaw() = %q, want a parse error for the first certificate only", got)
+	}
+}
@@ -20,16 +20,20 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -53,7 +57,8 @@ const validForTemplate = `Valid for:
 
 const validityPeriodTemplate = `Validity period:
 	Not Before: {{ .NotBefore }}
-	Not After: {{ .NotAfter }}`
+	Not After: {{ .NotAfter }}
+	Remaining: {{ .Remaining }}`
 
 const issuedByTemplate = `Issued By:
 	Common Name:	{{ .CommonName }}
@@ -83,16 +88,76 @@ const debuggingTemplate = `Debugging:
 
 var (
 	long = templates.LongDesc(i18n.T(`
-Get details about a kubernetes.io/tls typed secret`))
+Get details about a kubernetes.io/tls typed secret.
+
+The leaf certificate in 'tls.crt' is described in detail, followed by a summary of the intermediate certificates of
+the chain, if any. A warning is printed if the leaf certificate has expired, or expires within the window given by
+--warn-before.
+
+Use --all to additionally list every data key of the secret, including keys written for additionalOutputFormats.
+Values are only printed when --reveal-key is set.
+
+Use --export-kubeconfig-ca to only print the CA certificate stored in 'ca.crt', base64 encoded as expected by the
+'certificate-authority-data' field of a kubeconfig.
+
+Use --warn-weak-key to check every certificate in 'tls.crt' for RSA keys shorter than 2048 bits, signatures using
+SHA-1 and expired intermediate certificates. The command exits with an error if any of these are found.
+
+Use --raw to inspect certificates which cannot be parsed, e.g. because of unusual extensions. If the certificate in
+'tls.crt' cannot be parsed, the parse error is printed together with the ASN.1 structure of every certificate in
+'tls.crt', in the style of 'openssl asn1parse', instead of failing.
+
+Use --match-hostname to check whether TLS clients accept the leaf certificate for the given hostname or IP address.
+The hostname is verified against the subject alternative names of the certificate as Go's crypto/x509 does, i.e.
+wildcards only match a single label and the Common Name is ignored. The flag may be repeated, and the command exits
+with an error if any of the hostnames does not match.`))
 
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query information about a secret with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} inspect secret my-crt --namespace my-namespace
+
+# Warn if the certificate of a secret expires within the next 30 days
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --warn-before 720h
+
+# Additionally list every data key of the secret with its size and, for certificates, the expiry
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --all
+
+# Print the CA certificate of a secret in the form of the 'certificate-authority-data' field of a kubeconfig
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --export-kubeconfig-ca
+
+# Audit the certificate chain of a secret, failing if weak keys, SHA-1 signatures or expired intermediates are found
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --warn-weak-key
+
+# Print the ASN.1 structure of the certificates of a secret if they cannot be parsed
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --raw
+
+# Check that clients accept the certificate of a secret for both api.example.com and www.example.com
+{{.BuildName}} inspect secret my-crt --namespace my-namespace --match-hostname api.example.com --match-hostname www.example.com
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// If true, list every data key of the Secret with its size
+	All bool
+	// If true, print the values of the data keys listed by All
+	RevealKey bool
+	// If true, only print the CA certificate of the Secret base64 encoded,
+	// as expected by the certificate-authority-data field of a kubeconfig
+	ExportKubeconfigCA bool
+	// If true, check the certificates of the Secret for weak keys and
+	// signatures, and fail if any are found
+	WarnWeakKey bool
+	// If true, print the ASN.1 structure of the certificates of the Secret
+	// if they cannot be parsed, instead of failing
+	Raw bool
+	// Window before the expiry of the certificate of the Secret in which a
+	// warning is printed
+	WarnBefore time.Duration
+	// Hostnames to verify the leaf certificate of the Secret for, failing if
+	// any of them does not match
+	MatchHostnames []string
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -120,6 +185,24 @@ func NewCmdInspectSecret(ctx context.Context, ioStreams genericclioptions.IOStre
 		},
 	}
 
+	cmd.Flags().BoolVar(&o.All, "all", o.All,
+		"If set to true, list every data key of the Secret with its size, and a summary for keys containing certificates")
+	cmd.Flags().BoolVar(&o.RevealKey, "reveal-key", o.RevealKey,
+		"If set to true, print the values of the data keys listed with --all, including private keys")
+	cmd.Flags().BoolVar(&o.ExportKubeconfigCA, "export-kubeconfig-ca", o.ExportKubeconfigCA,
+		"If set to true, only print the CA certificate stored in 'ca.crt' base64 encoded, as expected by the 'certificate-authority-data' field of a kubeconfig")
+
+	cmd.Flags().BoolVar(&o.WarnWeakKey, "warn-weak-key", o.WarnWeakKey,
+		"If set to true, check every certificate in 'tls.crt' for RSA keys shorter than 2048 bits, SHA-1 signatures and expired intermediates, and exit with an error listing them")
+	cmd.Flags().BoolVar(&o.Raw, "raw", o.Raw,
+		"If set to true and the certificate in 'tls.crt' cannot be parsed, print the parse error and the ASN.1 structure of every certificate in 'tls.crt' instead of failing")
+
+	cmd.Flags().DurationVar(&o.WarnBefore, "warn-before", o.WarnBefore,
+		"Print a warning if the certificate in 'tls.crt' expires within the given duration, e.g. 720h. A warning is always printed for expired certificates")
+
+	cmd.Flags().StringArrayVar(&o.MatchHostnames, "match-hostname", o.MatchHostnames,
+		"Hostname or IP address to verify the certificate in 'tls.crt' for, as TLS clients do, exiting with an error if it does not match. May be specified multiple times.")
+
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -133,6 +216,24 @@ func (o *Options) Validate(args []string) error {
 	if len(args) > 1 {
 		return errors.New("only one argument can be passed in: the name of the Secret")
 	}
+	if o.RevealKey && !o.All {
+		return errors.New("the --reveal-key flag can only be used in conjunction with the --all flag")
+	}
+	if o.ExportKubeconfigCA && o.All {
+		return errors.New("the --export-kubeconfig-ca flag cannot be used in conjunction with the --all flag")
+	}
+	if o.ExportKubeconfigCA && o.WarnWeakKey {
+		return errors.New("the --export-kubeconfig-ca flag cannot be used in conjunction with the --warn-weak-key flag")
+	}
+	if o.WarnBefore < 0 {
+		return errors.New("the --warn-before flag must not be negative")
+	}
+	if o.Raw && (o.ExportKubeconfigCA || o.WarnWeakKey) {
+		return errors.New("the --raw flag cannot be used in conjunction with the --export-kubeconfig-ca or --warn-weak-key flags")
+	}
+	if len(o.MatchHostnames) > 0 && (o.ExportKubeconfigCA || o.Raw) {
+		return errors.New("the --match-hostname flag cannot be used in conjunction with the --export-kubeconfig-ca or --raw flags")
+	}
 	return nil
 }
 
@@ -143,12 +244,31 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when finding Secret %q: %w\n", args[0], err)
 	}
 
+	if o.ExportKubeconfigCA {
+		caData, err := kubeconfigCAData(secret)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, caData)
+		return nil
+	}
+
+	var dataDescription string
+	if o.All {
+		dataDescription = describeData(secret.Data, o.RevealKey)
+	}
+
 	certData := secret.Data[corev1.TLSCertKey]
-	certs, err := splitPEMs(certData)
+	certs, err := SplitPEMs(certData)
 	if err != nil {
 		return err
 	}
 	if len(certs) < 1 {
+		if o.All && !o.WarnWeakKey && len(o.MatchHostnames) == 0 {
+			// Secrets without a certificate can still be inspected using --all
+			fmt.Fprintln(o.Out, dataDescription)
+			return nil
+		}
 		return errors.New("no PEM data found in secret")
 	}
 
@@ -159,10 +279,23 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 
 	// we only want to inspect the leaf certificate
 	x509Cert, err := pki.DecodeX509CertificateBytes(certs[0])
+	if err != nil && o.Raw {
+		fmt.Fprintf(o.ErrOut, "warning: error when parsing 'tls.crt': %s\n", err)
+		out := []string{describeRaw(certs)}
+		if o.All {
+			out = append(out, dataDescription)
+		}
+		fmt.Fprintln(o.Out, strings.Join(out, "\n\n"))
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error when parsing 'tls.crt': %w", err)
 	}
 
+	if warning := expiryWarning(x509Cert, o.WarnBefore); len(warning) > 0 {
+		fmt.Fprintf(o.ErrOut, "warning: %s\n", warning)
+	}
+
 	out := []string{
 		describeValidFor(x509Cert),
 		describeValidityPeriod(x509Cert),
@@ -171,12 +304,65 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		describeCertificate(x509Cert),
 		describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
 	}
+	if len(intermediates) > 0 {
+		out = append(out, describeIntermediates(intermediates))
+	}
+	if o.All {
+		out = append(out, dataDescription)
+	}
+
+	var weaknesses []string
+	if o.WarnWeakKey {
+		chain := []*x509.Certificate{x509Cert}
+		for i, intermediate := range intermediates {
+			cert, err := pki.DecodeX509CertificateBytes(intermediate)
+			if err != nil {
+				return fmt.Errorf("error when parsing certificate %d of 'tls.crt': %w", i+1, err)
+			}
+			chain = append(chain, cert)
+		}
+		weaknesses = findWeakKeys(chain)
+		out = append(out, describeWeakKeys(weaknesses))
+	}
 
-	fmt.Println(strings.Join(out, "\n\n"))
+	var mismatches int
+	if len(o.MatchHostnames) > 0 {
+		var results []string
+		results, mismatches = matchHostnames(x509Cert, o.MatchHostnames)
+		out = append(out, describeHostnameMatches(results))
+	}
+
+	fmt.Fprintln(o.Out, strings.Join(out, "\n\n"))
+
+	if len(weaknesses) > 0 {
+		return fmt.Errorf("found %d weaknesses in the certificates of Secret %q", len(weaknesses), secret.Name)
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("the certificate of Secret %q is not valid for %d of the hostnames given by --match-hostname", secret.Name, mismatches)
+	}
 
 	return nil
 }
 
+// kubeconfigCAData returns the CA certificate stored in the 'ca.crt' key of
+// secret in the form of the certificate-authority-data field of a kubeconfig.
+func kubeconfigCAData(secret *corev1.Secret) (string, error) {
+	caData := secret.Data[cmmeta.TLSCAKey]
+	if len(caData) == 0 {
+		return "", fmt.Errorf("the Secret %q has no CA certificate: %q is not set", secret.Name, cmmeta.TLSCAKey)
+	}
+
+	certs, err := SplitPEMs(caData)
+	if err != nil {
+		return "", err
+	}
+	if len(certs) == 0 {
+		return "", fmt.Errorf("the %q key of Secret %q does not contain any PEM encoded certificate", cmmeta.TLSCAKey, secret.Name)
+	}
+
+	return base64.StdEncoding.EncodeToString(caData), nil
+}
+
 func describeValidFor(cert *x509.Certificate) string {
 	var b bytes.Buffer
 	template.Must(template.New("validForTemplate").Parse(validForTemplate)).Execute(&b, struct {
@@ -198,17 +384,58 @@ func describeValidFor(cert *x509.Certificate) string {
 
 func describeValidityPeriod(cert *x509.Certificate) string {
 	var b bytes.Buffer
+	remaining := "expired"
+	if now := clock.Now(); now.Before(cert.NotAfter) {
+		remaining = duration.HumanDuration(cert.NotAfter.Sub(now))
+	}
 	template.Must(template.New("validityPeriodTemplate").Parse(validityPeriodTemplate)).Execute(&b, struct {
 		NotBefore string
 		NotAfter  string
+		Remaining string
 	}{
 		NotBefore: cert.NotBefore.Format(time.RFC1123),
 		NotAfter:  cert.NotAfter.Format(time.RFC1123),
+		Remaining: remaining,
 	})
 
 	return b.String()
 }
 
+// expiryWarning returns a warning if cert has expired or expires within
+// warnBefore, or an empty string otherwise.
+func expiryWarning(cert *x509.Certificate, warnBefore time.Duration) string {
+	now := clock.Now()
+	if !now.Before(cert.NotAfter) {
+		return fmt.Sprintf("the certificate expired on %s", cert.NotAfter.Format(time.RFC1123))
+	}
+	if remaining := cert.NotAfter.Sub(now); remaining <= warnBefore {
+		return fmt.Sprintf("the certificate expires in %s on %s, within the --warn-before window of %s",
+			duration.HumanDuration(remaining), cert.NotAfter.Format(time.RFC1123), warnBefore)
+	}
+	return ""
+}
+
+// describeIntermediates summarizes the intermediate certificates of the
+// chain in 'tls.crt', numbered by their position in the chain.
+func describeIntermediates(intermediates [][]byte) string {
+	var b strings.Builder
+	b.WriteString("Intermediates:")
+	for i, intermediate := range intermediates {
+		fmt.Fprintf(&b, "\n\tCertificate %d:", i+1)
+		cert, err := pki.DecodeX509CertificateBytes(intermediate)
+		if err != nil {
+			fmt.Fprintf(&b, "\n\t\tError: %s", err)
+			continue
+		}
+		fmt.Fprintf(&b, "\n\t\tSubject:\t%s", printOrNone(cert.Subject.CommonName))
+		fmt.Fprintf(&b, "\n\t\tIssuer:\t%s", printOrNone(cert.Issuer.CommonName))
+		fmt.Fprintf(&b, "\n\t\tSerial Number:\t%s", cert.SerialNumber)
+		fmt.Fprintf(&b, "\n\t\tNot After:\t%s", cert.NotAfter.Format(time.RFC1123))
+		fmt.Fprintf(&b, "\n\t\tIs a CA certificate:\t%t", cert.IsCA)
+	}
+	return b.String()
+}
+
 func describeIssuedBy(cert *x509.Certificate) string {
 	var b bytes.Buffer
 	template.Must(template.New("issuedByTemplate").Parse(issuedByTemplate)).Execute(&b, struct {
@@ -281,6 +508,72 @@ func describeDebugging(cert *x509.Certificate, intermediates [][]byte, ca []byte
 	return b.String()
 }
 
+// describeData lists every key in data sorted by name, together with the
+// size of its value and, if the value contains PEM encoded certificates, the
+// number of certificates and the earliest expiry among them. The values are
+// only printed if reveal is true.
+func describeData(data map[string][]byte, reveal bool) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("Data:")
+	if len(keys) == 0 {
+		b.WriteString(" <none>")
+	}
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\n\t%s:\t%d bytes", key, len(data[key]))
+		if summary := describeCertificatesSummary(data[key]); summary != "" {
+			fmt.Fprintf(&b, ", %s", summary)
+		}
+		if reveal {
+			b.WriteString(printDataValue(data[key]))
+		}
+	}
+
+	return b.String()
+}
+
+// describeCertificatesSummary returns a one line summary of the PEM encoded
+// certificates in data, or an empty string if data doesn't contain any.
+func describeCertificatesSummary(data []byte) string {
+	certs, err := SplitPEMs(data)
+	if err != nil || len(certs) == 0 {
+		return ""
+	}
+
+	var earliest *x509.Certificate
+	for _, certPEM := range certs {
+		cert, err := pki.DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			return fmt.Sprintf("%d PEM certificate blocks, cannot parse certificate: %s", len(certs), err.Error())
+		}
+		if earliest == nil || cert.NotAfter.Before(earliest.NotAfter) {
+			earliest = cert
+		}
+	}
+
+	if len(certs) == 1 {
+		return fmt.Sprintf("1 certificate, expires %s", earliest.NotAfter.Format(time.RFC1123))
+	}
+	return fmt.Sprintf("%d certificates, earliest expires %s", len(certs), earliest.NotAfter.Format(time.RFC1123))
+}
+
+// printDataValue returns the value of a data key indented below the key.
+// Binary values are printed base64 encoded.
+func printDataValue(value []byte) string {
+	text := string(value)
+	if !utf8.Valid(value) {
+		text = base64.StdEncoding.EncodeToString(value)
+	}
+	text = strings.TrimRight(text, "\n")
+
+	return "\n\t\t" + strings.ReplaceAll(text, "\n", "\n\t\t")
+}
+
 func describeCRL(cert *x509.Certificate) string {
 	if len(cert.CRLDistributionPoints) < 1 {
 		return "No CRL endpoints set"
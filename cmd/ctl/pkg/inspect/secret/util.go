@@ -32,6 +32,7 @@ import (
 	"golang.org/x/crypto/ocsp"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func fingerprintCert(cert *x509.Certificate) string {
@@ -163,7 +164,28 @@ func printKeyUsage(in []cmapi.KeyUsage) string {
 	return "\n\t\t- " + strings.Trim(strings.Join(usageStrings, "\n\t\t- "), " ")
 }
 
-func splitPEMs(certData []byte) ([][]byte, error) {
+// LeafCertificate returns the first certificate of the PEM encoded
+// certificate chain in certData, which is the leaf certificate of the chains
+// written by cert-manager.
+func LeafCertificate(certData []byte) (*x509.Certificate, error) {
+	certs, err := SplitPEMs(certData)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) < 1 {
+		return nil, errors.New("no PEM data found in secret")
+	}
+
+	cert, err := pki.DecodeX509CertificateBytes(certs[0])
+	if err != nil {
+		return nil, fmt.Errorf("error when parsing 'tls.crt': %w", err)
+	}
+	return cert, nil
+}
+
+// SplitPEMs returns every PEM encoded certificate in certData, re-encoded
+// individually. PEM blocks which are not certificates are ignored.
+func SplitPEMs(certData []byte) ([][]byte, error) {
 	certs := [][]byte(nil)
 	for {
 		block, rest := pem.Decode(certData)
@@ -185,7 +185,7 @@ func Test_printSliceOrOne(t *testing.T) {
 	}
 }
 
-func Test_splitPEMs(t *testing.T) {
+func Test_SplitPEMs(t *testing.T) {
 	type args struct {
 		certData []byte
 	}
@@ -223,13 +223,13 @@ func Test_splitPEMs(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := splitPEMs(tt.certData)
+			got, err := SplitPEMs(tt.certData)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("splitPEMs() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("SplitPEMs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("splitPEMs() got = %v, want %v", got, tt.want)
+				t.Errorf("SplitPEMs() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
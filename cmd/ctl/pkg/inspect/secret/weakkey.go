@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// weakSignatureAlgorithms are the signature algorithms relying on a hash
+// function which is not collision resistant.
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// findWeakKeys returns the weaknesses of the certificate chain stored in
+// 'tls.crt', leaf first: RSA keys shorter than 2048 bits, signatures using
+// SHA-1 or an even weaker hash function, and expired intermediate
+// certificates. Every weakness names the certificate it was found in.
+func findWeakKeys(chain []*x509.Certificate) []string {
+	var weaknesses []string
+	for i, cert := range chain {
+		name := fmt.Sprintf("Certificate %d (Common Name: %s)", i, printOrNone(cert.Subject.CommonName))
+
+		if key, ok := cert.PublicKey.(*rsa.PublicKey); ok && key.N.BitLen() < pki.MinRSAKeySize {
+			weaknesses = append(weaknesses, fmt.Sprintf("%s: RSA key of %d bits, at least %d bits are required", name, key.N.BitLen(), pki.MinRSAKeySize))
+		}
+		if weakSignatureAlgorithms[cert.SignatureAlgorithm] {
+			weaknesses = append(weaknesses, fmt.Sprintf("%s: signed using %s, which relies on a hash function that is not collision resistant", name, cert.SignatureAlgorithm))
+		}
+		if i > 0 && clock.Now().After(cert.NotAfter) {
+			weaknesses = append(weaknesses, fmt.Sprintf("%s: intermediate certificate expired on %s", name, cert.NotAfter.Format(time.RFC1123)))
+		}
+	}
+	return weaknesses
+}
+
+// describeWeakKeys lists the weaknesses found by findWeakKeys.
+func describeWeakKeys(weaknesses []string) string {
+	if len(weaknesses) == 0 {
+		return "Weak Keys: <none>"
+	}
+	return "Weak Keys:\n\t- " + strings.Join(weaknesses, "\n\t- ")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func Test_findWeakKeys(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock = fakeclock.NewFakeClock(now)
+
+	rsaKey := func(bits int) *rsa.PublicKey {
+		return &rsa.PublicKey{N: new(big.Int).Lsh(big.NewInt(1), uint(bits-1)), E: 65537}
+	}
+	cert := func(cn string, key *rsa.PublicKey, alg x509.SignatureAlgorithm, notAfter time.Time) *x509.Certificate {
+		return &x509.Certificate{
+			Subject:            pkix.Name{CommonName: cn},
+			PublicKey:          key,
+			SignatureAlgorithm: alg,
+			NotAfter:           notAfter,
+		}
+	}
+	valid := now.Add(time.Hour)
+	expired := now.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		chain []*x509.Certificate
+		want  []string
+	}{
+		{
+			name:  "Strong chain has no weaknesses",
+			chain: []*x509.Certificate{cert("leaf", rsaKey(2048), x509.SHA256WithRSA, valid), cert("intermediate", rsaKey(4096), x509.SHA384WithRSA, valid)},
+			want:  nil,
+		},
+		{
+			name:  "Short RSA key and SHA-1 signature of the leaf are reported",
+			chain: []*x509.Certificate{cert("leaf", rsaKey(1024), x509.SHA1WithRSA, valid)},
+			want: []string{
+				"Certificate 0 (Common Name: leaf): RSA key of 1024 bits, at least 2048 bits are required",
+				"Certificate 0 (Common Name: leaf): signed using SHA1-RSA, which relies on a hash function that is not collision resistant",
+			},
+		},
+		{
+			name:  "Expired intermediate is reported, but not an expired leaf",
+			chain: []*x509.Certificate{cert("leaf", rsaKey(2048), x509.SHA256WithRSA, expired), cert("", rsaKey(2048), x509.ECDSAWithSHA1, expired)},
+			want: []string{
+				"Certificate 1 (Common Name: <none>): signed using ECDSA-SHA1, which relies on a hash function that is not collision resistant",
+				"Certificate 1 (Common Name: <none>): intermediate certificate expired on Tue, 31 May 2022 23:00:00 UTC",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findWeakKeys(tt.chain); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findWeakKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_describeWeakKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		weaknesses []string
+		want       string
+	}{
+		{
+			name: "Describe no weaknesses",
+			want: `Weak Keys: <none>`,
+		},
+		{
+			name:       "Describe weaknesses as a list",
+			weaknesses: []string{"first", "second"},
+			want: `Weak Keys:
+	- first
+	- second`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeWeakKeys(tt.weaknesses); got != tt.want {
+				t.Errorf("describeWeakKeys() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
+			}
+		})
+	}
+}
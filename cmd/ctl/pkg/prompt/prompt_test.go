@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]struct {
+		input        string
+		expConfirmed bool
+		expOutput    string
+	}{
+		"y is confirmation": {
+			input:        "y\n",
+			expConfirmed: true,
+			expOutput:    "Do you want to continue? [y/N]: ",
+		},
+		"yes in any case is confirmation": {
+			input:        " YES \n",
+			expConfirmed: true,
+			expOutput:    "Do you want to continue? [y/N]: ",
+		},
+		"answer without trailing newline is read": {
+			input:        "y",
+			expConfirmed: true,
+			expOutput:    "Do you want to continue? [y/N]: ",
+		},
+		"empty answer is no": {
+			input:     "\n",
+			expOutput: "Do you want to continue? [y/N]: ",
+		},
+		"other answer is no": {
+			input:     "sure\n",
+			expOutput: "Do you want to continue? [y/N]: ",
+		},
+		"no input is no": {
+			expOutput: "Do you want to continue? [y/N]: \n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			confirmed := Confirm(strings.NewReader(test.input), out, "Do you want to continue?")
+			if confirmed != test.expConfirmed {
+				t.Errorf("Unexpected confirmation; expected: %t, actual: %t", test.expConfirmed, confirmed)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: %q, actual: %q", test.expOutput, out.String())
+			}
+		})
+	}
+}
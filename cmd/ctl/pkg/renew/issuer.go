@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// issuerCertificates returns the Certificates whose issuerRef references the
+// issuer given by --issuer, --issuer-kind and --issuer-group, and the number
+// of Certificates which were skipped. The name and group are matched
+// exactly, the kind case-insensitively. The kind and group of issuerRefs
+// which don't set them default to Issuer and cert-manager.io, as they do for
+// cert-manager.
+func (o *Options) issuerCertificates(crts []cmapi.Certificate) ([]cmapi.Certificate, int) {
+	var matched []cmapi.Certificate
+	for _, crt := range crts {
+		ref := util.IssuerRefWithDefaults(&crt)
+		if ref.Name == o.Issuer && strings.EqualFold(ref.Kind, o.IssuerKind) && ref.Group == o.IssuerGroup {
+			matched = append(matched, crt)
+		}
+	}
+	return matched, len(crts) - len(matched)
+}
+
+// issuerString returns the issuer given by --issuer, --issuer-kind and
+// --issuer-group as printed in the summary, e.g.
+// 'ClusterIssuer.cert-manager.io/my-ca'.
+func (o *Options) issuerString() string {
+	return fmt.Sprintf("%s.%s/%s", o.IssuerKind, o.IssuerGroup, o.Issuer)
+}
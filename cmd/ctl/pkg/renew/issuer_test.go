@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renew
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRunIssuer(t *testing.T) {
+	const ns = "test-ns"
+	defaultedCrt := gen.Certificate("defaulted-crt", gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "my-ca"}))
+	issuerCrt := gen.Certificate("issuer-crt", gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "Issuer", Group: "cert-manager.io"}),
+		gen.AddCertificateLabels(map[string]string{"env": "prod"}))
+	clusterIssuerCrt := gen.Certificate("cluster-issuer-crt", gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "ClusterIssuer", Group: "cert-manager.io"}))
+	externalCrt := gen.Certificate("external-crt", gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "my-ca", Kind: "Issuer", Group: "example.com"}))
+	otherNameCrt := gen.Certificate("other-name-crt", gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "My-CA", Kind: "Issuer"}))
+
+	tests := map[string]struct {
+		options    *Options
+		expOutput  string
+		expRenewed []string
+	}{
+		"Issuer matches issuerRefs defaulting to its kind and group, but not other names": {
+			options: &Options{Issuer: "my-ca", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io"},
+			expOutput: `Matched 2 Certificate(s) issued by Issuer.cert-manager.io/my-ca, skipped 3
+Manually triggered issuance of Certificate test-ns/defaulted-crt
+Manually triggered issuance of Certificate test-ns/issuer-crt
+`,
+			expRenewed: []string{"defaulted-crt", "issuer-crt"},
+		},
+		"kind is matched case-insensitively": {
+			options: &Options{Issuer: "my-ca", IssuerKind: "clusterissuer", IssuerGroup: "cert-manager.io"},
+			expOutput: `Matched 1 Certificate(s) issued by clusterissuer.cert-manager.io/my-ca, skipped 4
+Manually triggered issuance of Certificate test-ns/cluster-issuer-crt
+`,
+			expRenewed: []string{"cluster-issuer-crt"},
+		},
+		"group of an external issuer is matched": {
+			options: &Options{Issuer: "my-ca", IssuerKind: "Issuer", IssuerGroup: "example.com"},
+			expOutput: `Matched 1 Certificate(s) issued by Issuer.example.com/my-ca, skipped 4
+Manually triggered issuance of Certificate test-ns/external-crt
+`,
+			expRenewed: []string{"external-crt"},
+		},
+		"--issuer is combined with --exclude": {
+			options: &Options{Issuer: "my-ca", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io", Exclude: "env=prod"},
+			expOutput: `Matched 2 Certificate(s) issued by Issuer.cert-manager.io/my-ca, skipped 3
+Excluded 1 Certificate(s) matching "env=prod"
+Manually triggered issuance of Certificate test-ns/defaulted-crt
+`,
+			expRenewed: []string{"defaulted-crt"},
+		},
+		"nothing is renewed if no Certificate matches": {
+			options: &Options{Issuer: "other-ca", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io"},
+			expOutput: `Matched 0 Certificate(s) issued by Issuer.cert-manager.io/other-ca, skipped 5
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			cmClient := cmfake.NewSimpleClientset(defaultedCrt, issuerCrt, clusterIssuerCrt, externalCrt, otherNameCrt)
+			opts := test.options
+			opts.IOStreams = streams
+			opts.Factory = &factory.Factory{
+				Namespace: ns,
+				CMClient:  cmClient,
+			}
+
+			if err := opts.Run(context.TODO(), nil); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+
+			crts, err := cmClient.CertmanagerV1().Certificates(ns).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var renewed []string
+			for _, crt := range crts.Items {
+				for _, cond := range crt.Status.Conditions {
+					if cond.Type == cmapi.CertificateConditionIssuing && strings.Contains(cond.Reason, "ManuallyTriggered") {
+						renewed = append(renewed, crt.Name)
+					}
+				}
+			}
+			if !reflect.DeepEqual(renewed, test.expRenewed) {
+				t.Errorf("Unexpected renewed Certificates; expected: %v, actual: %v", test.expRenewed, renewed)
+			}
+		})
+	}
+}
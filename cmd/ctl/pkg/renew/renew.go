@@ -18,29 +18,66 @@ package renew
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/secret"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/prompt"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 )
 
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
 var (
 	long = templates.LongDesc(i18n.T(`
-Mark cert-manager Certificate resources for manual renewal.`))
+Mark cert-manager Certificate resources for manual renewal.
+
+Renewal is triggered by setting the Issuing condition of the Certificates, which
+the cert-manager controller honors by issuing a new certificate. If any of the
+named Certificates does not exist, none of the Certificates are renewed.
+
+With --print-age, the age and remaining lifetime of the certificate currently
+stored in the Secret of every selected Certificate is printed, and confirmation
+is requested before renewing them, unless --yes is specified. This helps to
+avoid renewing freshly issued certificates by accident.
+
+With --exclude, the Certificates matching the given label selector are removed
+from the Certificates selected by --selector or --all, and are not renewed. The
+number of excluded Certificates is printed.
+
+With --issuer, the Certificates whose issuerRef references the given issuer
+are renewed, e.g. to renew every Certificate issued by a CA which is rotated.
+The Certificates in the namespace, or in all namespaces with --all-namespaces,
+are listed and the number of Certificates which reference the issuer and which
+are skipped is printed. The issuer is the Issuer with the given name, or the
+issuer of --issuer-kind and --issuer-group, e.g. a ClusterIssuer or an external
+issuer. The name and group must match exactly, the kind is matched
+case-insensitively. It can be combined with --selector to only renew the
+matching Certificates which also have the given labels.`))
 
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Renew the Certificates named 'my-app' and 'vault' in the current context namespace.
@@ -50,7 +87,21 @@ Mark cert-manager Certificate resources for manual renewal.`))
 {{.BuildName}} renew --namespace kube-system --all
 
 # Renew all Certificates in all namespaces, provided those Certificates have the label 'app=my-service'
-{{.BuildName}} renew --all-namespaces -l app=my-service`)))
+{{.BuildName}} renew --all-namespaces -l app=my-service
+
+# Renew all Certificates with the label 'app=web' in the current context namespace, except those with the label 'env=prod'.
+{{.BuildName}} renew -l app=web --exclude env=prod
+
+# Renew all Certificates in the current context namespace, labeling each renewed Certificate
+# with the rotation campaign and annotating it with the time it was renewed.
+{{.BuildName}} renew --all --label-renewed rotation=2022-q3 --annotate-renewed example.com/renewed-at
+
+# Renew all Certificates in all namespaces which are issued by the ClusterIssuer 'my-ca'.
+{{.BuildName}} renew --all-namespaces --issuer my-ca --issuer-kind ClusterIssuer
+
+# Print the age and remaining lifetime of the certificates of all Certificates with the label
+# 'app=my-service' and ask for confirmation before renewing them.
+{{.BuildName}} renew -l app=my-service --print-age`)))
 )
 
 // Options is a struct to support renew command
@@ -58,6 +109,27 @@ type Options struct {
 	LabelSelector string
 	All           bool
 	AllNamespaces bool
+	// Exclude is a label selector of Certificates which are not renewed,
+	// even though they are selected by LabelSelector or All
+	Exclude string
+	// Issuer is the name of the issuer whose Certificates are renewed,
+	// referenced with IssuerKind and IssuerGroup by their issuerRef
+	Issuer      string
+	IssuerKind  string
+	IssuerGroup string
+
+	// LabelRenewed are key=value labels added to every renewed Certificate
+	LabelRenewed []string
+	// AnnotateRenewed are key[=value] annotations added to every renewed
+	// Certificate. Annotations without a value are set to the time of renewal.
+	AnnotateRenewed []string
+
+	// If true, print the age and remaining lifetime of the current
+	// certificate of every Certificate and ask for confirmation before
+	// renewing them
+	PrintAge bool
+	// If true, the Certificates are renewed without asking for confirmation
+	Yes bool
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -88,6 +160,14 @@ func NewCmdRenew(ctx context.Context, ioStreams genericclioptions.IOStreams) *co
 	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, mark Certificates across namespaces for manual renewal. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().BoolVar(&o.All, "all", o.All, "Renew all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().StringVar(&o.Exclude, "exclude", o.Exclude, "Selector (label query) of Certificates not to renew, even though they are selected by --selector or --all, supports '=', '==', '!=', 'in' and 'notin'.(e.g. --exclude env=prod)")
+	cmd.Flags().StringVar(&o.Issuer, "issuer", o.Issuer, "Renew the Certificates whose issuerRef references the issuer with the given name, in the given Namespace or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", cmapi.IssuerKind, "The kind of the issuer given by --issuer, matched case-insensitively, e.g. Issuer or ClusterIssuer.")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", cmapi.SchemeGroupVersion.Group, "The API group of the issuer given by --issuer, e.g. of an external issuer.")
+	cmd.Flags().StringArrayVar(&o.LabelRenewed, "label-renewed", o.LabelRenewed, "Label in the form 'key=value' to add to every renewed Certificate. May be specified multiple times.")
+	cmd.Flags().StringArrayVar(&o.AnnotateRenewed, "annotate-renewed", o.AnnotateRenewed, "Annotation in the form 'key=value' or 'key' to add to every renewed Certificate. Annotations without a value are set to the time of renewal in RFC3339 format. May be specified multiple times.")
+	cmd.Flags().BoolVar(&o.PrintAge, "print-age", o.PrintAge, "If true, print the age and remaining lifetime of the current certificate of every Certificate and ask for confirmation before renewing them.")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", o.Yes, "If true, do not ask for confirmation before renewing when --print-age is specified.")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -112,10 +192,38 @@ func (o *Options) Validate(cmd *cobra.Command, args []string) error {
 		return errors.New("cannot specify --namespace flag in conjunction with --all flag")
 	}
 
-	if !o.All && len(args) == 0 {
+	if len(o.Issuer) > 0 && len(args) > 0 {
+		return errors.New("cannot specify Certificate names in conjunction with --issuer flag")
+	}
+
+	if len(o.Issuer) == 0 && (cmd.Flags().Changed("issuer-kind") || cmd.Flags().Changed("issuer-group")) {
+		return errors.New("the --issuer-kind and --issuer-group flags must be used in conjunction with --issuer")
+	}
+
+	if len(o.Issuer) > 0 && (len(o.IssuerKind) == 0 || len(o.IssuerGroup) == 0) {
+		return errors.New("the --issuer-kind and --issuer-group flags must not be empty")
+	}
+
+	if !o.All && len(o.LabelSelector) == 0 && len(o.Issuer) == 0 && len(args) == 0 {
 		return errors.New("please supply one or more Certificate resource names or use the --all flag to renew all Certificate resources")
 	}
 
+	if len(o.Exclude) > 0 && !o.All && len(o.LabelSelector) == 0 && len(o.Issuer) == 0 {
+		return errors.New("the --exclude flag can only be used in conjunction with --selector, --all or --issuer")
+	}
+
+	if _, err := o.excludeSelector(); err != nil {
+		return err
+	}
+
+	if o.Yes && !o.PrintAge {
+		return errors.New("the --yes flag must be used in conjunction with --print-age")
+	}
+
+	if _, _, err := o.renewedMetadata(time.Time{}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -160,9 +268,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	}
 
 	var crts []cmapi.Certificate
+	// Named Certificates which don't exist, by namespace. No Certificate is
+	// renewed if any is missing.
+	var missing []string
 	for _, ns := range nss {
 		switch {
-		case o.All, len(o.LabelSelector) > 0:
+		case o.All, len(o.LabelSelector) > 0, len(o.Issuer) > 0:
 			crtsList, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).List(ctx, metav1.ListOptions{
 				LabelSelector: o.LabelSelector,
 			})
@@ -175,8 +286,12 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		default:
 			for _, crtName := range args {
 				crt, err := o.CMClient.CertmanagerV1().Certificates(ns.Name).Get(ctx, crtName, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					missing = append(missing, ns.Name+"/"+crtName)
+					continue
+				}
 				if err != nil {
-					return err
+					return fmt.Errorf("error when getting Certificate %s/%s: %w", ns.Name, crtName, err)
 				}
 
 				crts = append(crts, *crt)
@@ -184,6 +299,26 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		}
 	}
 
+	if len(missing) > 0 {
+		return fmt.Errorf("Certificate(s) %s not found, no Certificates were renewed", strings.Join(missing, ", "))
+	}
+
+	if len(o.Issuer) > 0 {
+		var skipped int
+		crts, skipped = o.issuerCertificates(crts)
+		fmt.Fprintf(o.Out, "Matched %d Certificate(s) issued by %s, skipped %d\n", len(crts), o.issuerString(), skipped)
+	}
+
+	if len(o.Exclude) > 0 {
+		selector, err := o.excludeSelector()
+		if err != nil {
+			return err
+		}
+		var excluded int
+		crts, excluded = excludeCertificates(crts, selector)
+		fmt.Fprintf(o.Out, "Excluded %d Certificate(s) matching %q\n", excluded, o.Exclude)
+	}
+
 	if len(crts) == 0 {
 		if o.AllNamespaces {
 			fmt.Fprintln(o.ErrOut, "No Certificates found")
@@ -194,8 +329,25 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return nil
 	}
 
+	if o.PrintAge {
+		headers, rows := o.ageTable(ctx, crts)
+		if err := util.WriteTable(o.Out, headers, rows); err != nil {
+			return err
+		}
+
+		if !o.Yes && !prompt.Confirm(o.In, o.Out, "Do you want to continue?") {
+			fmt.Fprintln(o.Out, "No Certificates were renewed.")
+			return nil
+		}
+	}
+
+	labels, annotations, err := o.renewedMetadata(clock.Now())
+	if err != nil {
+		return err
+	}
+
 	for _, crt := range crts {
-		if err := o.renewCertificate(ctx, &crt); err != nil {
+		if err := o.renewCertificate(ctx, &crt, labels, annotations); err != nil {
 			return err
 		}
 	}
@@ -203,12 +355,140 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	return nil
 }
 
-func (o *Options) renewCertificate(ctx context.Context, crt *cmapi.Certificate) error {
+func (o *Options) renewCertificate(ctx context.Context, crt *cmapi.Certificate, labels, annotations map[string]string) error {
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "ManuallyTriggered", "Certificate re-issuance manually triggered")
 	_, err := o.CMClient.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to trigger issuance of Certificate %s/%s: %v", crt.Namespace, crt.Name, err)
 	}
+	// The Certificate is only marked once its renewal has been triggered, so
+	// the marks can be relied upon to find the renewed Certificates.
+	if len(labels) > 0 || len(annotations) > 0 {
+		patch, err := renewedMetadataPatch(labels, annotations)
+		if err != nil {
+			return err
+		}
+		_, err = o.CMClient.CertmanagerV1().Certificates(crt.Namespace).Patch(ctx, crt.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to mark Certificate %s/%s as renewed: %v", crt.Namespace, crt.Name, err)
+		}
+	}
 	fmt.Fprintf(o.Out, "Manually triggered issuance of Certificate %s/%s\n", crt.Namespace, crt.Name)
 	return nil
 }
+
+// ageTable returns the headers and rows of a table listing the age and
+// remaining lifetime of the certificate currently stored in the Secret of
+// every Certificate. Certificates without a valid certificate in their Secret
+// are listed as well, so that it is clear which of them will be renewed.
+func (o *Options) ageTable(ctx context.Context, crts []cmapi.Certificate) ([]string, [][]string) {
+	headers := []string{"NAMESPACE", "NAME", "SECRET", "AGE", "REMAINING"}
+
+	now := clock.Now()
+	var rows [][]string
+	for _, crt := range crts {
+		age, remaining := ageOfCertificate(ctx, o.KubeClient, &crt, now)
+		rows = append(rows, []string{crt.Namespace, crt.Name, crt.Spec.SecretName, age, remaining})
+	}
+	return headers, rows
+}
+
+// ageOfCertificate returns the human readable age and remaining lifetime of
+// the certificate stored in the Secret of crt.
+func ageOfCertificate(ctx context.Context, kubeClient kubernetes.Interface, crt *cmapi.Certificate, now time.Time) (string, string) {
+	s, err := kubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "<no secret>", "<unknown>"
+	}
+	if err != nil {
+		return "<unknown>", "<unknown>"
+	}
+	if len(s.Data[corev1.TLSCertKey]) == 0 {
+		return "<not issued>", "<unknown>"
+	}
+
+	cert, err := secret.LeafCertificate(s.Data[corev1.TLSCertKey])
+	if err != nil {
+		return "<invalid>", "<unknown>"
+	}
+
+	age := duration.HumanDuration(now.Sub(cert.NotBefore))
+	remaining := "expired"
+	if now.Before(cert.NotAfter) {
+		remaining = duration.HumanDuration(cert.NotAfter.Sub(now))
+	}
+	return age, remaining
+}
+
+// excludeSelector parses the --exclude label selector. If --exclude is not
+// set, the selector matches no Certificate.
+func (o *Options) excludeSelector() (labels.Selector, error) {
+	if len(o.Exclude) == 0 {
+		return labels.Nothing(), nil
+	}
+	selector, err := labels.Parse(o.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --exclude %q: %w", o.Exclude, err)
+	}
+	return selector, nil
+}
+
+// excludeCertificates returns the Certificates whose labels don't match
+// selector, and the number of Certificates which were excluded. The selector
+// is evaluated on the listed Certificates, rather than by the API server, as
+// label selectors cannot express the negation of a whole selector.
+func excludeCertificates(crts []cmapi.Certificate, selector labels.Selector) ([]cmapi.Certificate, int) {
+	var kept []cmapi.Certificate
+	for _, crt := range crts {
+		if !selector.Matches(labels.Set(crt.Labels)) {
+			kept = append(kept, crt)
+		}
+	}
+	return kept, len(crts) - len(kept)
+}
+
+// renewedMetadata parses the --label-renewed and --annotate-renewed flags into
+// the labels and annotations to add to every renewed Certificate. Annotations
+// without a value are set to now.
+func (o *Options) renewedMetadata(now time.Time) (map[string]string, map[string]string, error) {
+	labels := make(map[string]string)
+	for _, label := range o.LabelRenewed {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid --label-renewed %q: must be in the form 'key=value'", label)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid --label-renewed %q: %s", label, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid --label-renewed %q: %s", label, strings.Join(errs, "; "))
+		}
+		labels[key] = value
+	}
+
+	annotations := make(map[string]string)
+	for _, annotation := range o.AnnotateRenewed {
+		key, value, ok := strings.Cut(annotation, "=")
+		if !ok {
+			value = now.UTC().Format(time.RFC3339)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid --annotate-renewed %q: %s", annotation, strings.Join(errs, "; "))
+		}
+		annotations[key] = value
+	}
+
+	return labels, annotations, nil
+}
+
+// renewedMetadataPatch returns a merge patch adding labels and annotations to
+// a Certificate, leaving its other labels and annotations untouched.
+func renewedMetadataPatch(labels, annotations map[string]string) ([]byte, error) {
+	type metadata struct {
+		Labels      map[string]string `json:"labels,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+	return json.Marshal(struct {
+		Metadata metadata `json:"metadata"`
+	}{metadata{Labels: labels, Annotations: annotations}})
+}
@@ -18,9 +18,22 @@ package renew
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 type stringFlag struct {
@@ -87,6 +100,108 @@ func TestValidate(t *testing.T) {
 			},
 			expErr: false,
 		},
+		"If --label-renewed is not in the form key=value, error": {
+			options: &Options{
+				All:          true,
+				LabelRenewed: []string{"rotation"},
+			},
+			expErr: true,
+		},
+		"If --label-renewed has an invalid value, error": {
+			options: &Options{
+				All:          true,
+				LabelRenewed: []string{"rotation=not a label value"},
+			},
+			expErr: true,
+		},
+		"If --annotate-renewed has an invalid key, error": {
+			options: &Options{
+				All:             true,
+				AnnotateRenewed: []string{"not/a/key=foo"},
+			},
+			expErr: true,
+		},
+		"If --label-renewed and --annotate-renewed are valid, don't error": {
+			options: &Options{
+				All:             true,
+				LabelRenewed:    []string{"cert-manager.io/last-renewed-by=alice"},
+				AnnotateRenewed: []string{"example.com/renewed-at", "example.com/reason=rotation campaign"},
+			},
+			expErr: false,
+		},
+		"If --yes specified without --print-age, error": {
+			options: &Options{
+				All: true,
+				Yes: true,
+			},
+			expErr: true,
+		},
+		"If --yes specified with --print-age, don't error": {
+			options: &Options{
+				All:      true,
+				PrintAge: true,
+				Yes:      true,
+			},
+			expErr: false,
+		},
+		"If a label selector is specified without arguments, don't error": {
+			options: &Options{
+				LabelSelector: "foo=bar",
+			},
+			expErr: false,
+		},
+		"If --exclude specified without label selector or --all, error": {
+			options: &Options{
+				Exclude: "env=prod",
+			},
+			args:   []string{"abc"},
+			expErr: true,
+		},
+		"If --exclude is not a valid label selector, error": {
+			options: &Options{
+				All:     true,
+				Exclude: "env in prod",
+			},
+			expErr: true,
+		},
+		"If --issuer specified with Certificate names, error": {
+			options: &Options{
+				Issuer: "my-ca", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io",
+			},
+			args:   []string{"abc"},
+			expErr: true,
+		},
+		"If --issuer-kind specified without --issuer, error": {
+			options:        &Options{All: true},
+			setStringFlags: []stringFlag{{name: "issuer-kind", value: "ClusterIssuer"}},
+			expErr:         true,
+		},
+		"If --issuer specified with an empty --issuer-kind, error": {
+			options: &Options{
+				Issuer: "my-ca", IssuerGroup: "cert-manager.io",
+			},
+			expErr: true,
+		},
+		"If --issuer specified without names, label selector or --all, don't error": {
+			options: &Options{
+				Issuer: "my-ca", IssuerKind: "ClusterIssuer", IssuerGroup: "cert-manager.io",
+			},
+			setStringFlags: []stringFlag{{name: "namespace", value: "foo"}},
+			expErr:         false,
+		},
+		"If --exclude specified with --issuer, don't error": {
+			options: &Options{
+				Issuer: "my-ca", IssuerKind: "Issuer", IssuerGroup: "cert-manager.io", Exclude: "env=prod",
+			},
+			expErr: false,
+		},
+		"If --exclude specified with label selector, don't error": {
+			options: &Options{
+				LabelSelector: "app=web",
+				Exclude:       "env in (prod,preprod)",
+			},
+			expErr: false,
+		},
 		"If --namespace specified with multiple arguments, don't error": {
 			options: &Options{},
 			args:    []string{"bar", "abc"},
@@ -108,7 +223,11 @@ func TestValidate(t *testing.T) {
 
 			if test.setStringFlags != nil {
 				for _, s := range test.setStringFlags {
-					if err := cmd.PersistentFlags().Set(s.name, s.value); err != nil {
+					flags := cmd.PersistentFlags()
+					if flags.Lookup(s.name) == nil {
+						flags = cmd.Flags()
+					}
+					if err := flags.Set(s.name, s.value); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -122,3 +241,209 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRenewedMetadata(t *testing.T) {
+	now := time.Date(2022, 9, 16, 9, 26, 18, 0, time.UTC)
+	o := &Options{
+		LabelRenewed:    []string{"cert-manager.io/last-renewed-by=alice", "rotation=2022-q3"},
+		AnnotateRenewed: []string{"example.com/renewed-at", "example.com/reason=rotation campaign"},
+	}
+
+	labels, annotations, err := o.renewedMetadata(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expLabels := map[string]string{"cert-manager.io/last-renewed-by": "alice", "rotation": "2022-q3"}
+	if !reflect.DeepEqual(labels, expLabels) {
+		t.Errorf("Unexpected labels; expected: %v, actual: %v", expLabels, labels)
+	}
+	expAnnotations := map[string]string{"example.com/renewed-at": "2022-09-16T09:26:18Z", "example.com/reason": "rotation campaign"}
+	if !reflect.DeepEqual(annotations, expAnnotations) {
+		t.Errorf("Unexpected annotations; expected: %v, actual: %v", expAnnotations, annotations)
+	}
+
+	patch, err := renewedMetadataPatch(labels, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expPatch := `{"metadata":{"labels":{"cert-manager.io/last-renewed-by":"alice","rotation":"2022-q3"}}}`
+	if string(patch) != expPatch {
+		t.Errorf("Unexpected patch; expected: %s, actual: %s", expPatch, patch)
+	}
+}
+
+func TestRunPrintAge(t *testing.T) {
+	const ns = "test-ns"
+	now := time.Date(2022, 9, 16, 0, 0, 0, 0, time.UTC)
+
+	realClock := clock
+	clock = fakeclock.NewFakeClock(now)
+	defer func() { clock = realClock }()
+
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(gen.Certificate("test-crt", gen.SetCertificateCommonName("example.com")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.NotBefore = now.Add(-2 * time.Hour)
+	template.NotAfter = now.Add(90 * 24 * time.Hour)
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "fresh-secret", Namespace: ns},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+	}
+	freshCrt := gen.Certificate("fresh-crt", gen.SetCertificateNamespace(ns), gen.SetCertificateSecretName("fresh-secret"))
+	missingCrt := gen.Certificate("missing-crt", gen.SetCertificateNamespace(ns), gen.SetCertificateSecretName("missing-secret"))
+
+	tests := map[string]struct {
+		yes        bool
+		input      string
+		expOutput  string
+		expRenewed bool
+	}{
+		"renewal is not triggered without confirmation": {
+			input: "n\n",
+			expOutput: `NAMESPACE  NAME         SECRET          AGE          REMAINING
+test-ns    fresh-crt    fresh-secret    120m         90d
+test-ns    missing-crt  missing-secret  <no secret>  <unknown>
+Do you want to continue? [y/N]: No Certificates were renewed.
+`,
+		},
+		"renewal is triggered after confirmation": {
+			input: "y\n",
+			expOutput: `NAMESPACE  NAME         SECRET          AGE          REMAINING
+test-ns    fresh-crt    fresh-secret    120m         90d
+test-ns    missing-crt  missing-secret  <no secret>  <unknown>
+Do you want to continue? [y/N]: Manually triggered issuance of Certificate test-ns/fresh-crt
+Manually triggered issuance of Certificate test-ns/missing-crt
+`,
+			expRenewed: true,
+		},
+		"renewal is triggered without asking with --yes": {
+			yes: true,
+			expOutput: `NAMESPACE  NAME         SECRET          AGE          REMAINING
+test-ns    fresh-crt    fresh-secret    120m         90d
+test-ns    missing-crt  missing-secret  <no secret>  <unknown>
+Manually triggered issuance of Certificate test-ns/fresh-crt
+Manually triggered issuance of Certificate test-ns/missing-crt
+`,
+			expRenewed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.input)
+
+			cmClient := cmfake.NewSimpleClientset(freshCrt, missingCrt)
+			opts := NewOptions(streams)
+			opts.PrintAge = true
+			opts.Yes = test.yes
+			opts.Factory = &factory.Factory{
+				Namespace:  ns,
+				CMClient:   cmClient,
+				KubeClient: kubefake.NewSimpleClientset(fresh),
+			}
+
+			if err := opts.Run(context.TODO(), []string{"fresh-crt", "missing-crt"}); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+
+			crt, err := cmClient.CertmanagerV1().Certificates(ns).Get(context.TODO(), "fresh-crt", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			renewed := false
+			for _, cond := range crt.Status.Conditions {
+				if cond.Type == cmapi.CertificateConditionIssuing && strings.Contains(cond.Reason, "ManuallyTriggered") {
+					renewed = true
+				}
+			}
+			if renewed != test.expRenewed {
+				t.Errorf("Unexpected renewal; expected: %t, actual: %t", test.expRenewed, renewed)
+			}
+		})
+	}
+}
+
+func TestRunExclude(t *testing.T) {
+	const ns = "test-ns"
+	stagingCrt := gen.Certificate("staging-crt", gen.SetCertificateNamespace(ns), gen.AddCertificateLabels(map[string]string{"app": "web", "env": "staging"}))
+	prodCrt := gen.Certificate("prod-crt", gen.SetCertificateNamespace(ns), gen.AddCertificateLabels(map[string]string{"app": "web", "env": "prod"}))
+	otherCrt := gen.Certificate("other-crt", gen.SetCertificateNamespace(ns), gen.AddCertificateLabels(map[string]string{"app": "api", "env": "staging"}))
+
+	tests := map[string]struct {
+		options    *Options
+		expOutput  string
+		expRenewed []string
+	}{
+		"Certificates matching --exclude are not renewed": {
+			options: &Options{LabelSelector: "app=web", Exclude: "env=prod"},
+			expOutput: `Excluded 1 Certificate(s) matching "env=prod"
+Manually triggered issuance of Certificate test-ns/staging-crt
+`,
+			expRenewed: []string{"staging-crt"},
+		},
+		"--exclude is applied to the Certificates selected by --all": {
+			options: &Options{All: true, Exclude: "app=web"},
+			expOutput: `Excluded 2 Certificate(s) matching "app=web"
+Manually triggered issuance of Certificate test-ns/other-crt
+`,
+			expRenewed: []string{"other-crt"},
+		},
+		"nothing is renewed if all Certificates are excluded": {
+			options: &Options{LabelSelector: "app=web", Exclude: "env"},
+			expOutput: `Excluded 2 Certificate(s) matching "env"
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			cmClient := cmfake.NewSimpleClientset(stagingCrt, prodCrt, otherCrt)
+			opts := test.options
+			opts.IOStreams = streams
+			opts.Factory = &factory.Factory{
+				Namespace: ns,
+				CMClient:  cmClient,
+			}
+
+			if err := opts.Run(context.TODO(), nil); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+
+			crts, err := cmClient.CertmanagerV1().Certificates(ns).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var renewed []string
+			for _, crt := range crts.Items {
+				for _, cond := range crt.Status.Conditions {
+					if cond.Type == cmapi.CertificateConditionIssuing && strings.Contains(cond.Reason, "ManuallyTriggered") {
+						renewed = append(renewed, crt.Name)
+					}
+				}
+			}
+			if !reflect.DeepEqual(renewed, test.expRenewed) {
+				t.Errorf("Unexpected renewed Certificates; expected: %v, actual: %v", test.expRenewed, renewed)
+			}
+		})
+	}
+}
@@ -20,13 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/tools/reference"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -34,7 +35,9 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/cert-manager/cert-manager/pkg/ctl"
@@ -43,16 +46,234 @@ import (
 
 var (
 	long = templates.LongDesc(i18n.T(`
-Get details about the current status of a cert-manager Certificate resource, including information on related resources like CertificateRequest or Order.`))
+Get details about the current status of a cert-manager Certificate resource, including information on related resources like CertificateRequest or Order.
+
+For Certificates issued by an ACME Issuer, the chain of resources created for the issuance is followed from the current
+CertificateRequest to its Order and the Challenges of the Order, which are printed nested below each other. Every
+Challenge is printed with its state, reason, solver type, token and key.
+
+When used with the --selector, --all or --all-namespaces flags, a table summarizing the status of all matching Certificates is printed instead.
+Unless it is rendered by the API server or printed with --as-table, the table is followed by a line counting the
+Certificates, e.g. '24 certificates: 20 Ready, 3 not Ready, 1 expiring soon',
+where Certificates expiring within --expiring-within count as expiring soon.
+With --server-print, the columns of the table are rendered by the API server, the same way as for 'kubectl get certificates'.
+If the API server cannot render the table, the table is rendered by cmctl as without --server-print.
+With --as-table, the table is printed as a JSON encoded meta.k8s.io/v1 Table object with the same columns,
+the format in which the API server renders tables for kubectl, so that it can be rendered by generic tools.
+
+With --filename, the status of a Certificate exported to a file is printed without connecting to a cluster, e.g. to
+analyse a Certificate exported from another cluster. The Certificate may be given in any version supported by the
+convert command, a Certificate which does not declare its apiVersion is read in the version given by --assume-version.
+Related resources are not looked up, so only the status reported by the Certificate itself is printed.
+
+With --json-schema-report, the Certificate is validated against the OpenAPI schema of the Certificate
+CustomResourceDefinition installed in the cluster. Values which don't match the schema, e.g. because the schema was
+tightened after the Certificate was stored, fields which are not part of the schema and deprecated fields or versions
+are listed after the status.
+
+Every certificate of the chain stored in tls.crt of the Secret is listed with its Not After time and the time remaining,
+and the soonest expiry across the chain is printed as 'Chain Expires In', to catch intermediates which expire before
+the leaf certificate.
+
+With --highlight-expiry, the Not After time of a single Certificate is printed in green, in yellow if the Certificate
+expires within --warn-within, and in red if it expires within --critical-within or has expired. If the output is not
+colorized, [WARN] and [CRIT] markers are appended instead.
+
+The statuses of conditions, the states of Orders and Challenges and the types of events are colorized according to
+--color: True, valid and Normal in green, False, failed states and Warning in red, and Unknown and pending states in
+yellow. By default, the output is only colorized if it is written to a terminal and NO_COLOR is not set.
+
+With --watch, the status of a single Certificate is printed again every --watch-interval, including the related
+resources, until the Certificate is Ready. On a terminal the previous status is cleared first. Watching fails once
+--timeout is reached before the Certificate is Ready, and stops without an error if interrupted, e.g. with Ctrl-C.
+
+With --since-last-transition, the time since the last transition of the Ready condition is printed below the
+conditions, e.g. 'Ready for 3d4h' or 'Not Ready for 5m', to tell whether a Certificate just broke or has been broken
+for a while.
+
+With --include-issuer, the Ready condition of the Issuer or ClusterIssuer referenced by the Certificate, or the error
+resolving it, is summarized after the status, so that an unhealthy issuer stands out as the cause of a stuck
+Certificate.
+
+With --export-events-csv, the events of the Certificate, its Issuer, Secret and CertificateRequest are additionally
+written to a CSV file, merged in the order they were last seen, e.g. to analyse an incident in a spreadsheet. The
+columns are source, type, reason, firstSeen, lastSeen, count and message.
+
+With --profile, the time taken by each API call made to gather the Certificate and its related resources, e.g. getting
+the Certificate, listing its CertificateRequests, Orders and Challenges and searching their events, is printed to
+stderr along with the total, to find the call which makes the command slow against a big cluster. There is no
+discovery phase to profile: status uses the typed clients of cert-manager and Kubernetes, which make no discovery calls.
+
+The status of a single Certificate can be printed as JSON or YAML with --output json or --output yaml, e.g. to be
+processed by scripts, or using a go-template or a jsonpath expression with --output.
+With --output dot, the Certificate, its CertificateRequest, Order and Challenges are printed as a Graphviz DOT graph
+instead, with the state of every resource in its node and edges pointing from owners to the resources they own. The
+graph can be rendered, e.g., with 'dot -Tpng'.
+The following fields are printed, and can be addressed by templates, e.g. '{.notAfter}':
+  name, namespace, creationTime, labels, annotations, conditions, dnsNames, events, notBefore, notAfter, renewalTime,
+  chainNotAfter, the soonest Not After time of the certificates in the Secret,
+  spec: secretName, issuerRef, dnsNames, duration and renewBefore of the Certificate,
+  issuer: name, kind, generation, observedGeneration, stale, conditions and events of the Issuer,
+  secret: name, issuerCommonName, issuerOrganisation, issuerCountry, keyUsage, extKeyUsage, publicKeyAlgorithm,
+    signatureAlgorithm, subjectKeyId, authorityKeyId, serialNumber and events of the Secret and its certificate,
+    privateKeyType of its private key and privateKeyMismatch, describing how it differs from spec.privateKey,
+    chain: subject and notAfter of every certificate in tls.crt, the leaf certificate first,
+  certificateRequest: name, namespace, conditions, events and, with --show-csr, csr of the CertificateRequest,
+  order: name, state, reason, authorizations and failureTime of the ACME Order,
+  challenges.items: name, type, solver, token, key, state, reason, processing and presented of every ACME Challenge,
+  schemaReport: version and, for every finding of --json-schema-report, the type, field and message in findings.
+Fields that could not be determined are replaced by an error field, e.g. '{.issuer.error}'.`))
 
 	example = templates.Examples(i18n.T(build.WithTemplate(`
 # Query status of Certificate with name 'my-crt' in namespace 'my-namespace'
 {{.BuildName}} status certificate my-crt --namespace my-namespace
+
+# List the status of all Certificates in namespace 'my-namespace' as a table
+{{.BuildName}} status certificate --all --namespace my-namespace
+
+# List all Certificates in namespace 'my-namespace' with the columns printed by 'kubectl get certificates'
+{{.BuildName}} status certificate --all --namespace my-namespace --server-print
+
+# List the status of all Certificates in namespace 'my-namespace', counting the ones expiring within 14 days as expiring soon
+{{.BuildName}} status certificate --all --namespace my-namespace --expiring-within 336h
+
+# List the status of all Certificates in namespace 'my-namespace' as a JSON encoded meta.k8s.io/v1 Table
+{{.BuildName}} status certificate --all --namespace my-namespace --as-table
+
+# List the status of all Certificates in all namespaces with the label 'app=my-service', showing the 'team' label as a column
+{{.BuildName}} status certificate --all-namespaces -l app=my-service -L team
+
+# Print the Ready condition of Certificate 'my-crt' using a go-template, failing if the template references a missing field
+{{.BuildName}} status certificate my-crt -o go-template='{{"{{"}}range .conditions{{"}}"}}{{"{{"}}if eq .type "Ready"{{"}}"}}{{"{{"}}.status{{"}}"}}{{"{{"}}end{{"}}"}}{{"{{"}}end{{"}}"}}' --allow-missing-template-keys=false
+
+# Print the status of Certificate 'my-crt' as JSON
+{{.BuildName}} status certificate my-crt -o json
+
+# Print the status of the Ready condition of Certificate 'my-crt' using a jsonpath expression
+{{.BuildName}} status certificate my-crt -o jsonpath='{.conditions[?(@.type=="Ready")].status}'
+
+# Render Certificate 'my-crt' and the resources created to issue it as a PNG image
+{{.BuildName}} status certificate my-crt -o dot | dot -Tpng > my-crt.png
+
+# Query status of Certificate 'my-crt', including a summary of the CSR of its active CertificateRequest
+{{.BuildName}} status certificate my-crt --show-csr
+
+# Wait up to 10 minutes for Certificate 'my-crt' to become Ready, then print the details of the issued certificate
+{{.BuildName}} status certificate my-crt --wait-ready --then-inspect --timeout 10m
+
+# Print the status of Certificate 'my-crt' every 5 seconds until it is Ready
+{{.BuildName}} status certificate my-crt --watch --watch-interval 5s
+
+# Print the expected TXT records of the DNS-01 Challenges of Certificate 'my-crt', comparing them with the records served by its nameservers
+{{.BuildName}} status certificate my-crt --diagnose-dns01 --resolve
+
+# Print where the '_acme-challenge' CNAMEs of the DNS-01 Challenges of Certificate 'my-crt' point to, to check the delegation of DNS-01 Challenges to another zone
+{{.BuildName}} status certificate my-crt --diagnose-dns01 --resolve-cname
+
+# Print the status of the Certificate exported to 'my-crt.yaml' in version v1alpha2, without connecting to a cluster
+{{.BuildName}} status certificate -f my-crt.yaml --assume-version cert-manager.io/v1alpha2
+
+# Query status of Certificate 'my-crt', flagging its expiry if it expires within 14 days, or within 3 days as critical
+{{.BuildName}} status certificate my-crt --highlight-expiry --warn-within 336h --critical-within 72h
+
+# Query status of Certificate 'my-crt', printing how long it has been in its current Ready state
+{{.BuildName}} status certificate my-crt --since-last-transition
+
+# Query status of Certificate 'my-crt', summarizing the health of its Issuer or ClusterIssuer at the end
+{{.BuildName}} status certificate my-crt --include-issuer
+
+# Query status of Certificate 'my-crt', writing the events of the Certificate and its related resources to 'my-crt-events.csv'
+{{.BuildName}} status certificate my-crt --export-events-csv my-crt-events.csv
+
+# Query status of Certificate 'my-crt', printing how long each API call took to stderr
+{{.BuildName}} status certificate my-crt --profile
+
+# Query status of Certificate 'my-crt', validating it against the schema of the installed CustomResourceDefinition
+{{.BuildName}} status certificate my-crt --json-schema-report
+
+# Print Prometheus gauges for the readiness, expiry and renewal of all Certificates in namespace 'my-namespace'
+{{.BuildName}} status certificate --all --namespace my-namespace --metrics
 `)))
 )
 
 // Options is a struct to support status certificate command
 type Options struct {
+	LabelSelector string
+	All           bool
+	AllNamespaces bool
+	// If true, print the status as Prometheus gauges instead
+	Metrics bool
+	// If true, request the table listing the Certificates from the API server
+	ServerPrint bool
+	// If true, print the table listing the Certificates as a meta.k8s.io/v1
+	// Table object
+	AsTable bool
+	// Certificates expiring within ExpiringWithin are counted as expiring
+	// soon in the summary printed after the table listing the Certificates
+	ExpiringWithin time.Duration
+	// If true, a summary of the CSR of the CertificateRequest is printed
+	ShowCSR bool
+	// If true, wait for the Certificate to become Ready before printing its status
+	WaitReady bool
+	// If true, print the details of the certificate in the Secret instead of
+	// the status once the Certificate is Ready
+	ThenInspect bool
+	// If true, print a diagnosis of the DNS-01 Challenges instead of the status
+	DiagnoseDNS01 bool
+	// If true, look up the TXT records of the DNS-01 Challenges
+	Resolve bool
+	// If true, follow the CNAME chain of the DNS-01 Challenges to the name
+	// the TXT record has to be written to
+	ResolveCNAME bool
+	// Recursive nameservers used to look up the TXT records, in addition to
+	// the authoritative nameservers, and to find the zones and follow the
+	// CNAMEs of the DNS-01 Challenges
+	DNS01Nameservers []string
+	// If true, print the status again every WatchInterval until the
+	// Certificate is Ready
+	Watch         bool
+	WatchInterval time.Duration
+	// Length of time to wait for the Certificate to become Ready
+	Timeout time.Duration
+	// Output format of the status of a single Certificate, if not set
+	// the human readable description is printed
+	Output string
+	// If true, highlight the Not After time depending on how soon the
+	// Certificate expires
+	HighlightExpiry bool
+	// Certificates expiring within WarnWithin are highlighted as a warning
+	WarnWithin time.Duration
+	// Certificates expiring within CriticalWithin are highlighted as critical
+	CriticalWithin time.Duration
+	// If true, print how long the Certificate has been in its current Ready
+	// state
+	SinceLastTransition bool
+	// If true, print a summary of the health of the Issuer/ClusterIssuer of
+	// the Certificate after its status
+	IncludeIssuer bool
+	// File the Certificate is read from instead of the cluster
+	Filename string
+	// The apiVersion assumed for a Certificate read from Filename which
+	// doesn't declare one
+	AssumeVersion string
+	// If true, validate the Certificate against the schema of the installed
+	// CustomResourceDefinition and report the findings
+	SchemaReport bool
+	// File the events of the Certificate and its related resources are
+	// written to as CSV, in addition to printing the status
+	ExportEventsCSV string
+	// If true, print how long the API calls gathering the resources of the
+	// Certificate took to stderr
+	Profile bool
+
+	// profile records the durations printed by Profile
+	profile *profile
+
+	TemplateFlags *genericclioptions.KubeTemplatePrintFlags
+	Printer       printers.ResourcePrinter
+
+	util.LabelColumnOptions
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -75,12 +296,14 @@ type Data struct {
 	OrderError   error
 	Challenges   []*cmacme.Challenge
 	ChallengeErr error
+	SchemaReport *SchemaReport
 }
 
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		IOStreams:     ioStreams,
+		TemplateFlags: genericclioptions.NewKubeTemplatePrintFlags(),
 	}
 }
 
@@ -96,17 +319,206 @@ func NewCmdStatusCert(ctx context.Context, ioStreams genericclioptions.IOStreams
 		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Complete())
 			cmdutil.CheckErr(o.Run(ctx, args))
 		},
 	}
 
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) to filter on when listing Certificates, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, list the status of Certificates across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "List the status of all Certificates in the given Namespace, or all namespaces with --all-namespaces enabled.")
+	cmd.Flags().BoolVar(&o.Metrics, "metrics", o.Metrics, "If present, print Prometheus gauges for the readiness, seconds until expiry and seconds until renewal of the Certificates instead.")
+	cmd.Flags().BoolVar(&o.ServerPrint, "server-print", o.ServerPrint, "If true, have the API server render the table when listing Certificates, falling back to rendering it client-side if the API server does not support it.")
+	cmd.Flags().DurationVar(&o.ExpiringWithin, "expiring-within", 30*24*time.Hour, "Count Certificates expiring within the given duration as expiring soon in the summary printed after the table listing Certificates.")
+	cmd.Flags().BoolVar(&o.AsTable, "as-table", o.AsTable, "If true, print the table listing Certificates as a JSON encoded meta.k8s.io/v1 Table object with the same columns.")
+	cmd.Flags().BoolVar(&o.ShowCSR, "show-csr", o.ShowCSR, "If present, decode the CSR of the active CertificateRequest and print a summary of its subject, SANs and key type.")
+	cmd.Flags().BoolVar(&o.WaitReady, "wait-ready", o.WaitReady, "If present, wait for the Certificate to become Ready before printing its status.")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "If present, print the status of the Certificate again every --watch-interval, until the Certificate is Ready or --timeout is reached.")
+	cmd.Flags().DurationVar(&o.WatchInterval, "watch-interval", 2*time.Second, "Time between the updates of the status printed by --watch, must include unit, e.g. 2s or 1m.")
+	cmd.Flags().BoolVar(&o.ThenInspect, "then-inspect", o.ThenInspect, "If present, print the details of the certificate stored in the Secret, as printed by 'inspect secret', once the Certificate is Ready. Must be used in conjunction with --wait-ready.")
+	cmd.Flags().BoolVar(&o.DiagnoseDNS01, "diagnose-dns01", o.DiagnoseDNS01, "If present, print the FQDN and expected TXT value of every DNS-01 Challenge of the Certificate instead of its status.")
+	cmd.Flags().BoolVar(&o.Resolve, "resolve", o.Resolve, "If present, look up the TXT records of the DNS-01 Challenges using their authoritative nameservers and the nameservers given by --dns01-nameservers. The authoritative nameservers are found using the nameservers given by --dns01-nameservers, as done by cert-manager. Must be used in conjunction with --diagnose-dns01.")
+	cmd.Flags().BoolVar(&o.ResolveCNAME, "resolve-cname", o.ResolveCNAME, "If present, follow the CNAME chain of the '_acme-challenge' name of every DNS-01 Challenge as done by cert-manager, using the nameservers given by --dns01-nameservers, and print the name and zone the TXT record has to be written to. Must be used in conjunction with --diagnose-dns01.")
+	cmd.Flags().StringSliceVar(&o.DNS01Nameservers, "dns01-nameservers", []string{"8.8.8.8:53", "1.1.1.1:53"}, "Recursive nameservers used by --resolve and --resolve-cname, in the form host:port.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Time before timeout when waiting for the Certificate to become Ready with --wait-ready or --watch, must include unit, e.g. 10m or 1h.")
+	cmd.Flags().BoolVar(&o.HighlightExpiry, "highlight-expiry", o.HighlightExpiry, "If present, highlight the Not After time in green, yellow or red depending on the --warn-within and --critical-within thresholds. If the output is not colorized, see --color, [WARN] and [CRIT] markers are printed instead.")
+	cmd.Flags().DurationVar(&o.WarnWithin, "warn-within", 30*24*time.Hour, "Highlight the Not After time as a warning if the Certificate expires within the given duration, used with --highlight-expiry.")
+	cmd.Flags().DurationVar(&o.CriticalWithin, "critical-within", 7*24*time.Hour, "Highlight the Not After time as critical if the Certificate expires within the given duration or has expired, used with --highlight-expiry.")
+	cmd.Flags().BoolVar(&o.SinceLastTransition, "since-last-transition", o.SinceLastTransition, "If present, print how long the Certificate has been in its current Ready state, computed from the last transition time of the Ready condition.")
+	cmd.Flags().BoolVar(&o.IncludeIssuer, "include-issuer", o.IncludeIssuer, "If present, print the Ready condition of the Issuer or ClusterIssuer referenced by the Certificate, or the error resolving it, after the status of the Certificate.")
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "Read the Certificate from the given file, or stdin if set to '-', instead of the cluster. The Certificate may be given in any supported version. Related resources are not looked up.")
+	cmd.Flags().StringVar(&o.AssumeVersion, "assume-version", o.AssumeVersion, "The apiVersion of a Certificate read with --filename which does not declare one, e.g. cert-manager.io/v1alpha2.")
+	cmd.Flags().BoolVar(&o.SchemaReport, "json-schema-report", o.SchemaReport, "If present, validate the Certificate against the OpenAPI schema of the installed CustomResourceDefinition and list values which don't match the schema, unknown fields and deprecated fields or versions.")
+	o.LabelColumnOptions.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.ExportEventsCSV, "export-events-csv", o.ExportEventsCSV, "Write the events of the Certificate, its Issuer, Secret and CertificateRequest to the given file as CSV, ordered by the time they were last seen, in addition to printing the status. The columns are source, type, reason, firstSeen, lastSeen, count and message.")
+	cmd.Flags().BoolVar(&o.Profile, "profile", o.Profile, "If present, print how long each API call made to gather the Certificate and its related resources took to stderr, e.g. to find which call makes the command slow.")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format of the status of a single Certificate. One of: (%s).", strings.Join(append(append(structuredFormats, dotFormat), o.TemplateFlags.AllowedFormats()...), ", ")))
+	o.TemplateFlags.AddFlags(cmd)
+
 	o.Factory = factory.New(ctx, cmd)
 
+	// A Certificate read from a file is analysed offline, so don't require
+	// a cluster to be configured.
+	factoryPreRun := cmd.PreRun
+	cmd.PreRun = func(cmd *cobra.Command, args []string) {
+		if len(o.Filename) == 0 {
+			factoryPreRun(cmd, args)
+		}
+	}
+
 	return cmd
 }
 
 // Validate validates the provided options
 func (o *Options) Validate(args []string) error {
+	if o.Metrics && (o.ShowLabels || len(o.LabelColumns) > 0) {
+		return errors.New("the --show-labels and --label-columns flags cannot be used in conjunction with the --metrics flag")
+	}
+
+	if o.Output != "" && (o.Metrics || o.listing()) {
+		return errors.New("the --output flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.ServerPrint && (o.Metrics || !o.listing()) {
+		return errors.New("the --server-print flag can only be used when listing Certificates with the --selector, --all or --all-namespaces flags")
+	}
+
+	if o.ExpiringWithin < 0 {
+		return errors.New("the --expiring-within duration must not be negative")
+	}
+
+	if o.AsTable && (o.Metrics || !o.listing()) {
+		return errors.New("the --as-table flag can only be used when listing Certificates with the --selector, --all or --all-namespaces flags")
+	}
+
+	if o.ShowCSR && (o.Metrics || o.listing()) {
+		return errors.New("the --show-csr flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.WaitReady && (o.Metrics || o.listing()) {
+		return errors.New("the --wait-ready flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.Watch && (o.Metrics || o.listing()) {
+		return errors.New("the --watch flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.Watch && (o.Output != "" || o.WaitReady || o.DiagnoseDNS01 || o.SchemaReport || len(o.Filename) > 0) {
+		return errors.New("the --watch flag cannot be used in conjunction with the --output, --wait-ready, --diagnose-dns01, --json-schema-report or --filename flags")
+	}
+
+	if o.Watch && o.WatchInterval <= 0 {
+		return errors.New("the --watch-interval flag must be greater than 0")
+	}
+
+	if o.DiagnoseDNS01 && (o.Metrics || o.listing()) {
+		return errors.New("the --diagnose-dns01 flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.DiagnoseDNS01 && (o.Output != "" || o.ShowCSR || o.WaitReady) {
+		return errors.New("the --diagnose-dns01 flag cannot be used in conjunction with the --output, --show-csr or --wait-ready flags")
+	}
+
+	if o.HighlightExpiry && (o.Metrics || o.listing()) {
+		return errors.New("the --highlight-expiry flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.HighlightExpiry && (o.Output != "" || o.DiagnoseDNS01 || o.ThenInspect) {
+		return errors.New("the --highlight-expiry flag cannot be used in conjunction with the --output, --diagnose-dns01 or --then-inspect flags")
+	}
+
+	if o.HighlightExpiry && o.CriticalWithin > o.WarnWithin {
+		return errors.New("the --critical-within duration must not be longer than the --warn-within duration")
+	}
+
+	if o.SinceLastTransition && (o.Metrics || o.listing()) {
+		return errors.New("the --since-last-transition flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.SinceLastTransition && (o.Output != "" || o.DiagnoseDNS01 || o.ThenInspect) {
+		return errors.New("the --since-last-transition flag cannot be used in conjunction with the --output, --diagnose-dns01 or --then-inspect flags")
+	}
+
+	if o.IncludeIssuer && (o.Metrics || o.listing()) {
+		return errors.New("the --include-issuer flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.IncludeIssuer && (o.Output != "" || o.DiagnoseDNS01 || len(o.Filename) > 0) {
+		return errors.New("the --include-issuer flag cannot be used in conjunction with the --output, --diagnose-dns01 or --filename flags")
+	}
+
+	if len(o.Filename) > 0 && (o.Metrics || o.listing()) {
+		return errors.New("the --filename flag can only be used when printing the status of a single Certificate")
+	}
+
+	if len(o.Filename) > 0 && (o.WaitReady || o.DiagnoseDNS01 || o.ShowCSR) {
+		return errors.New("the --filename flag cannot be used in conjunction with the --wait-ready, --diagnose-dns01 or --show-csr flags")
+	}
+
+	if o.SchemaReport && (o.Metrics || o.listing()) {
+		return errors.New("the --json-schema-report flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.SchemaReport && (len(o.Filename) > 0 || o.DiagnoseDNS01 || o.ThenInspect) {
+		return errors.New("the --json-schema-report flag cannot be used in conjunction with the --filename, --diagnose-dns01 or --then-inspect flags")
+	}
+
+	if len(o.ExportEventsCSV) > 0 && (o.Metrics || o.listing()) {
+		return errors.New("the --export-events-csv flag can only be used when printing the status of a single Certificate")
+	}
+
+	if len(o.ExportEventsCSV) > 0 && (o.Watch || o.DiagnoseDNS01 || o.ThenInspect || len(o.Filename) > 0) {
+		return errors.New("the --export-events-csv flag cannot be used in conjunction with the --watch, --diagnose-dns01, --then-inspect or --filename flags")
+	}
+
+	if o.Profile && (o.Metrics || o.listing()) {
+		return errors.New("the --profile flag can only be used when printing the status of a single Certificate")
+	}
+
+	if o.Profile && (o.Watch || o.DiagnoseDNS01 || o.ThenInspect || len(o.Filename) > 0) {
+		return errors.New("the --profile flag cannot be used in conjunction with the --watch, --diagnose-dns01, --then-inspect or --filename flags")
+	}
+
+	if len(o.AssumeVersion) > 0 && len(o.Filename) == 0 {
+		return errors.New("the --assume-version flag must be used in conjunction with --filename")
+	}
+
+	if o.Resolve && !o.DiagnoseDNS01 {
+		return errors.New("the --resolve flag must be used in conjunction with --diagnose-dns01")
+	}
+
+	if o.ResolveCNAME && !o.DiagnoseDNS01 {
+		return errors.New("the --resolve-cname flag must be used in conjunction with --diagnose-dns01")
+	}
+
+	if (o.Resolve || o.ResolveCNAME) && len(o.DNS01Nameservers) == 0 {
+		return errors.New("the --resolve and --resolve-cname flags require at least one nameserver given by --dns01-nameservers")
+	}
+
+	if o.ThenInspect && !o.WaitReady {
+		return errors.New("the --then-inspect flag must be used in conjunction with --wait-ready")
+	}
+
+	if o.ThenInspect && (o.Output != "" || o.ShowCSR) {
+		return errors.New("the --then-inspect flag cannot be used in conjunction with the --output or --show-csr flags")
+	}
+
+	if o.listing() {
+		if len(args) > 0 {
+			return errors.New("cannot specify a Certificate name in conjunction with the --selector, --all or --all-namespaces flags")
+		}
+		return nil
+	}
+
+	if o.ShowLabels || len(o.LabelColumns) > 0 {
+		return errors.New("the --show-labels and --label-columns flags can only be used when listing Certificates with the --selector, --all or --all-namespaces flags")
+	}
+	if len(o.Filename) > 0 {
+		if len(args) > 0 {
+			return errors.New("cannot specify a Certificate name in conjunction with the --filename flag")
+		}
+		return nil
+	}
 	if len(args) < 1 {
 		return errors.New("the name of the Certificate has to be provided as argument")
 	}
@@ -116,32 +528,146 @@ func (o *Options) Validate(args []string) error {
 	return nil
 }
 
+// Complete takes the command arguments and infers any remaining options.
+func (o *Options) Complete() error {
+	// As in kubectl, a template given without an output format is rendered
+	// as a go-template.
+	if o.Output == "" && len(*o.TemplateFlags.TemplateArgument) > 0 {
+		o.Output = "go-template"
+	}
+	if o.Output == "" || o.Output == dotFormat || isStructuredFormat(o.Output) {
+		return nil
+	}
+
+	var err error
+	o.Printer, err = o.TemplateFlags.ToPrinter(o.Output)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Run executes status certificate command
 func (o *Options) Run(ctx context.Context, args []string) error {
+	if o.Metrics {
+		return o.runMetrics(ctx, args)
+	}
+	if o.listing() {
+		return o.runList(ctx)
+	}
+
+	if len(o.Filename) > 0 {
+		crt, err := o.readCertificate()
+		if err != nil {
+			return err
+		}
+		return o.printStatus(fileData(crt))
+	}
+
+	if o.DiagnoseDNS01 {
+		return o.runDiagnoseDNS01(ctx, args[0])
+	}
+
+	if o.Watch {
+		return o.runWatch(ctx, args[0])
+	}
+
+	if o.WaitReady {
+		crt, err := o.waitForReady(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if o.ThenInspect {
+			return o.inspectSecret(ctx, crt)
+		}
+	}
+
+	if o.Profile {
+		o.profile = &profile{}
+	}
+
 	data, err := o.GetResources(ctx, args[0])
 	if err != nil {
 		return err
 	}
 
+	if o.SchemaReport {
+		start := clock.Now()
+		data.SchemaReport = o.schemaReport(ctx, data.Certificate)
+		o.profile.record("Get CustomResourceDefinition", start)
+	}
+
+	if o.profile != nil {
+		if err := o.profile.write(o.ErrOut); err != nil {
+			return err
+		}
+	}
+
+	if len(o.ExportEventsCSV) > 0 {
+		if err := o.exportEventsCSV(data); err != nil {
+			return err
+		}
+	}
+
+	return o.printStatus(data)
+}
+
+// printStatus prints the status of the Certificate built from data.
+func (o *Options) printStatus(data *Data) error {
+	if o.Output == dotFormat {
+		return writeDot(o.Out, data)
+	}
+
 	// Build status of Certificate with data gathered
 	status := StatusFromResources(data)
+	if o.ShowCSR {
+		status.withCSR(data.Req)
+	}
+	color := util.ColorEnabled(o.Out)
+	status.withColor(color)
+	status.withOutputWidth(util.OutputWidth(o.Out))
+	if o.HighlightExpiry {
+		status.withExpiryHighlight(&expiryHighlight{
+			warnWithin:     o.WarnWithin,
+			criticalWithin: o.CriticalWithin,
+			color:          color,
+			now:            clock.Now(),
+		})
+	}
+	if o.SinceLastTransition {
+		status.withSinceLastTransition(clock.Now())
+	}
+	if o.IncludeIssuer {
+		status.withIssuerHealth()
+	}
 
-	fmt.Fprintf(o.Out, status.String())
+	if isStructuredFormat(o.Output) {
+		return writeStructured(o.Out, o.Output, status)
+	}
+
+	if o.Printer != nil {
+		obj, err := util.ToUnstructured(status)
+		if err != nil {
+			return fmt.Errorf("error when preparing the status for output: %w", err)
+		}
+		return o.Printer.PrintObj(obj, o.Out)
+	}
+
+	fmt.Fprint(o.Out, status.String())
 
 	return nil
 }
 
 // GetResources collects all related resources of the Certificate and any errors while doing so
 // in a Data struct and returns it.
-// Returns error if error occurs when finding Certificate resource or while preparing to find other resources,
-// e.g. when creating clientSet
+// Returns error if error occurs when finding Certificate resource or while preparing to find other resources
 func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, error) {
-	clientSet, err := kubernetes.NewForConfig(o.RESTConfig)
-	if err != nil {
-		return nil, err
-	}
+	clientSet := o.KubeClient
 
+	start := clock.Now()
 	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, crtName, metav1.GetOptions{})
+	o.profile.record("Get Certificate", start)
 	if err != nil {
 		return nil, fmt.Errorf("error when getting Certificate resource: %v", err)
 	}
@@ -151,12 +677,16 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		return nil, err
 	}
 	// If no events found, crtEvents would be nil and handled down the line in DescribeEvents
+	start = clock.Now()
 	crtEvents, err := clientSet.CoreV1().Events(crt.Namespace).Search(ctl.Scheme, crtRef)
+	o.profile.record("Search Certificate events", start)
 	if err != nil {
 		return nil, err
 	}
 
+	start = clock.Now()
 	issuer, issuerKind, issuerError := getGenericIssuer(o.CMClient, ctx, crt)
+	o.profile.record("Get Issuer", start)
 	var issuerEvents *corev1.EventList
 	if issuer != nil {
 		issuerRef, err := reference.GetReference(ctl.Scheme, issuer)
@@ -164,13 +694,17 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 			return nil, err
 		}
 		// If no events found, issuerEvents would be nil and handled down the line in DescribeEvents
+		start = clock.Now()
 		issuerEvents, err = clientSet.CoreV1().Events(issuer.GetNamespace()).Search(ctl.Scheme, issuerRef)
+		o.profile.record("Search Issuer events", start)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	start = clock.Now()
 	secret, secretErr := clientSet.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	o.profile.record("Get Secret", start)
 	if secretErr != nil {
 		secretErr = fmt.Errorf("error when finding Secret %q: %w\n", crt.Spec.SecretName, secretErr)
 	}
@@ -181,7 +715,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 			return nil, err
 		}
 		// If no events found, secretEvents would be nil and handled down the line in DescribeEvents
+		start = clock.Now()
 		secretEvents, err = clientSet.CoreV1().Events(secret.Namespace).Search(ctl.Scheme, secretRef)
+		o.profile.record("Search Secret events", start)
 		if err != nil {
 			return nil, err
 		}
@@ -189,7 +725,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 
 	// TODO: What about timing issues? When I query condition it's not ready yet, but then looking for cr it's finished and deleted
 	// Try find the CertificateRequest that is owned by crt and has the correct revision
+	start = clock.Now()
 	req, reqErr := findMatchingCR(o.CMClient, ctx, crt)
+	o.profile.record("List CertificateRequests", start)
 	if reqErr != nil {
 		reqErr = fmt.Errorf("error when finding CertificateRequest: %w\n", reqErr)
 	} else if req == nil {
@@ -203,7 +741,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 			return nil, err
 		}
 		// If no events found,  reqEvents would be nil and handled down the line in DescribeEvents
+		start = clock.Now()
 		reqEvents, err = clientSet.CoreV1().Events(req.Namespace).Search(ctl.Scheme, reqRef)
+		o.profile.record("Search CertificateRequest events", start)
 		if err != nil {
 			return nil, err
 		}
@@ -219,7 +759,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 	// Nothing to output about Order and Challenge if no CR or not ACME Issuer
 	if req != nil && issuer != nil && issuer.GetSpec().ACME != nil {
 		// Get Order
+		start = clock.Now()
 		order, orderErr = findMatchingOrder(o.CMClient, ctx, req)
+		o.profile.record("List Orders", start)
 		if orderErr != nil {
 			orderErr = fmt.Errorf("error when finding Order: %w\n", orderErr)
 		} else if order == nil {
@@ -227,7 +769,9 @@ func (o *Options) GetResources(ctx context.Context, crtName string) (*Data, erro
 		}
 
 		if order != nil {
+			start = clock.Now()
 			challenges, challengeErr = findMatchingChallenges(o.CMClient, ctx, order)
+			o.profile.record("List Challenges", start)
 			if challengeErr != nil {
 				challengeErr = fmt.Errorf("error when finding Challenges: %w\n", challengeErr)
 			} else if len(challenges) == 0 {
@@ -263,9 +807,12 @@ func StatusFromResources(data *Data) *CertificateStatus {
 		withEvents(data.CrtEvents).
 		withGenericIssuer(data.Issuer, data.IssuerKind, data.IssuerEvents, data.IssuerError).
 		withSecret(data.Secret, data.SecretEvents, data.SecretError).
+		withPrivateKey(data.Certificate, data.Secret).
+		withChain(data.Secret, clock.Now()).
 		withCR(data.Req, data.ReqEvents, data.ReqError).
 		withOrder(data.Order, data.OrderError).
-		withChallenges(data.Challenges, data.ChallengeErr)
+		withChallenges(data.Challenges, data.ChallengeErr).
+		withSchemaReport(data.SchemaReport)
 }
 
 // formatStringSlice takes in a string slice and formats the contents of the slice
@@ -349,28 +896,28 @@ func findMatchingOrder(cmClient cmclient.Interface, ctx context.Context, req *cm
 }
 
 func getGenericIssuer(cmClient cmclient.Interface, ctx context.Context, crt *cmapi.Certificate) (cmapi.GenericIssuer, string, error) {
-	issuerKind := crt.Spec.IssuerRef.Kind
-	if issuerKind == "" {
-		issuerKind = "Issuer"
-	}
+	ref := util.IssuerRefWithDefaults(crt)
 
-	if crt.Spec.IssuerRef.Group != "cert-manager.io" && crt.Spec.IssuerRef.Group != "" {
+	switch {
+	case ref.Group != certmanager.GroupName:
 		// TODO: Support Issuers/ClusterIssuers from other groups as well
 		return nil, "", fmt.Errorf("The %s %q is not of the group cert-manager.io, this command currently does not support third party issuers.\nTo get more information about %q, try 'kubectl describe'\n",
-			issuerKind, crt.Spec.IssuerRef.Name, crt.Spec.IssuerRef.Name)
-	} else if issuerKind == "Issuer" {
-		issuer, issuerErr := cmClient.CertmanagerV1().Issuers(crt.Namespace).Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+			ref.Kind, ref.Name, ref.Name)
+	case ref.Kind == cmapi.IssuerKind:
+		issuer, issuerErr := cmClient.CertmanagerV1().Issuers(crt.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
 		if issuerErr != nil {
 			issuerErr = fmt.Errorf("error when getting Issuer: %v\n", issuerErr)
 		}
-		return issuer, issuerKind, issuerErr
-	} else {
-		// ClusterIssuer
-		clusterIssuer, issuerErr := cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, crt.Spec.IssuerRef.Name, metav1.GetOptions{})
+		return issuer, ref.Kind, issuerErr
+	case ref.Kind == cmapi.ClusterIssuerKind:
+		clusterIssuer, issuerErr := cmClient.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
 		if issuerErr != nil {
 			issuerErr = fmt.Errorf("error when getting ClusterIssuer: %v\n", issuerErr)
 		}
-		return clusterIssuer, issuerKind, issuerErr
+		return clusterIssuer, ref.Kind, issuerErr
+	default:
+		return nil, "", fmt.Errorf("The issuerRef of the Certificate is of the unknown kind %q, expected %s or %s\n",
+			ref.Kind, cmapi.IssuerKind, cmapi.ClusterIssuerKind)
 	}
 }
 
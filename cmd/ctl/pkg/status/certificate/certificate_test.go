@@ -17,9 +17,17 @@ limitations under the License.
 package certificate
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"math/big"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -27,10 +35,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -116,6 +127,413 @@ func TestCRInfoString(t *testing.T) {
 	}
 }
 
+func TestOrderAndChallengesString(t *testing.T) {
+	tests := map[string]struct {
+		order         *cmacme.Order
+		orderErr      error
+		challenges    []*cmacme.Challenge
+		challengesErr error
+		expOutput     string
+	}{
+		// Newlines are part of the expected output
+		"errors are indented below their headings": {
+			order:         &cmacme.Order{ObjectMeta: metav1.ObjectMeta{Name: "example-order"}},
+			challengesErr: errors.New("No Challenges found for this Certificate\n"),
+			expOutput: `  Order:
+    Name: example-order
+    State: , Reason: 
+    No Authorizations for this Order
+    Challenges:
+      No Challenges found for this Certificate
+`,
+		},
+		"Challenges are nested below the Order with their solver": {
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{Name: "example-order"},
+				Status: cmacme.OrderStatus{
+					State: cmacme.Pending,
+					Authorizations: []cmacme.ACMEAuthorization{
+						{URL: "https://example.com/authz", Identifier: "example.com", InitialState: cmacme.Pending},
+					},
+				},
+			},
+			challenges: []*cmacme.Challenge{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "http-challenge"},
+					Spec: cmacme.ChallengeSpec{
+						Type:   cmacme.ACMEChallengeTypeHTTP01,
+						Token:  "token",
+						Key:    "token.thumbprint",
+						Solver: cmacme.ACMEChallengeSolver{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}}},
+					},
+					Status: cmacme.ChallengeStatus{State: cmacme.Pending, Processing: true, Presented: true, Reason: "Waiting for HTTP-01 challenge propagation"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "dns-challenge"},
+					Spec: cmacme.ChallengeSpec{
+						Type:   cmacme.ACMEChallengeTypeDNS01,
+						Token:  "token",
+						Key:    "digest",
+						Solver: cmacme.ACMEChallengeSolver{DNS01: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{GroupName: "acme.example.com", SolverName: "example"}}},
+					},
+				},
+			},
+			expOutput: `  Order:
+    Name: example-order
+    State: pending, Reason: 
+    Authorizations:
+      URL: https://example.com/authz, Identifier: example.com, Initial State: pending, Wildcard: nil (bool pointer not set)
+    Challenges:
+    - Name: http-challenge
+      Type: HTTP-01, Solver: HTTP-01 Ingress
+      State: pending, Reason: Waiting for HTTP-01 challenge propagation
+      Processing: true, Presented: true
+      Token: token, Key: token.thumbprint
+    - Name: dns-challenge
+      Type: DNS-01, Solver: DNS-01 Webhook (acme.example.com/example)
+      State: , Reason: 
+      Processing: false, Presented: false
+      Token: token, Key: digest
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := (&CertificateStatus{}).withOrder(test.order, test.orderErr).withChallenges(test.challenges, test.challengesErr)
+			actualOutput := status.OrderStatus.String() + status.ChallengeStatusList.String()
+			if actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestIssuerInfoString(t *testing.T) {
+	setIssuerGeneration := func(generation int64) gen.IssuerModifier {
+		return func(issuer cmapi.GenericIssuer) {
+			issuer.SetGeneration(generation)
+		}
+	}
+
+	tests := map[string]struct {
+		issuer    cmapi.GenericIssuer
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"Issuer without generation output correct": {
+			issuer: gen.Issuer("test-issuer"),
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Conditions:
+    No Conditions set
+  Events:  <none>
+`,
+		},
+		"Issuer observed by the controller output correct": {
+			issuer: gen.Issuer("test-issuer",
+				setIssuerGeneration(2),
+				gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2}),
+			),
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Generation: 2, Observed Generation: 2
+  Conditions:
+    Ready: True, Reason: , Message:
+  Events:  <none>
+`,
+		},
+		"Issuer not observed by the controller yet is flagged": {
+			issuer: gen.Issuer("test-issuer",
+				setIssuerGeneration(3),
+				gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse, ObservedGeneration: 2}),
+			),
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Generation: 3, Observed Generation: 2
+  The controller has not observed the latest generation of the spec yet, the conditions may be outdated
+  Conditions:
+    Ready: False, Reason: , Message:
+  Events:  <none>
+`,
+		},
+		"Issuer without Ready condition is flagged": {
+			issuer: gen.Issuer("test-issuer", setIssuerGeneration(1)),
+			expOutput: `Issuer:
+  Name: test-issuer
+  Kind: Issuer
+  Generation: 1, Observed Generation: <none>
+  The controller has not observed the latest generation of the spec yet, the conditions may be outdated
+  Conditions:
+    No Conditions set
+  Events:  <none>
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := (&CertificateStatus{}).withGenericIssuer(test.issuer, "Issuer", nil, nil).IssuerStatus.String()
+			if strings.ReplaceAll(actualOutput, " \n", "\n") != strings.ReplaceAll(test.expOutput, " \n", "\n") {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestIssuerHealthString(t *testing.T) {
+	tests := map[string]struct {
+		issuer    cmapi.GenericIssuer
+		kind      string
+		err       error
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"Ready Issuer output correct": {
+			kind: cmapi.ClusterIssuerKind,
+			issuer: gen.ClusterIssuer("test-issuer",
+				gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Reason: "ACMEAccountRegistered", Message: "The ACME account was registered with the ACME server"}),
+			),
+			expOutput: `Issuer Health:
+  ClusterIssuer test-issuer: Ready: True, Reason: ACMEAccountRegistered, Message: The ACME account was registered with the ACME server
+`,
+		},
+		"Issuer without Ready condition is flagged": {
+			issuer: gen.Issuer("test-issuer"),
+			kind:   cmapi.IssuerKind,
+			expOutput: `Issuer Health:
+  Issuer test-issuer: No Ready condition set
+`,
+		},
+		"Error getting the Issuer is printed": {
+			err: errors.New("error when getting Issuer: issuers.cert-manager.io \"test-issuer\" not found\n"),
+			expOutput: `Issuer Health:
+  Error: error when getting Issuer: issuers.cert-manager.io "test-issuer" not found
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := (&CertificateStatus{}).withGenericIssuer(test.issuer, test.kind, nil, test.err).IssuerStatus.healthString()
+			if actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestSpecSummaryString(t *testing.T) {
+	tests := map[string]struct {
+		crt       *cmapi.Certificate
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"Certificate without optional fields set output correct": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateSecretName("test-secret"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+			),
+			expOutput: `Spec:
+  Secret Name: test-secret
+  Issuer Ref: Issuer/ca-issuer (cert-manager.io)
+  DNS Names: <none>
+  Duration: <none>
+  Renew Before: <none>
+`,
+		},
+		"Certificate with all fields set output correct": {
+			crt: gen.Certificate("test-crt",
+				gen.SetCertificateSecretName("test-secret"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+				gen.SetCertificateDNSNames("example.com", "www.example.com"),
+				gen.SetCertificateDuration(90*24*time.Hour),
+				gen.SetCertificateRenewBefore(30*24*time.Hour),
+			),
+			expOutput: `Spec:
+  Secret Name: test-secret
+  Issuer Ref: ClusterIssuer/letsencrypt (cert-manager.io)
+  DNS Names: example.com, www.example.com
+  Duration: 2160h0m0s
+  Renew Before: 720h0m0s
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := newSpecSummary(test.crt).String()
+			if actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestGetGenericIssuer(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"))
+	clusterIssuer := gen.ClusterIssuer("test-cluster-issuer")
+
+	tests := map[string]struct {
+		issuerRef cmmeta.ObjectReference
+
+		expKind   string
+		expName   string
+		expErrMsg string
+	}{
+		"omitted kind and group refer to an Issuer": {
+			issuerRef: cmmeta.ObjectReference{Name: "test-issuer"},
+			expKind:   "Issuer",
+			expName:   "test-issuer",
+		},
+		"omitted kind with the cert-manager.io group refers to an Issuer": {
+			issuerRef: cmmeta.ObjectReference{Name: "test-issuer", Group: "cert-manager.io"},
+			expKind:   "Issuer",
+			expName:   "test-issuer",
+		},
+		"omitted group with the ClusterIssuer kind refers to a ClusterIssuer": {
+			issuerRef: cmmeta.ObjectReference{Name: "test-cluster-issuer", Kind: "ClusterIssuer"},
+			expKind:   "ClusterIssuer",
+			expName:   "test-cluster-issuer",
+		},
+		"omitted kind with a third party group throws error": {
+			issuerRef: cmmeta.ObjectReference{Name: "test-issuer", Group: "example.com"},
+			expErrMsg: "The Issuer \"test-issuer\" is not of the group cert-manager.io, this command currently does not support third party issuers.\nTo get more information about \"test-issuer\", try 'kubectl describe'\n",
+		},
+		"unknown kind throws error": {
+			issuerRef: cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuers"},
+			expErrMsg: "The issuerRef of the Certificate is of the unknown kind \"Issuers\", expected Issuer or ClusterIssuer\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test-crt", gen.SetCertificateNamespace("test-ns"), gen.SetCertificateIssuer(test.issuerRef))
+
+			genericIssuer, kind, err := getGenericIssuer(cmfake.NewSimpleClientset(issuer, clusterIssuer), context.TODO(), crt)
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expKind, kind)
+			assert.Equal(t, test.expName, genericIssuer.GetName())
+		})
+	}
+}
+
+func TestSinceLastTransition(t *testing.T) {
+	now := time.Date(2022, 3, 4, 4, 0, 0, 0, time.UTC)
+	transition := &metav1.Time{Time: now.Add(-(3*24 + 4) * time.Hour)}
+
+	tests := map[string]struct {
+		conditions []cmapi.CertificateCondition
+		expOutput  string
+	}{
+		"Ready condition with status True": {
+			conditions: []cmapi.CertificateCondition{
+				{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionFalse, LastTransitionTime: &metav1.Time{Time: now}},
+				{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, LastTransitionTime: transition},
+			},
+			expOutput: "Ready for 3d4h",
+		},
+		"Ready condition with status False": {
+			conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, LastTransitionTime: &metav1.Time{Time: now.Add(-5 * time.Minute)}}},
+			expOutput:  "Not Ready for 5m",
+		},
+		"Ready condition with status Unknown": {
+			conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionUnknown, LastTransitionTime: transition}},
+			expOutput:  "Ready state Unknown for 3d4h",
+		},
+		"Ready condition without last transition time": {
+			conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+			expOutput:  "Ready condition has no last transition time",
+		},
+		"no Ready condition": {
+			expOutput: "No Ready condition set",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actualOutput := sinceLastTransition(test.conditions, now); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestCSRInfoString(t *testing.T) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "example.com", Organization: []string{"Example"}},
+		DNSNames:    []string{"example.com", "www.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	tests := map[string]struct {
+		cr        *cmapi.CertificateRequest
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"CR with valid CSR output correct": {
+			cr: &cmapi.CertificateRequest{
+				Spec: cmapi.CertificateRequestSpec{Request: csrPEM},
+			},
+			expOutput: `CertificateRequest:
+  Name:
+  Namespace:
+  Conditions:
+    No Conditions set
+  CSR:
+    Subject: CN=example.com,O=Example
+    Key Type: ECDSA P-256
+    DNS Names:
+    - example.com
+    - www.example.com
+    IP Addresses:
+    - 10.0.0.1
+    URIs: <none>
+    Email Addresses: <none>
+  Events:  <none>
+`,
+		},
+		"CR with invalid CSR output correct": {
+			cr: &cmapi.CertificateRequest{
+				Spec: cmapi.CertificateRequestSpec{Request: []byte("not a CSR")},
+			},
+			expOutput: `CertificateRequest:
+  Name:
+  Namespace:
+  Conditions:
+    No Conditions set
+  CSR:
+    error when decoding the CSR of the CertificateRequest: error decoding certificate request PEM block
+  Events:  <none>
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualOutput := (&CertificateStatus{}).withCR(test.cr, nil, nil).withCSR(test.cr).CRStatus.String()
+			if strings.ReplaceAll(actualOutput, " \n", "\n") != strings.ReplaceAll(test.expOutput, " \n", "\n") {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
 func TestKeyUsageToString(t *testing.T) {
 	tests := map[string]struct {
 		usage     x509.KeyUsage
@@ -215,6 +633,10 @@ eMmpfeISQmCsBC1CTmpxUjeUg5DEQ0X1TQykXq+bG2iso6RYPxZTFTHJFzXiDYEc
 MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 -----END CERTIFICATE-----`)
 
+	clock = fakeclock.NewFakeClock(timestamp)
+	tlsCrtNotAfter := metav1.NewTime(time.Date(2020, 10, 28, 16, 11, 43, 0, time.UTC))
+	tlsCrtChain := []ChainCertificateStatus{{Subject: "test", NotAfter: tlsCrtNotAfter, expiresIn: "42d"}}
+
 	serialNum, _ := new(big.Int).SetString("301696114246524167282555582613204853562", 10)
 	ns := "ns1"
 	dummyEventList := &corev1.EventList{
@@ -246,6 +668,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}, DNSNames: []string{"example.com"}},
 				Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady,
 					Status: cmmeta.ConditionTrue, Message: "Certificate is up to date and has not expired"}},
 				DNSNames:    []string{"example.com"},
@@ -268,6 +691,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				IssuerStatus: &IssuerStatus{
 					Name:   "test-issuer",
 					Kind:   "Issuer",
@@ -288,6 +712,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				IssuerStatus: &IssuerStatus{
 					Name:   "test-clusterissuer",
 					Kind:   "ClusterIssuer",
@@ -306,9 +731,12 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				SecretEvents: dummyEventList,
 			},
 			expOutput: &CertificateStatus{
-				Name:         "test-crt",
-				Namespace:    ns,
-				CreationTime: metav1.Time{},
+				Name:          "test-crt",
+				Namespace:     ns,
+				CreationTime:  metav1.Time{},
+				Spec:          &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
+				ChainNotAfter: &tlsCrtNotAfter,
+				chainExpiry:   &tlsCrtChain[0],
 				SecretStatus: &SecretStatus{
 					Error:              nil,
 					Name:               "existing-tls-secret",
@@ -322,6 +750,8 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 					SubjectKeyId:       nil,
 					AuthorityKeyId:     nil,
 					SerialNumber:       serialNum,
+					PrivateKeyType:     "<unavailable>",
+					Chain:              tlsCrtChain,
 					Events:             dummyEventList,
 				},
 			},
@@ -340,6 +770,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				CRStatus: &CRStatus{
 					Error:      nil,
 					Name:       "test-req",
@@ -365,6 +796,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				OrderStatus: &OrderStatus{
 					Error:          nil,
 					Name:           "example-order",
@@ -417,6 +849,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:         "test-crt",
 				Namespace:    ns,
 				CreationTime: metav1.Time{},
+				Spec:         &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				ChallengeStatusList: &ChallengeStatusList{
 					ChallengeStatuses: []*ChallengeStatus{
 						{
@@ -469,6 +902,7 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 				Name:                "test-crt",
 				Namespace:           ns,
 				CreationTime:        metav1.Time{},
+				Spec:                &SpecSummary{IssuerRef: cmmeta.ObjectReference{Kind: "Issuer", Group: "cert-manager.io"}},
 				IssuerStatus:        &IssuerStatus{Error: errors.New("dummy error")},
 				SecretStatus:        &SecretStatus{Error: errors.New("dummy error")},
 				CRStatus:            &CRStatus{Error: errors.New("dummy error")},
@@ -484,3 +918,26 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 		})
 	}
 }
+
+func TestPrintStatusVerbatim(t *testing.T) {
+	// Values of the Certificate, e.g. annotations, can contain % characters,
+	// which must be printed verbatim rather than as formatting verbs
+	crt := gen.Certificate("my-crt", gen.SetCertificateNamespace("default"))
+	crt.Annotations = map[string]string{"note": "100%done"}
+
+	var out bytes.Buffer
+	o := NewOptions(genericclioptions.IOStreams{Out: &out, ErrOut: &out})
+	data := &Data{
+		Certificate: crt,
+		Issuer:      gen.Issuer("my-issuer", gen.SetIssuerNamespace("default")),
+		IssuerKind:  cmapi.IssuerKind,
+		SecretError: errors.New("error when finding Secret \"my-secret\": not found"),
+		ReqError:    errors.New("error when finding CertificateRequest: not found"),
+	}
+	if err := o.printStatus(data); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "note=100%done") {
+		t.Errorf("expected the annotation to be printed verbatim, got: \n%s", out.String())
+	}
+}
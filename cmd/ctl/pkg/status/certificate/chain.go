@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// ChainCertificateStatus is the validity of one of the certificates in the
+// tls.crt of the Secret.
+type ChainCertificateStatus struct {
+	// Subject Common Name of the certificate, or its full subject if it has
+	// no Common Name
+	Subject string `json:"subject"`
+	// Not After time of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// Time remaining until NotAfter, e.g. "89d" or "expired 2d ago"
+	expiresIn string
+}
+
+// withChain sets the validity of every certificate in the tls.crt of the
+// Secret, the leaf certificate first, and the soonest Not After time across
+// the chain, as of now. A leaf which is valid for a long time can be signed
+// by an intermediate which is about to expire.
+func (status *CertificateStatus) withChain(secret *v1.Secret, now time.Time) *CertificateStatus {
+	if status.SecretStatus == nil || status.SecretStatus.Error != nil || secret == nil {
+		return status
+	}
+	certs, err := pki.DecodeX509CertificateChainBytes(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		// The leaf certificate was parsed already, which is all that is
+		// printed about an invalid chain
+		return status
+	}
+
+	var soonest *ChainCertificateStatus
+	for _, cert := range certs {
+		status.SecretStatus.Chain = append(status.SecretStatus.Chain, ChainCertificateStatus{
+			Subject:   chainSubject(cert),
+			NotAfter:  metav1.NewTime(cert.NotAfter),
+			expiresIn: expiresIn(cert.NotAfter, now),
+		})
+	}
+	for i := range status.SecretStatus.Chain {
+		if c := &status.SecretStatus.Chain[i]; soonest == nil || c.NotAfter.Before(&soonest.NotAfter) {
+			soonest = c
+		}
+	}
+	if soonest != nil {
+		notAfter := soonest.NotAfter
+		status.ChainNotAfter = &notAfter
+		status.chainExpiry = soonest
+	}
+	return status
+}
+
+// chainSubject returns the Common Name of the subject of cert, or the full
+// subject if it has no Common Name.
+func chainSubject(cert *x509.Certificate) string {
+	if len(cert.Subject.CommonName) > 0 {
+		return cert.Subject.CommonName
+	}
+	if subject := cert.Subject.String(); len(subject) > 0 {
+		return subject
+	}
+	return "<none>"
+}
+
+// expiresIn returns the time remaining until notAfter as of now, e.g. "89d",
+// or how long ago it expired.
+func expiresIn(notAfter, now time.Time) string {
+	if !notAfter.After(now) {
+		return fmt.Sprintf("expired %s ago", duration.HumanDuration(now.Sub(notAfter)))
+	}
+	return duration.HumanDuration(notAfter.Sub(now))
+}
+
+// chainString returns the validity of the certificates in the chain, as
+// printed in the status of the Secret.
+func chainString(chain []ChainCertificateStatus) string {
+	if len(chain) == 0 {
+		return ""
+	}
+	output := "  Certificate Chain:\n"
+	for _, c := range chain {
+		output += fmt.Sprintf("    - %s: Not After %s (%s)\n", c.Subject, formatTimeString(&c.NotAfter), c.expiresIn)
+	}
+	return output
+}
+
+// chainExpiryString returns when the certificate of the chain expiring first
+// expires, as printed after the Not After time of the Certificate.
+func chainExpiryString(c *ChainCertificateStatus) string {
+	if c == nil {
+		return ""
+	}
+	return fmt.Sprintf("Chain Expires In: %s (%s, Not After %s)\n", c.expiresIn, c.Subject, formatTimeString(&c.NotAfter))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithChain(t *testing.T) {
+	now := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	leafNotAfter := now.Add(80 * 24 * time.Hour)
+	intermediateNotAfter := now.Add(10 * 24 * time.Hour)
+	rootNotAfter := now.Add(-2 * 24 * time.Hour)
+
+	certPEM := func(subject pkix.Name, notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      subject,
+			NotBefore:    now.Add(-365 * 24 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	leaf := certPEM(pkix.Name{CommonName: "www.example.com"}, leafNotAfter)
+	intermediate := certPEM(pkix.Name{CommonName: "Example Intermediate CA"}, intermediateNotAfter)
+	root := certPEM(pkix.Name{Organization: []string{"Example"}}, rootNotAfter)
+
+	tests := map[string]struct {
+		tlsCrt []byte
+
+		expChain     []ChainCertificateStatus
+		expNotAfter  *metav1.Time
+		expOutput    string
+		expSecretOut string
+	}{
+		"leaf certificate only": {
+			tlsCrt:       leaf,
+			expChain:     []ChainCertificateStatus{{Subject: "www.example.com", NotAfter: metav1.NewTime(leafNotAfter), expiresIn: "80d"}},
+			expNotAfter:  &metav1.Time{Time: leafNotAfter},
+			expOutput:    "Chain Expires In: 80d (www.example.com, Not After 2023-07-20T00:00:00Z)\n",
+			expSecretOut: "  Certificate Chain:\n    - www.example.com: Not After 2023-07-20T00:00:00Z (80d)\n",
+		},
+		"intermediate expiring before the leaf certificate": {
+			tlsCrt: append(append([]byte{}, leaf...), intermediate...),
+			expChain: []ChainCertificateStatus{
+				{Subject: "www.example.com", NotAfter: metav1.NewTime(leafNotAfter), expiresIn: "80d"},
+				{Subject: "Example Intermediate CA", NotAfter: metav1.NewTime(intermediateNotAfter), expiresIn: "10d"},
+			},
+			expNotAfter: &metav1.Time{Time: intermediateNotAfter},
+			expOutput:   "Chain Expires In: 10d (Example Intermediate CA, Not After 2023-05-11T00:00:00Z)\n",
+			expSecretOut: "  Certificate Chain:\n" +
+				"    - www.example.com: Not After 2023-07-20T00:00:00Z (80d)\n" +
+				"    - Example Intermediate CA: Not After 2023-05-11T00:00:00Z (10d)\n",
+		},
+		"expired certificate without common name": {
+			tlsCrt: append(append([]byte{}, leaf...), root...),
+			expChain: []ChainCertificateStatus{
+				{Subject: "www.example.com", NotAfter: metav1.NewTime(leafNotAfter), expiresIn: "80d"},
+				{Subject: "O=Example", NotAfter: metav1.NewTime(rootNotAfter), expiresIn: "expired 2d ago"},
+			},
+			expNotAfter: &metav1.Time{Time: rootNotAfter},
+			expOutput:   "Chain Expires In: expired 2d ago (O=Example, Not After 2023-04-29T00:00:00Z)\n",
+			expSecretOut: "  Certificate Chain:\n" +
+				"    - www.example.com: Not After 2023-07-20T00:00:00Z (80d)\n" +
+				"    - O=Example: Not After 2023-04-29T00:00:00Z (expired 2d ago)\n",
+		},
+		"invalid chain": {
+			tlsCrt: append(append([]byte{}, leaf...), []byte("-----BEGIN CERTIFICATE-----\naW52YWxpZA==\n-----END CERTIFICATE-----\n")...),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-tls"},
+				Data:       map[string][]byte{corev1.TLSCertKey: test.tlsCrt},
+			}
+			status := (&CertificateStatus{SecretStatus: &SecretStatus{Name: "test-tls"}}).withChain(secret, now)
+
+			assert.Equal(t, test.expChain, status.SecretStatus.Chain)
+			if test.expNotAfter == nil {
+				assert.Nil(t, status.ChainNotAfter)
+			} else if assert.NotNil(t, status.ChainNotAfter) {
+				assert.True(t, test.expNotAfter.Equal(status.ChainNotAfter))
+			}
+			assert.Equal(t, test.expOutput, chainExpiryString(status.chainExpiry))
+			assert.Equal(t, test.expSecretOut, chainString(status.SecretStatus.Chain))
+		})
+	}
+}
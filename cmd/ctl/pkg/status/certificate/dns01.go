@@ -0,0 +1,315 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+// dnsResolver performs the DNS lookups of --resolve and --resolve-cname, it is
+// replaced in tests.
+var dnsResolver txtResolver = dnsutilResolver{}
+
+// txtResolver looks up the DNS records of DNS-01 challenges.
+type txtResolver interface {
+	// LookupTXT returns the TXT records of fqdn served by nameserver
+	LookupTXT(nameserver, fqdn string) ([]string, error)
+	// AuthoritativeNameservers returns the nameservers of the zone of fqdn,
+	// found using the recursive nameservers
+	AuthoritativeNameservers(fqdn string, nameservers []string) ([]string, error)
+	// LookupFQDN returns the FQDN the TXT record of the DNS-01 Challenge for
+	// dnsName is written to, following the CNAMEs of its '_acme-challenge'
+	// name using the recursive nameservers
+	LookupFQDN(dnsName string, nameservers []string) (string, error)
+}
+
+// dns01Diagnosis describes a DNS-01 Challenge and, if resolved, the TXT
+// records served for it.
+type dns01Diagnosis struct {
+	DNSName  string
+	Wildcard bool
+	FQDN     string
+	// Key is the value of the TXT record expected by the ACME server
+	Key     string
+	State   cmacme.State
+	Reason  string
+	Lookups []txtLookup
+	// LookupErr is set if the nameservers to query could not be determined
+	LookupErr error
+	// CNAMEStrategy of the DNS-01 solver of the Challenge
+	CNAMEStrategy cmacme.CNAMEStrategy
+	// CNAME is set if the CNAME chain of FQDN was resolved
+	CNAME *cnameChain
+}
+
+// cnameChain is the CNAME chain of the FQDN of a DNS-01 Challenge, which
+// ends at the name the TXT record has to be written to.
+type cnameChain struct {
+	// Target is the last name of the chain, or the FQDN if it is not a CNAME
+	Target string
+	// Nameservers of the zone of Target
+	Nameservers []string
+	// Err is set if the chain could not be followed to its end
+	Err error
+	// NameserversErr is set if the zone of Target could not be found
+	NameserversErr error
+}
+
+// txtLookup is the result of looking up the TXT records of a Challenge
+// using a single nameserver.
+type txtLookup struct {
+	Nameserver    string
+	Authoritative bool
+	Values        []string
+	Err           error
+}
+
+// runDiagnoseDNS01 prints the DNS-01 Challenges of the Certificate named
+// name, looking up their TXT records if o.Resolve is true.
+func (o *Options) runDiagnoseDNS01(ctx context.Context, name string) error {
+	data, err := o.GetResources(ctx, name)
+	if err != nil {
+		return err
+	}
+	if data.OrderError != nil {
+		return data.OrderError
+	}
+	if data.ChallengeErr != nil {
+		return data.ChallengeErr
+	}
+
+	var diagnoses []*dns01Diagnosis
+	for _, ch := range data.Challenges {
+		if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 {
+			continue
+		}
+		diagnosis := &dns01Diagnosis{
+			DNSName:  ch.Spec.DNSName,
+			Wildcard: ch.Spec.Wildcard,
+			FQDN:     dns01FQDN(ch.Spec.DNSName),
+			Key:      ch.Spec.Key,
+			State:    ch.Status.State,
+			Reason:   ch.Status.Reason,
+		}
+		if ch.Spec.Solver.DNS01 != nil {
+			diagnosis.CNAMEStrategy = ch.Spec.Solver.DNS01.CNAMEStrategy
+		}
+		diagnoses = append(diagnoses, diagnosis)
+	}
+	if len(diagnoses) == 0 {
+		return errors.New("no DNS-01 Challenges found for this Certificate")
+	}
+	sort.Slice(diagnoses, func(i, j int) bool { return diagnoses[i].DNSName < diagnoses[j].DNSName })
+
+	for _, diagnosis := range diagnoses {
+		if o.ResolveCNAME {
+			diagnosis.resolveCNAME(dnsResolver, o.DNS01Nameservers)
+		}
+		if o.Resolve {
+			diagnosis.resolve(dnsResolver, o.DNS01Nameservers)
+		}
+	}
+
+	output := "DNS-01 Challenges:\n"
+	for _, diagnosis := range diagnoses {
+		output += diagnosis.String()
+	}
+	_, err = fmt.Fprint(o.Out, output)
+	return err
+}
+
+// dns01FQDN returns the name of the TXT record of a DNS-01 Challenge for
+// dnsName. Wildcards are already stripped from the DNS names of Challenges.
+func dns01FQDN(dnsName string) string {
+	return "_acme-challenge." + strings.TrimSuffix(dnsName, ".") + "."
+}
+
+// resolve looks up the TXT records of the Challenge using its authoritative
+// nameservers, followed by the given public nameservers. The authoritative
+// nameservers are found using the public nameservers, as done by cert-manager
+// when checking the propagation of the TXT record.
+func (d *dns01Diagnosis) resolve(resolver txtResolver, publicNameservers []string) {
+	authoritative, err := resolver.AuthoritativeNameservers(d.FQDN, publicNameservers)
+	if err != nil {
+		d.LookupErr = fmt.Errorf("error when finding the authoritative nameservers: %w", err)
+	}
+
+	lookup := func(nameserver string, isAuthoritative bool) {
+		values, err := resolver.LookupTXT(nameserver, d.FQDN)
+		d.Lookups = append(d.Lookups, txtLookup{Nameserver: nameserver, Authoritative: isAuthoritative, Values: values, Err: err})
+	}
+	for _, nameserver := range authoritative {
+		lookup(nameserver, true)
+	}
+	for _, nameserver := range publicNameservers {
+		lookup(nameserver, false)
+	}
+}
+
+// resolveCNAME follows the CNAME chain of the FQDN of the Challenge using the
+// recursive nameservers, and finds the nameservers of the zone the chain ends
+// in, which is where the TXT record has to be written.
+func (d *dns01Diagnosis) resolveCNAME(resolver txtResolver, nameservers []string) {
+	chain := &cnameChain{}
+	d.CNAME = chain
+
+	chain.Target, chain.Err = resolver.LookupFQDN(strings.TrimSuffix(d.DNSName, "."), nameservers)
+	if chain.Err != nil {
+		chain.Err = fmt.Errorf("error when following the CNAMEs of %s: %w", d.FQDN, chain.Err)
+		return
+	}
+
+	chain.Nameservers, chain.NameserversErr = resolver.AuthoritativeNameservers(chain.Target, nameservers)
+}
+
+func (d *dns01Diagnosis) String() string {
+	dnsName := d.DNSName
+	if d.Wildcard {
+		dnsName = "*." + dnsName
+	}
+
+	output := fmt.Sprintf("  - %s:\n", dnsName)
+	output += fmt.Sprintf("    FQDN: %s\n", d.FQDN)
+	output += fmt.Sprintf("    Expected TXT value: %s\n", d.Key)
+	output += fmt.Sprintf("    State: %s, Reason: %s\n", d.State, d.Reason)
+	if d.LookupErr != nil {
+		output += fmt.Sprintf("    %s\n", d.LookupErr)
+	}
+	if d.CNAME != nil {
+		output += d.CNAME.String(d.FQDN, d.CNAMEStrategy)
+	}
+	if len(d.Lookups) > 0 {
+		output += "    Lookups:\n"
+	}
+	for _, lookup := range d.Lookups {
+		output += fmt.Sprintf("      %s: %s\n", lookup.nameserverString(), lookup.result(d.Key))
+	}
+	return output
+}
+
+// String returns where the CNAME chain starting at fqdn ends, and so where
+// the TXT record has to be written if the chain could be followed to its end.
+// cert-manager only follows the chain if the solver uses the Follow CNAME
+// strategy.
+func (c *cnameChain) String(fqdn string, strategy cmacme.CNAMEStrategy) string {
+	if c.Err != nil {
+		return fmt.Sprintf("    CNAME: %s\n", c.Err)
+	}
+	if c.Target == fqdn {
+		return "    CNAME: none, the TXT record is written to the FQDN\n"
+	}
+
+	output := fmt.Sprintf("    CNAME: %s -> %s\n", fqdn, c.Target)
+	switch {
+	case c.NameserversErr != nil:
+		output += fmt.Sprintf("      The TXT record has to be written to %s, but its zone could not be found: %s\n", c.Target, c.NameserversErr)
+	default:
+		output += fmt.Sprintf("      The TXT record has to be written to %s, in the zone served by %s\n", c.Target, strings.Join(c.Nameservers, ", "))
+	}
+	if strategy != cmacme.FollowStrategy {
+		output += "      The DNS-01 solver doesn't follow CNAMEs, set its cnameStrategy to Follow to write the TXT record to the target of the chain\n"
+	}
+	return output
+}
+
+func (l txtLookup) nameserverString() string {
+	if l.Authoritative {
+		return l.Nameserver + " (authoritative)"
+	}
+	return l.Nameserver
+}
+
+// result returns whether the expected TXT value key was served.
+func (l txtLookup) result(key string) string {
+	if l.Err != nil {
+		return fmt.Sprintf("error: %s", l.Err)
+	}
+	for _, value := range l.Values {
+		if value == key {
+			return "found"
+		}
+	}
+	if len(l.Values) == 0 {
+		return "not found, no TXT records served"
+	}
+	return fmt.Sprintf("not found, served TXT records: %s", strings.Join(l.Values, ", "))
+}
+
+// dnsutilResolver looks up DNS records using the DNS helpers of the ACME
+// issuer, so that the CNAME chain and the zone of a Challenge are found the
+// same way the cert-manager controller does.
+type dnsutilResolver struct{}
+
+func (dnsutilResolver) LookupTXT(nameserver, fqdn string) ([]string, error) {
+	in, err := dnsutil.DNSQuery(fqdn, dns.TypeTXT, []string{nameserver}, true)
+	if err != nil {
+		return nil, err
+	}
+	switch in.Rcode {
+	case dns.RcodeSuccess, dns.RcodeNameError:
+	default:
+		return nil, fmt.Errorf("nameserver %s returned %s", nameserver, dns.RcodeToString[in.Rcode])
+	}
+
+	var values []string
+	for _, rr := range in.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			values = append(values, strings.Join(txt.Txt, ""))
+		}
+	}
+	return values, nil
+}
+
+func (dnsutilResolver) LookupFQDN(dnsName string, nameservers []string) (string, error) {
+	return dnsutil.DNS01LookupFQDN(dnsName, true, nameservers...)
+}
+
+// AuthoritativeNameservers finds the zone of fqdn using its SOA record, and
+// returns the nameservers of its NS records.
+func (dnsutilResolver) AuthoritativeNameservers(fqdn string, nameservers []string) ([]string, error) {
+	zone, err := dnsutil.FindZoneByFqdn(fqdn, nameservers)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := dnsutil.DNSQuery(zone, dns.TypeNS, nameservers, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var authoritative []string
+	for _, rr := range in.Answer {
+		if ns, ok := rr.(*dns.NS); ok {
+			authoritative = append(authoritative, net.JoinHostPort(strings.ToLower(dnsutil.UnFqdn(ns.Ns)), "53"))
+		}
+	}
+	if len(authoritative) == 0 {
+		return nil, fmt.Errorf("no NS records found for the zone %s", zone)
+	}
+	sort.Strings(authoritative)
+	return authoritative, nil
+}
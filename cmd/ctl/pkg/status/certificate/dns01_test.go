@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+type fakeTXTResolver struct {
+	authoritative    []string
+	authoritativeErr error
+	records          map[string][]string
+	errs             map[string]error
+	// fqdn is the FQDN the CNAMEs of the Challenge end at
+	fqdn    string
+	fqdnErr error
+}
+
+func (f fakeTXTResolver) LookupTXT(nameserver, _ string) ([]string, error) {
+	return f.records[nameserver], f.errs[nameserver]
+}
+
+func (f fakeTXTResolver) AuthoritativeNameservers(string, []string) ([]string, error) {
+	return f.authoritative, f.authoritativeErr
+}
+
+func (f fakeTXTResolver) LookupFQDN(string, []string) (string, error) {
+	return f.fqdn, f.fqdnErr
+}
+
+func TestDNS01Diagnosis(t *testing.T) {
+	newDiagnosis := func() *dns01Diagnosis {
+		return &dns01Diagnosis{
+			DNSName:  "example.com",
+			Wildcard: true,
+			FQDN:     dns01FQDN("example.com"),
+			Key:      "expected-key",
+			State:    cmacme.Pending,
+			Reason:   "Waiting for DNS-01 challenge propagation",
+		}
+	}
+
+	tests := map[string]struct {
+		resolver  txtResolver
+		expOutput string
+	}{
+		"not resolved": {
+			expOutput: `  - *.example.com:
+    FQDN: _acme-challenge.example.com.
+    Expected TXT value: expected-key
+    State: pending, Reason: Waiting for DNS-01 challenge propagation
+`,
+		},
+		"authoritative and public nameservers are compared": {
+			resolver: fakeTXTResolver{
+				authoritative: []string{"ns1.example.com:53"},
+				records: map[string][]string{
+					"ns1.example.com:53": {"other-key", "expected-key"},
+					"8.8.8.8:53":         {"stale-key"},
+				},
+				errs: map[string]error{"1.1.1.1:53": errors.New("i/o timeout")},
+			},
+			expOutput: `  - *.example.com:
+    FQDN: _acme-challenge.example.com.
+    Expected TXT value: expected-key
+    State: pending, Reason: Waiting for DNS-01 challenge propagation
+    Lookups:
+      ns1.example.com:53 (authoritative): found
+      8.8.8.8:53: not found, served TXT records: stale-key
+      1.1.1.1:53: error: i/o timeout
+`,
+		},
+		"public nameservers are queried if the authoritative nameservers are unknown": {
+			resolver: fakeTXTResolver{authoritativeErr: errors.New("no NS records found")},
+			expOutput: `  - *.example.com:
+    FQDN: _acme-challenge.example.com.
+    Expected TXT value: expected-key
+    State: pending, Reason: Waiting for DNS-01 challenge propagation
+    error when finding the authoritative nameservers: no NS records found
+    Lookups:
+      8.8.8.8:53: not found, no TXT records served
+      1.1.1.1:53: not found, no TXT records served
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diagnosis := newDiagnosis()
+			if test.resolver != nil {
+				diagnosis.resolve(test.resolver, []string{"8.8.8.8:53", "1.1.1.1:53"})
+			}
+			assert.Equal(t, test.expOutput, diagnosis.String())
+		})
+	}
+}
+
+func TestDNS01DiagnosisCNAME(t *testing.T) {
+	const header = `  - example.com:
+    FQDN: _acme-challenge.example.com.
+    Expected TXT value: expected-key
+    State: pending, Reason: Waiting for DNS-01 challenge propagation
+`
+
+	tests := map[string]struct {
+		resolver      txtResolver
+		cnameStrategy cmacme.CNAMEStrategy
+		expOutput     string
+	}{
+		"FQDN without CNAME": {
+			resolver: fakeTXTResolver{
+				authoritative: []string{"ns1.example.com:53"},
+				fqdn:          "_acme-challenge.example.com.",
+			},
+			expOutput: header + `    CNAME: none, the TXT record is written to the FQDN
+`,
+		},
+		"CNAME followed by the solver": {
+			resolver: fakeTXTResolver{
+				authoritative: []string{"ns1.example.net:53", "ns2.example.net:53"},
+				fqdn:          "example.com.acme.example.net.",
+			},
+			cnameStrategy: cmacme.FollowStrategy,
+			expOutput: header + `    CNAME: _acme-challenge.example.com. -> example.com.acme.example.net.
+      The TXT record has to be written to example.com.acme.example.net., in the zone served by ns1.example.net:53, ns2.example.net:53
+`,
+		},
+		"CNAME not followed by the solver": {
+			resolver: fakeTXTResolver{
+				authoritative: []string{"ns1.example.net:53"},
+				fqdn:          "example.com.acme.example.net.",
+			},
+			expOutput: header + `    CNAME: _acme-challenge.example.com. -> example.com.acme.example.net.
+      The TXT record has to be written to example.com.acme.example.net., in the zone served by ns1.example.net:53
+      The DNS-01 solver doesn't follow CNAMEs, set its cnameStrategy to Follow to write the TXT record to the target of the chain
+`,
+		},
+		"CNAME loop": {
+			resolver: fakeTXTResolver{
+				fqdnErr: errors.New(`Found recursive CNAME record to "_acme-challenge.example.com." when looking up "example.com.acme.example.net."`),
+			},
+			cnameStrategy: cmacme.FollowStrategy,
+			expOutput: header + `    CNAME: error when following the CNAMEs of _acme-challenge.example.com.: Found recursive CNAME record to "_acme-challenge.example.com." when looking up "example.com.acme.example.net."
+`,
+		},
+		"zone of the target not found": {
+			resolver: fakeTXTResolver{
+				authoritativeErr: errors.New("Could not find the SOA record in the DNS tree"),
+				fqdn:             "example.com.acme.example.invalid.",
+			},
+			cnameStrategy: cmacme.FollowStrategy,
+			expOutput: header + `    CNAME: _acme-challenge.example.com. -> example.com.acme.example.invalid.
+      The TXT record has to be written to example.com.acme.example.invalid., but its zone could not be found: Could not find the SOA record in the DNS tree
+`,
+		},
+		"lookup error": {
+			resolver: fakeTXTResolver{fqdnErr: errors.New("i/o timeout")},
+			expOutput: header + `    CNAME: error when following the CNAMEs of _acme-challenge.example.com.: i/o timeout
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diagnosis := &dns01Diagnosis{
+				DNSName:       "example.com",
+				FQDN:          dns01FQDN("example.com"),
+				Key:           "expected-key",
+				State:         cmacme.Pending,
+				Reason:        "Waiting for DNS-01 challenge propagation",
+				CNAMEStrategy: test.cnameStrategy,
+			}
+			diagnosis.resolveCNAME(test.resolver, []string{"8.8.8.8:53"})
+			assert.Equal(t, test.expOutput, diagnosis.String())
+		})
+	}
+}
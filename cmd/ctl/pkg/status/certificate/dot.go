@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// dotFormat is the output format printing the Certificate and the resources
+// created for it as a Graphviz DOT graph.
+const dotFormat = "dot"
+
+// writeDot writes the Certificate of data, its CertificateRequest, Order and
+// Challenges to w as a Graphviz DOT graph. Every resource is a node labelled
+// with its kind, name and state, and the edges point from owners to the
+// resources they own. Resources which were not found are left out.
+func writeDot(w io.Writer, data *Data) error {
+	crt := data.Certificate
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote("Certificate "+crt.Namespace+"/"+crt.Name))
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	crtID := dotNodeID(cmapi.CertificateKind, crt.Namespace, crt.Name)
+	crtState := readyLabel("", "")
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+		crtState = readyLabel(cond.Status, cond.Reason)
+	}
+	writeDotNode(&b, crtID, cmapi.CertificateKind, crt.Name, crtState)
+
+	if req := data.Req; req != nil {
+		reqID := dotNodeID(cmapi.CertificateRequestKind, req.Namespace, req.Name)
+		reqState := readyLabel("", "")
+		if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady); cond != nil {
+			reqState = readyLabel(cond.Status, cond.Reason)
+		}
+		writeDotNode(&b, reqID, cmapi.CertificateRequestKind, req.Name, reqState)
+		writeDotEdge(&b, crtID, reqID)
+
+		if order := data.Order; order != nil {
+			orderID := dotNodeID(cmacme.OrderKind, order.Namespace, order.Name)
+			writeDotNode(&b, orderID, cmacme.OrderKind, order.Name, stateLabel(order.Status.State))
+			writeDotEdge(&b, reqID, orderID)
+
+			for _, challenge := range data.Challenges {
+				challengeID := dotNodeID(cmacme.ChallengeKind, challenge.Namespace, challenge.Name)
+				writeDotNode(&b, challengeID, cmacme.ChallengeKind, challenge.Name,
+					stateLabel(challenge.Status.State), "Type: "+string(challenge.Spec.Type))
+				writeDotEdge(&b, orderID, challengeID)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDotNode writes a node with the given ID to b, labelled with the kind
+// and name of the resource followed by one line per detail.
+func writeDotNode(b *strings.Builder, id, kind, name string, details ...string) {
+	label := append([]string{kind, name}, details...)
+	fmt.Fprintf(b, "\t%s [label=%s];\n", dotQuote(id), dotQuote(label...))
+}
+
+// writeDotEdge writes an edge from the owner to the owned node to b.
+func writeDotEdge(b *strings.Builder, ownerID, ownedID string) {
+	fmt.Fprintf(b, "\t%s -> %s [label=\"owns\"];\n", dotQuote(ownerID), dotQuote(ownedID))
+}
+
+// dotNodeID returns the ID of the node of a resource, unique within a graph.
+func dotNodeID(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// dotQuote returns lines as a quoted DOT string, separated by line breaks.
+func dotQuote(lines ...string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		escaped[i] = escaper.Replace(line)
+	}
+	return `"` + strings.Join(escaped, `\n`) + `"`
+}
+
+// readyLabel returns the status and reason of a Ready condition, as used in
+// the labels of nodes. An empty status stands for a missing condition.
+func readyLabel(status cmmeta.ConditionStatus, reason string) string {
+	if len(status) == 0 {
+		return "Ready: <none>"
+	}
+	if len(reason) == 0 {
+		return fmt.Sprintf("Ready: %s", status)
+	}
+	return fmt.Sprintf("Ready: %s (%s)", status, reason)
+}
+
+// stateLabel returns the state of an ACME Order or Challenge, as used in the
+// labels of nodes.
+func stateLabel(state cmacme.State) string {
+	if len(state) == 0 {
+		return "State: <none>"
+	}
+	return "State: " + string(state)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestWriteDot(t *testing.T) {
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt", Namespace: "my-namespace"},
+		Status: cmapi.CertificateStatus{Conditions: []cmapi.CertificateCondition{
+			{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "InProgress"},
+		}},
+	}
+	req := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt-1", Namespace: "my-namespace"},
+		Status: cmapi.CertificateRequestStatus{Conditions: []cmapi.CertificateRequestCondition{
+			{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionFalse, Reason: "Pending"},
+		}},
+	}
+	order := &cmacme.Order{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt-1-123", Namespace: "my-namespace"},
+		Status:     cmacme.OrderStatus{State: cmacme.Pending},
+	}
+	challenge := &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-crt-1-123-456", Namespace: "my-namespace"},
+		Spec:       cmacme.ChallengeSpec{Type: cmacme.ACMEChallengeTypeDNS01},
+	}
+
+	tests := map[string]struct {
+		data *Data
+		want string
+	}{
+		"Certificate without CertificateRequest": {
+			data: &Data{Certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: `my-"crt"`, Namespace: "my-namespace"},
+			}},
+			want: `digraph "Certificate my-namespace/my-\"crt\"" {
+	rankdir=LR;
+	node [shape=box];
+	"Certificate/my-namespace/my-\"crt\"" [label="Certificate\nmy-\"crt\"\nReady: <none>"];
+}
+`,
+		},
+		"Certificate with its CertificateRequest, Order and Challenges": {
+			data: &Data{Certificate: crt, Req: req, Order: order, Challenges: []*cmacme.Challenge{challenge}},
+			want: `digraph "Certificate my-namespace/my-crt" {
+	rankdir=LR;
+	node [shape=box];
+	"Certificate/my-namespace/my-crt" [label="Certificate\nmy-crt\nReady: False (InProgress)"];
+	"CertificateRequest/my-namespace/my-crt-1" [label="CertificateRequest\nmy-crt-1\nReady: False (Pending)"];
+	"Certificate/my-namespace/my-crt" -> "CertificateRequest/my-namespace/my-crt-1" [label="owns"];
+	"Order/my-namespace/my-crt-1-123" [label="Order\nmy-crt-1-123\nState: pending"];
+	"CertificateRequest/my-namespace/my-crt-1" -> "Order/my-namespace/my-crt-1-123" [label="owns"];
+	"Challenge/my-namespace/my-crt-1-123-456" [label="Challenge\nmy-crt-1-123-456\nState: <none>\nType: DNS-01"];
+	"Order/my-namespace/my-crt-1-123" -> "Challenge/my-namespace/my-crt-1-123-456" [label="owns"];
+}
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := writeDot(&out, test.data); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.want, out.String())
+		})
+	}
+}
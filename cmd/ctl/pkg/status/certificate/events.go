@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// eventsCSVHeader are the columns of the CSV written by --export-events-csv.
+var eventsCSVHeader = []string{"source", "type", "reason", "firstSeen", "lastSeen", "count", "message"}
+
+// chainEvent is an event of the Certificate or of one of its related
+// resources, together with the resource it is about.
+type chainEvent struct {
+	// Source is the kind and name of the resource, e.g. "Secret/my-tls"
+	Source string
+	Event  corev1.Event
+}
+
+// mergedEvents returns the events of the Certificate of data, its Issuer,
+// Secret and CertificateRequest, ordered by the time they were last seen.
+// Events seen at the same time keep the order of the resources.
+func mergedEvents(data *Data) []chainEvent {
+	var events []chainEvent
+	add := func(kind, name string, list *corev1.EventList) {
+		if list == nil {
+			return
+		}
+		for _, event := range list.Items {
+			events = append(events, chainEvent{Source: kind + "/" + name, Event: event})
+		}
+	}
+
+	add(cmapi.CertificateKind, data.Certificate.Name, data.CrtEvents)
+	if data.Issuer != nil {
+		kind := cmapi.IssuerKind
+		if data.IssuerKind == cmapi.ClusterIssuerKind {
+			kind = cmapi.ClusterIssuerKind
+		}
+		add(kind, data.Issuer.GetName(), data.IssuerEvents)
+	}
+	if data.Secret != nil {
+		add("Secret", data.Secret.Name, data.SecretEvents)
+	}
+	if data.Req != nil {
+		add(cmapi.CertificateRequestKind, data.Req.Name, data.ReqEvents)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return lastSeen(&events[i].Event).Before(lastSeen(&events[j].Event))
+	})
+	return events
+}
+
+// writeEventsCSV writes events to w as CSV, with a header naming the
+// columns. Times are formatted as RFC3339, and left empty if not set.
+func writeEventsCSV(w io.Writer, events []chainEvent) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(eventsCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range events {
+		count := e.Event.Count
+		if count == 0 && e.Event.Series != nil {
+			count = e.Event.Series.Count
+		}
+		if err := cw.Write([]string{
+			e.Source,
+			e.Event.Type,
+			e.Event.Reason,
+			formatEventTime(firstSeen(&e.Event)),
+			formatEventTime(lastSeen(&e.Event)),
+			strconv.Itoa(int(count)),
+			e.Event.Message,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportEventsCSV writes the merged events of data to the file given by
+// --export-events-csv.
+func (o *Options) exportEventsCSV(data *Data) error {
+	f, err := os.Create(o.ExportEventsCSV)
+	if err != nil {
+		return fmt.Errorf("error when exporting the events: %w", err)
+	}
+	if err := writeEventsCSV(f, mergedEvents(data)); err != nil {
+		f.Close()
+		return fmt.Errorf("error when exporting the events to %q: %w", o.ExportEventsCSV, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error when exporting the events to %q: %w", o.ExportEventsCSV, err)
+	}
+	return nil
+}
+
+// firstSeen returns the time event was first seen. Events recorded by the
+// events.k8s.io API only set the event time.
+func firstSeen(event *corev1.Event) time.Time {
+	if !event.FirstTimestamp.IsZero() {
+		return event.FirstTimestamp.Time
+	}
+	return event.EventTime.Time
+}
+
+// lastSeen returns the time event was last seen.
+func lastSeen(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	return firstSeen(event)
+}
+
+// formatEventTime returns t in RFC3339 format, or an empty string if t is
+// not set.
+func formatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
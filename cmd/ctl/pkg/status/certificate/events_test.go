@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestWriteEventsCSV(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2023, 5, 1, 12, minute, 0, 0, time.UTC))
+	}
+	event := func(eventType, reason, message string, first, last int, count int32) corev1.Event {
+		return corev1.Event{Type: eventType, Reason: reason, Message: message, FirstTimestamp: at(first), LastTimestamp: at(last), Count: count}
+	}
+
+	tests := map[string]struct {
+		data *Data
+		want string
+	}{
+		"Certificate without events": {
+			data: &Data{Certificate: &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "my-crt"}}},
+			want: "source,type,reason,firstSeen,lastSeen,count,message\n",
+		},
+		"events of the chain merged in the order they were last seen": {
+			data: &Data{
+				Certificate: &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "my-crt"}},
+				CrtEvents: &corev1.EventList{Items: []corev1.Event{
+					event("Normal", "Issuing", "Issuing certificate as Secret does not exist", 0, 0, 1),
+					event("Normal", "Issuing", "The certificate has been successfully issued", 5, 5, 1),
+				}},
+				Issuer:     &cmapi.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "letsencrypt"}},
+				IssuerKind: cmapi.ClusterIssuerKind,
+				IssuerEvents: &corev1.EventList{Items: []corev1.Event{
+					event("Warning", "ErrVerifyACMEAccount", "Failed to verify ACME account: \"rate limited\", retrying", 1, 3, 3),
+				}},
+				Secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-tls"}},
+				SecretEvents: &corev1.EventList{Items: []corev1.Event{
+					{Type: "Normal", Reason: "Updated", Message: "Secret updated", EventTime: metav1.NewMicroTime(at(4).Time)},
+				}},
+				Req: &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{Name: "my-crt-1"}},
+				ReqEvents: &corev1.EventList{Items: []corev1.Event{
+					event("Normal", "OrderCreated", "Created Order resource\nmy-crt-1-123", 1, 1, 1),
+				}},
+			},
+			want: `source,type,reason,firstSeen,lastSeen,count,message
+Certificate/my-crt,Normal,Issuing,2023-05-01T12:00:00Z,2023-05-01T12:00:00Z,1,Issuing certificate as Secret does not exist
+CertificateRequest/my-crt-1,Normal,OrderCreated,2023-05-01T12:01:00Z,2023-05-01T12:01:00Z,1,"Created Order resource
+my-crt-1-123"
+ClusterIssuer/letsencrypt,Warning,ErrVerifyACMEAccount,2023-05-01T12:01:00Z,2023-05-01T12:03:00Z,3,"Failed to verify ACME account: ""rate limited"", retrying"
+Secret/my-tls,Normal,Updated,2023-05-01T12:04:00Z,2023-05-01T12:04:00Z,0,Secret updated
+Certificate/my-crt,Normal,Issuing,2023-05-01T12:05:00Z,2023-05-01T12:05:00Z,1,The certificate has been successfully issued
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeEventsCSV(&buf, mergedEvents(test.data)); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/convert"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// readCertificate reads the single Certificate in o.Filename, converting it
+// to cert-manager.io/v1 if it is given in another supported version. If the
+// Certificate doesn't declare its apiVersion, o.AssumeVersion is used.
+func (o *Options) readCertificate() (*cmapi.Certificate, error) {
+	in := o.In
+	if o.Filename != "-" {
+		f, err := os.Open(o.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("error when reading %q: %w", o.Filename, err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var u *unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(in, 4096)
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error when decoding %q: %w", o.Filename, err)
+		}
+		// Skip empty documents
+		if obj == nil {
+			continue
+		}
+		if u != nil {
+			return nil, fmt.Errorf("%q must contain a single Certificate", o.Filename)
+		}
+		u = &unstructured.Unstructured{Object: obj}
+	}
+	if u == nil {
+		return nil, fmt.Errorf("no Certificate found in %q", o.Filename)
+	}
+
+	if len(u.GetAPIVersion()) == 0 {
+		if len(o.AssumeVersion) == 0 {
+			return nil, fmt.Errorf("the Certificate in %q does not declare an apiVersion, set the version to read it in with --assume-version", o.Filename)
+		}
+		u.SetAPIVersion(o.AssumeVersion)
+	}
+	if len(u.GetKind()) == 0 {
+		u.SetKind(cmapi.CertificateKind)
+	}
+
+	obj, err := convert.ToVersion(u, cmapi.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error when converting the Certificate in %q: %w", o.Filename, err)
+	}
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("%q does not contain a Certificate, but a %s", o.Filename, u.GetKind())
+	}
+	return crt, nil
+}
+
+// fileData returns the Data of a Certificate read from a file. As no cluster
+// is queried, the related resources are reported as not looked up.
+func fileData(crt *cmapi.Certificate) *Data {
+	return &Data{
+		Certificate: crt,
+		IssuerError: errors.New("The Issuer was not looked up, as the Certificate was read from a file\n"),
+		SecretError: errors.New("The Secret was not looked up, as the Certificate was read from a file\n"),
+		ReqError:    errors.New("The CertificateRequest was not looked up, as the Certificate was read from a file\n"),
+	}
+}
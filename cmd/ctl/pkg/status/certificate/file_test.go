@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestReadCertificate(t *testing.T) {
+	const v1alpha2Crt = `---
+apiVersion: cert-manager.io/v1alpha2
+kind: Certificate
+metadata:
+  name: my-crt
+  namespace: default
+spec:
+  secretName: my-crt-tls
+  organization: [Example]
+  duration: 2160h
+  issuerRef:
+    name: ca-issuer
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+---
+`
+	const untypedCrt = `metadata:
+  name: my-crt
+spec:
+  secretName: my-crt-tls
+  keyAlgorithm: ecdsa
+  issuerRef:
+    name: ca-issuer
+`
+
+	tests := map[string]struct {
+		input         string
+		assumeVersion string
+		expCrt        *cmapi.Certificate
+		expErrMsg     string
+	}{
+		"Certificate in an older version is converted": {
+			input: v1alpha2Crt,
+			expCrt: &cmapi.Certificate{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-crt", Namespace: "default"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "my-crt-tls",
+					Subject:    &cmapi.X509Subject{Organizations: []string{"Example"}},
+					Duration:   &metav1.Duration{Duration: 2160 * time.Hour},
+					IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer"},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}},
+				},
+			},
+		},
+		"Certificate without a type is read in the assumed version": {
+			input:         untypedCrt,
+			assumeVersion: "cert-manager.io/v1alpha2",
+			expCrt: &cmapi.Certificate{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "Certificate"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-crt"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "my-crt-tls",
+					PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+					IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer"},
+				},
+			},
+		},
+		"Certificate without a type throws error without an assumed version": {
+			input:     untypedCrt,
+			expErrMsg: `the Certificate in "-" does not declare an apiVersion, set the version to read it in with --assume-version`,
+		},
+		"other kinds throw error": {
+			input:     "apiVersion: cert-manager.io/v1\nkind: Issuer\nmetadata:\n  name: ca-issuer\n",
+			expErrMsg: `"-" does not contain a Certificate, but a Issuer`,
+		},
+		"more than one object throws error": {
+			input:     v1alpha2Crt + v1alpha2Crt,
+			expErrMsg: `"-" must contain a single Certificate`,
+		},
+		"empty input throws error": {
+			input:     "---\n",
+			expErrMsg: `no Certificate found in "-"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, in, _, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.input)
+			opts := NewOptions(streams)
+			opts.Filename = "-"
+			opts.AssumeVersion = test.assumeVersion
+
+			crt, err := opts.readCertificate()
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expCrt, crt)
+
+			// The status is printed without any related resources
+			status := StatusFromResources(fileData(crt)).String()
+			if exp := "The Issuer was not looked up, as the Certificate was read from a file\n"; !strings.Contains(status, exp) {
+				t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", exp, status)
+			}
+		})
+	}
+}
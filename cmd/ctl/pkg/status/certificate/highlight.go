@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+)
+
+// expiryHighlight flags the Not After time of a Certificate depending on how
+// soon it expires.
+type expiryHighlight struct {
+	// Certificates expiring within warnWithin are flagged as a warning
+	warnWithin time.Duration
+	// Certificates expiring within criticalWithin, or which have expired,
+	// are flagged as critical
+	criticalWithin time.Duration
+	// If true, the time is colorized, otherwise a textual marker is appended
+	// to flagged times
+	color bool
+	now   time.Time
+}
+
+// format returns notAfter formatted in the same way as formatTimeString,
+// highlighted according to the thresholds. Without colors, only times which
+// are not far enough in the future are marked.
+func (h *expiryHighlight) format(notAfter *metav1.Time) string {
+	formatted := formatTimeString(notAfter)
+	if h == nil || notAfter == nil {
+		return formatted
+	}
+
+	remaining := notAfter.Sub(h.now)
+	switch {
+	case remaining <= h.criticalWithin:
+		if h.color {
+			return util.ColorRed + formatted + util.ColorReset
+		}
+		return formatted + " [CRIT]"
+	case remaining <= h.warnWithin:
+		if h.color {
+			return util.ColorYellow + formatted + util.ColorReset
+		}
+		return formatted + " [WARN]"
+	default:
+		if h.color {
+			return util.ColorGreen + formatted + util.ColorReset
+		}
+		return formatted
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpiryHighlightFormat(t *testing.T) {
+	now := time.Date(2022, 9, 16, 0, 0, 0, 0, time.UTC)
+	notAfter := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+
+	tests := map[string]struct {
+		highlight *expiryHighlight
+		notAfter  *metav1.Time
+		expOutput string
+	}{
+		"without highlighting the time is printed as is": {
+			notAfter:  notAfter(24 * time.Hour),
+			expOutput: "2022-09-17T00:00:00Z",
+		},
+		"missing time is not highlighted": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, color: true, now: now},
+			expOutput: "<none>",
+		},
+		"time beyond the thresholds is printed in green": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, color: true, now: now},
+			notAfter:  notAfter(60 * 24 * time.Hour),
+			expOutput: "\x1b[32m2022-11-15T00:00:00Z\x1b[0m",
+		},
+		"time within the warning threshold is printed in yellow": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, color: true, now: now},
+			notAfter:  notAfter(10 * 24 * time.Hour),
+			expOutput: "\x1b[33m2022-09-26T00:00:00Z\x1b[0m",
+		},
+		"expired time is printed in red": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, color: true, now: now},
+			notAfter:  notAfter(-time.Hour),
+			expOutput: "\x1b[31m2022-09-15T23:00:00Z\x1b[0m",
+		},
+		"time beyond the thresholds is not marked without colors": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, now: now},
+			notAfter:  notAfter(60 * 24 * time.Hour),
+			expOutput: "2022-11-15T00:00:00Z",
+		},
+		"time within the warning threshold is marked without colors": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, now: now},
+			notAfter:  notAfter(10 * 24 * time.Hour),
+			expOutput: "2022-09-26T00:00:00Z [WARN]",
+		},
+		"time within the critical threshold is marked without colors": {
+			highlight: &expiryHighlight{warnWithin: 720 * time.Hour, criticalWithin: 168 * time.Hour, now: now},
+			notAfter:  notAfter(24 * time.Hour),
+			expOutput: "2022-09-17T00:00:00Z [CRIT]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if output := test.highlight.format(test.notAfter); output != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%q\nactual: \n%q", test.expOutput, output)
+			}
+		})
+	}
+}
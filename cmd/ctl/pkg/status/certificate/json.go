@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// structuredFormats are the output formats printing the whole status as a
+// document, rather than rendering a template.
+var structuredFormats = []string{"json", "yaml"}
+
+// isStructuredFormat returns true if format is one of structuredFormats.
+func isStructuredFormat(format string) bool {
+	for _, f := range structuredFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// writeStructured writes status to w as a JSON or YAML document, depending on
+// format. The status is marshalled directly rather than by the printers of
+// k8s.io/cli-runtime, as it is not a Kubernetes object with a kind.
+func writeStructured(w io.Writer, format string, status *CertificateStatus) error {
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(status, "", "    ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = yaml.Marshal(status)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("error when preparing the status for output: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// The status types are serialized to JSON to be rendered by the templated
+// output formats. Errors are not serializable by themselves, so every status
+// with an Error field renders it as an "error" string instead. Similarly, the
+// x509 details of a Secret are rendered the same way as the human readable
+// output does.
+
+func (issuerStatus *IssuerStatus) MarshalJSON() ([]byte, error) {
+	type alias IssuerStatus
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(issuerStatus.Error), (*alias)(issuerStatus)})
+}
+
+func (secretStatus *SecretStatus) MarshalJSON() ([]byte, error) {
+	type alias SecretStatus
+	out := struct {
+		Error              string `json:"error,omitempty"`
+		KeyUsage           string `json:"keyUsage,omitempty"`
+		ExtKeyUsage        string `json:"extKeyUsage,omitempty"`
+		PublicKeyAlgorithm string `json:"publicKeyAlgorithm,omitempty"`
+		SignatureAlgorithm string `json:"signatureAlgorithm,omitempty"`
+		SubjectKeyId       string `json:"subjectKeyId,omitempty"`
+		AuthorityKeyId     string `json:"authorityKeyId,omitempty"`
+		SerialNumber       string `json:"serialNumber,omitempty"`
+		*alias
+	}{
+		Error: errorString(secretStatus.Error),
+		alias: (*alias)(secretStatus),
+	}
+	if secretStatus.Error == nil {
+		extKeyUsageString, err := extKeyUsageToString(secretStatus.ExtKeyUsage)
+		if err != nil {
+			extKeyUsageString = err.Error()
+		}
+		out.KeyUsage = keyUsageToString(secretStatus.KeyUsage)
+		out.ExtKeyUsage = extKeyUsageString
+		out.PublicKeyAlgorithm = secretStatus.PublicKeyAlgorithm.String()
+		out.SignatureAlgorithm = secretStatus.SignatureAlgorithm.String()
+		out.SubjectKeyId = hex.EncodeToString(secretStatus.SubjectKeyId)
+		out.AuthorityKeyId = hex.EncodeToString(secretStatus.AuthorityKeyId)
+		if secretStatus.SerialNumber != nil {
+			out.SerialNumber = hex.EncodeToString(secretStatus.SerialNumber.Bytes())
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (crStatus *CRStatus) MarshalJSON() ([]byte, error) {
+	type alias CRStatus
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(crStatus.Error), (*alias)(crStatus)})
+}
+
+func (csrStatus *CSRStatus) MarshalJSON() ([]byte, error) {
+	type alias CSRStatus
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(csrStatus.Error), (*alias)(csrStatus)})
+}
+
+func (orderStatus *OrderStatus) MarshalJSON() ([]byte, error) {
+	type alias OrderStatus
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(orderStatus.Error), (*alias)(orderStatus)})
+}
+
+func (c *ChallengeStatusList) MarshalJSON() ([]byte, error) {
+	type alias ChallengeStatusList
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(c.Error), (*alias)(c)})
+}
+
+func (report *SchemaReport) MarshalJSON() ([]byte, error) {
+	type alias SchemaReport
+	return json.Marshal(struct {
+		Error string `json:"error,omitempty"`
+		*alias
+	}{errorString(report.Error), (*alias)(report)})
+}
+
+// errorString returns the message of err without the trailing newline used
+// by the human readable output, or an empty string if err is nil.
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return strings.TrimSpace(err.Error())
+}
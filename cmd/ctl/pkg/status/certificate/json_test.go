@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestTemplateOutput(t *testing.T) {
+	status := &CertificateStatus{
+		Name:      "my-crt",
+		Namespace: "my-namespace",
+		Conditions: []cmapi.CertificateCondition{
+			{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+		},
+		IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+		SecretStatus: &SecretStatus{
+			Name:               "my-secret",
+			KeyUsage:           x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			PublicKeyAlgorithm: x509.ECDSA,
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			SerialNumber:       big.NewInt(4096),
+		},
+	}
+
+	tests := map[string]struct {
+		// Output format of the template, go-template if not set
+		format           string
+		template         string
+		allowMissingKeys bool
+
+		expOutput string
+		expErr    bool
+	}{
+		"template renders the status fields": {
+			template:         `{{.name}} {{range .conditions}}{{.type}}={{.status}}{{end}}`,
+			allowMissingKeys: true,
+			expOutput:        "my-crt Ready=True",
+		},
+		"errors are rendered as strings": {
+			template:         `{{.issuer.error}}`,
+			allowMissingKeys: true,
+			expOutput:        "error when getting Issuer: not found",
+		},
+		"x509 details of the Secret are rendered as in the human readable output": {
+			template:         `{{.secret.keyUsage}}; {{.secret.extKeyUsage}}; {{.secret.publicKeyAlgorithm}}; {{.secret.signatureAlgorithm}}; {{.secret.serialNumber}}`,
+			allowMissingKeys: true,
+			expOutput:        "Digital Signature, Key Encipherment; Server Authentication; ECDSA; ECDSA-SHA256; 1000",
+		},
+		"missing key renders no value if allowed": {
+			template:         `{{.renewalTime}}`,
+			allowMissingKeys: true,
+			expOutput:        "<no value>",
+		},
+		"missing key throws error if not allowed": {
+			template:         `{{.renewalTime}}`,
+			allowMissingKeys: false,
+			expErr:           true,
+		},
+		"jsonpath renders the status fields": {
+			format:           "jsonpath",
+			template:         `{.name} {.conditions[?(@.type=="Ready")].status} {.secret.serialNumber}`,
+			allowMissingKeys: true,
+			expOutput:        "my-crt True 1000",
+		},
+		"jsonpath missing key throws error if not allowed": {
+			format:           "jsonpath",
+			template:         `{.renewalTime}`,
+			allowMissingKeys: false,
+			expErr:           true,
+		},
+		"invalid jsonpath expression throws error": {
+			format:   "jsonpath",
+			template: `{.conditions[`,
+			expErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			format := test.format
+			if format == "" {
+				format = "go-template"
+			}
+			opts.Output = format + "=" + test.template
+			*opts.TemplateFlags.AllowMissingKeys = test.allowMissingKeys
+			if err := opts.Complete(); err != nil {
+				if test.expErr {
+					return
+				}
+				t.Fatal(err)
+			}
+
+			obj, err := util.ToUnstructured(status)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			err = opts.Printer.PrintObj(obj, &buf)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	status := &CertificateStatus{
+		Name:      "my-crt",
+		Namespace: "my-namespace",
+		Conditions: []cmapi.CertificateCondition{
+			{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+		},
+		IssuerStatus: &IssuerStatus{Error: errors.New("error when getting Issuer: not found\n")},
+	}
+
+	tests := map[string]struct {
+		format string
+
+		expOutput string
+	}{
+		"json prints the status as an indented document": {
+			format: "json",
+			expOutput: `{
+    "name": "my-crt",
+    "namespace": "my-namespace",
+    "creationTime": null,
+    "conditions": [
+        {
+            "type": "Ready",
+            "status": "True"
+        }
+    ],
+    "issuer": {
+        "error": "error when getting Issuer: not found"
+    }
+}
+`,
+		},
+		"yaml prints the status as a document": {
+			format: "yaml",
+			expOutput: `conditions:
+- status: "True"
+  type: Ready
+creationTime: null
+issuer:
+  error: 'error when getting Issuer: not found'
+name: my-crt
+namespace: my-namespace
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			opts.Output = test.format
+			if err := opts.Complete(); err != nil {
+				t.Fatal(err)
+			}
+			assert.Nil(t, opts.Printer)
+
+			var buf bytes.Buffer
+			assert.NoError(t, writeStructured(&buf, opts.Output, status))
+			assert.Equal(t, test.expOutput, buf.String())
+		})
+	}
+}
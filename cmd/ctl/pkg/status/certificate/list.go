@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// listing returns true if the status of multiple Certificates should be
+// printed as a table instead of the status of a single Certificate.
+func (o *Options) listing() bool {
+	return o.All || o.AllNamespaces || len(o.LabelSelector) > 0
+}
+
+// runList prints a table with the status of all Certificates matching the options.
+func (o *Options) runList(ctx context.Context) error {
+	if o.ServerPrint {
+		headers, rows, err := o.serverCertificatesTable(ctx, o.CMClient.CertmanagerV1().RESTClient())
+		switch {
+		case errors.Is(err, util.ErrServerPrintUnsupported):
+			// Fall back to rendering the table client-side
+		case err != nil:
+			return err
+		case len(rows) == 0 && !o.AsTable:
+			o.printNoCertificatesFound()
+			return nil
+		default:
+			return o.writeTable(headers, rows)
+		}
+	}
+
+	crts, err := o.listCertificates(ctx)
+	if err != nil {
+		return err
+	}
+	// An empty Table object is printed so that tools consuming it don't
+	// have to handle missing output.
+	if len(crts) == 0 && !o.AsTable {
+		return nil
+	}
+
+	headers, rows := o.certificatesTable(crts)
+	if err := o.writeTable(headers, rows); err != nil {
+		return err
+	}
+	if !o.AsTable {
+		fmt.Fprintln(o.Out, certificatesSummary(crts, clock.Now(), o.ExpiringWithin))
+	}
+	return nil
+}
+
+// certificatesSummary returns a line counting crts by their Ready condition
+// and the ones expiring within expiringWithin as of now, e.g.
+// "24 certificates: 20 Ready, 3 not Ready, 1 expiring soon".
+func certificatesSummary(crts []cmapi.Certificate, now time.Time, expiringWithin time.Duration) string {
+	ready, expiring := 0, 0
+	for i := range crts {
+		crt := &crts[i]
+		if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}) {
+			ready++
+		}
+		if notAfter := crt.Status.NotAfter; notAfter != nil && notAfter.Time.Before(now.Add(expiringWithin)) {
+			expiring++
+		}
+	}
+
+	noun := "certificates"
+	if len(crts) == 1 {
+		noun = "certificate"
+	}
+	return fmt.Sprintf("%d %s: %d Ready, %d not Ready, %d expiring soon", len(crts), noun, ready, len(crts)-ready, expiring)
+}
+
+// writeTable writes the table listing the Certificates to o.Out, as a Table
+// object if --as-table is set.
+func (o *Options) writeTable(headers []string, rows [][]string) error {
+	if o.AsTable {
+		return util.WriteTableObject(o.Out, headers, rows)
+	}
+	return util.WriteTable(o.Out, headers, rows)
+}
+
+// listCertificates returns all Certificates matching the options. If none are
+// found, a message saying so is printed.
+func (o *Options) listCertificates(ctx context.Context) ([]cmapi.Certificate, error) {
+	ns := o.Namespace
+	if o.AllNamespaces {
+		ns = metav1.NamespaceAll
+	}
+
+	crtsList, err := o.CMClient.CertmanagerV1().Certificates(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+
+	if len(crtsList.Items) == 0 {
+		o.printNoCertificatesFound()
+	}
+
+	return crtsList.Items, nil
+}
+
+// serverCertificatesTable returns the headers and rows of the table listing
+// the Certificates matching the options, as rendered by the API server.
+func (o *Options) serverCertificatesTable(ctx context.Context, client rest.Interface) ([]string, [][]string, error) {
+	ns := o.Namespace
+	if o.AllNamespaces {
+		ns = metav1.NamespaceAll
+	}
+
+	headers, rows, err := util.ServerTable(ctx, client, "certificates", ns, metav1.ListOptions{
+		LabelSelector: o.LabelSelector,
+	}, o.AllNamespaces, o.LabelColumnOptions)
+	if err != nil && !errors.Is(err, util.ErrServerPrintUnsupported) {
+		return nil, nil, fmt.Errorf("error when listing Certificate resources: %w", err)
+	}
+	return headers, rows, err
+}
+
+// printNoCertificatesFound prints a message saying that no Certificates are
+// matching the options.
+func (o *Options) printNoCertificatesFound() {
+	if o.AllNamespaces {
+		fmt.Fprintln(o.ErrOut, "No Certificates found")
+	} else {
+		fmt.Fprintf(o.ErrOut, "No Certificates found in %s namespace.\n", o.Namespace)
+	}
+}
+
+// sortCertificates sorts crts by namespace and name.
+func sortCertificates(crts []cmapi.Certificate) {
+	sort.Slice(crts, func(i, j int) bool {
+		if crts[i].Namespace != crts[j].Namespace {
+			return crts[i].Namespace < crts[j].Namespace
+		}
+		return crts[i].Name < crts[j].Name
+	})
+}
+
+// certificatesTable returns the headers and rows of the table listing crts,
+// sorted by namespace and name.
+func (o *Options) certificatesTable(crts []cmapi.Certificate) ([]string, [][]string) {
+	sortCertificates(crts)
+
+	headers := []string{"NAME", "READY", "SECRET", "ISSUER", "NOT AFTER", "RENEWAL TIME", "AGE"}
+	if o.AllNamespaces {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	headers = o.LabelColumnOptions.Headers(headers)
+
+	rows := make([][]string, 0, len(crts))
+	for i := range crts {
+		crt := &crts[i]
+
+		ready := string(metav1.ConditionUnknown)
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady); cond != nil {
+			ready = string(cond.Status)
+		}
+
+		row := []string{
+			crt.Name,
+			ready,
+			crt.Spec.SecretName,
+			util.IssuerRefWithDefaults(crt).Kind + "/" + crt.Spec.IssuerRef.Name,
+			formatTimeString(crt.Status.NotAfter),
+			formatTimeString(crt.Status.RenewalTime),
+			util.TranslateTimestampSince(crt.CreationTimestamp),
+		}
+		if o.AllNamespaces {
+			row = append([]string{crt.Namespace}, row...)
+		}
+		rows = append(rows, o.LabelColumnOptions.Row(row, crt.Labels))
+	}
+
+	return headers, rows
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		opts      *Options
+		inputArgs []string
+		expErrMsg string
+	}{
+		"no name passed as arg throws error": {
+			opts:      &Options{},
+			expErrMsg: "the name of the Certificate has to be provided as argument",
+		},
+		"more than one arg throws error": {
+			opts:      &Options{},
+			inputArgs: []string{"crt-1", "crt-2"},
+			expErrMsg: "only one argument can be passed in: the name of the Certificate",
+		},
+		"name passed in conjunction with --all throws error": {
+			opts:      &Options{All: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "cannot specify a Certificate name in conjunction with the --selector, --all or --all-namespaces flags",
+		},
+		"label columns without listing throws error": {
+			opts:      &Options{LabelColumnOptions: util.LabelColumnOptions{LabelColumns: []string{"team"}}},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --show-labels and --label-columns flags can only be used when listing Certificates with the --selector, --all or --all-namespaces flags",
+		},
+		"label columns in conjunction with metrics throws error": {
+			opts:      &Options{All: true, Metrics: true, LabelColumnOptions: util.LabelColumnOptions{ShowLabels: true}},
+			expErrMsg: "the --show-labels and --label-columns flags cannot be used in conjunction with the --metrics flag",
+		},
+		"--show-csr in conjunction with --all throws error": {
+			opts:      &Options{All: true, ShowCSR: true},
+			expErrMsg: "the --show-csr flag can only be used when printing the status of a single Certificate",
+		},
+		"--wait-ready in conjunction with --all throws error": {
+			opts:      &Options{All: true, WaitReady: true},
+			expErrMsg: "the --wait-ready flag can only be used when printing the status of a single Certificate",
+		},
+		"--watch in conjunction with --all throws error": {
+			opts:      &Options{All: true, Watch: true, WatchInterval: time.Second},
+			expErrMsg: "the --watch flag can only be used when printing the status of a single Certificate",
+		},
+		"--watch in conjunction with --wait-ready throws error": {
+			opts:      &Options{Watch: true, WatchInterval: time.Second, WaitReady: true},
+			inputArgs: []string{"my-crt"},
+			expErrMsg: "the --watch flag cannot be used in conjunction with the --output, --wait-ready, --diagnose-dns01, --json-schema-report or --filename flags",
+		},
+		"--watch with non-positive --watch-interval throws error": {
+			opts:      &Options{Watch: true},
+			inputArgs: []string{"my-crt"},
+			expErrMsg: "the --watch-interval flag must be greater than 0",
+		},
+		"--then-inspect without --wait-ready throws error": {
+			opts:      &Options{ThenInspect: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --then-inspect flag must be used in conjunction with --wait-ready",
+		},
+		"--then-inspect in conjunction with --output throws error": {
+			opts:      &Options{WaitReady: true, ThenInspect: true, Output: "json"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --then-inspect flag cannot be used in conjunction with the --output or --show-csr flags",
+		},
+		"--server-print without listing throws error": {
+			opts:      &Options{ServerPrint: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --server-print flag can only be used when listing Certificates with the --selector, --all or --all-namespaces flags",
+		},
+		"--filename in conjunction with --all throws error": {
+			opts:      &Options{All: true, Filename: "crt.yaml"},
+			expErrMsg: "the --filename flag can only be used when printing the status of a single Certificate",
+		},
+		"--filename in conjunction with --wait-ready throws error": {
+			opts:      &Options{Filename: "crt.yaml", WaitReady: true},
+			expErrMsg: "the --filename flag cannot be used in conjunction with the --wait-ready, --diagnose-dns01 or --show-csr flags",
+		},
+		"--assume-version without --filename throws error": {
+			opts:      &Options{AssumeVersion: "cert-manager.io/v1alpha2"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --assume-version flag must be used in conjunction with --filename",
+		},
+		"--filename in conjunction with a name throws error": {
+			opts:      &Options{Filename: "crt.yaml"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "cannot specify a Certificate name in conjunction with the --filename flag",
+		},
+		"--filename without a name is valid": {
+			opts: &Options{Filename: "crt.yaml", AssumeVersion: "cert-manager.io/v1alpha2"},
+		},
+		"--json-schema-report in conjunction with --all throws error": {
+			opts:      &Options{All: true, SchemaReport: true},
+			expErrMsg: "the --json-schema-report flag can only be used when printing the status of a single Certificate",
+		},
+		"--json-schema-report in conjunction with --filename throws error": {
+			opts:      &Options{Filename: "crt.yaml", SchemaReport: true},
+			expErrMsg: "the --json-schema-report flag cannot be used in conjunction with the --filename, --diagnose-dns01 or --then-inspect flags",
+		},
+		"--profile in conjunction with --all throws error": {
+			opts:      &Options{All: true, Profile: true},
+			expErrMsg: "the --profile flag can only be used when printing the status of a single Certificate",
+		},
+		"--profile in conjunction with --watch throws error": {
+			opts:      &Options{Watch: true, WatchInterval: time.Second, Profile: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --profile flag cannot be used in conjunction with the --watch, --diagnose-dns01, --then-inspect or --filename flags",
+		},
+		"negative --expiring-within throws error": {
+			opts:      &Options{All: true, ExpiringWithin: -time.Hour},
+			expErrMsg: "the --expiring-within duration must not be negative",
+		},
+		"--as-table without listing throws error": {
+			opts:      &Options{AsTable: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --as-table flag can only be used when listing Certificates with the --selector, --all or --all-namespaces flags",
+		},
+		"--as-table in conjunction with --metrics throws error": {
+			opts:      &Options{All: true, AsTable: true, Metrics: true},
+			expErrMsg: "the --as-table flag can only be used when listing Certificates with the --selector, --all or --all-namespaces flags",
+		},
+		"--diagnose-dns01 in conjunction with --all throws error": {
+			opts:      &Options{All: true, DiagnoseDNS01: true},
+			expErrMsg: "the --diagnose-dns01 flag can only be used when printing the status of a single Certificate",
+		},
+		"--diagnose-dns01 in conjunction with --output throws error": {
+			opts:      &Options{DiagnoseDNS01: true, Output: "json"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --diagnose-dns01 flag cannot be used in conjunction with the --output, --show-csr or --wait-ready flags",
+		},
+		"--resolve without --diagnose-dns01 throws error": {
+			opts:      &Options{Resolve: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --resolve flag must be used in conjunction with --diagnose-dns01",
+		},
+		"--resolve-cname without --diagnose-dns01 throws error": {
+			opts:      &Options{ResolveCNAME: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --resolve-cname flag must be used in conjunction with --diagnose-dns01",
+		},
+		"--resolve-cname without nameservers throws error": {
+			opts:      &Options{DiagnoseDNS01: true, ResolveCNAME: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --resolve and --resolve-cname flags require at least one nameserver given by --dns01-nameservers",
+		},
+		"--resolve without nameservers throws error": {
+			opts:      &Options{DiagnoseDNS01: true, Resolve: true},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --resolve and --resolve-cname flags require at least one nameserver given by --dns01-nameservers",
+		},
+		"--highlight-expiry in conjunction with --all throws error": {
+			opts:      &Options{All: true, HighlightExpiry: true},
+			expErrMsg: "the --highlight-expiry flag can only be used when printing the status of a single Certificate",
+		},
+		"--highlight-expiry in conjunction with --output throws error": {
+			opts:      &Options{HighlightExpiry: true, Output: "json"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --highlight-expiry flag cannot be used in conjunction with the --output, --diagnose-dns01 or --then-inspect flags",
+		},
+		"--since-last-transition in conjunction with --all throws error": {
+			opts:      &Options{All: true, SinceLastTransition: true},
+			expErrMsg: "the --since-last-transition flag can only be used when printing the status of a single Certificate",
+		},
+		"--since-last-transition in conjunction with --output throws error": {
+			opts:      &Options{SinceLastTransition: true, Output: "json"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --since-last-transition flag cannot be used in conjunction with the --output, --diagnose-dns01 or --then-inspect flags",
+		},
+		"--include-issuer in conjunction with --all throws error": {
+			opts:      &Options{All: true, IncludeIssuer: true},
+			expErrMsg: "the --include-issuer flag can only be used when printing the status of a single Certificate",
+		},
+		"--include-issuer in conjunction with --output throws error": {
+			opts:      &Options{IncludeIssuer: true, Output: "json"},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --include-issuer flag cannot be used in conjunction with the --output, --diagnose-dns01 or --filename flags",
+		},
+		"--critical-within longer than --warn-within throws error": {
+			opts:      &Options{HighlightExpiry: true, WarnWithin: time.Hour, CriticalWithin: 2 * time.Hour},
+			inputArgs: []string{"crt-1"},
+			expErrMsg: "the --critical-within duration must not be longer than the --warn-within duration",
+		},
+		"single name is valid": {
+			opts:      &Options{},
+			inputArgs: []string{"crt-1"},
+		},
+		"selector with label columns is valid": {
+			opts:      &Options{LabelSelector: "app=foo", LabelColumnOptions: util.LabelColumnOptions{ShowLabels: true}},
+			inputArgs: []string{},
+		},
+		"--all with --server-print is valid": {
+			opts: &Options{All: true, ServerPrint: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.opts.Validate(test.inputArgs)
+			if test.expErrMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErrMsg)
+			}
+		})
+	}
+}
+
+func TestCertificatesTable(t *testing.T) {
+	notAfter := metav1.NewTime(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	renewalTime := metav1.NewTime(time.Date(2022, 1, 30, 0, 0, 0, 0, time.UTC))
+	crts := func() []cmapi.Certificate {
+		return []cmapi.Certificate{
+			*gen.Certificate("crt-2",
+				gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-2"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer"}),
+			),
+			*gen.Certificate("crt-1",
+				gen.SetCertificateNamespace("ns-1"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer"}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				gen.SetCertificateNotAfter(notAfter),
+				gen.SetCertificateRenewalTime(renewalTime),
+				gen.AddCertificateLabels(map[string]string{"team": "payments"}),
+			),
+		}
+	}
+
+	tests := map[string]struct {
+		opts       *Options
+		expHeaders []string
+		expRows    [][]string
+	}{
+		"Certificates are sorted by name": {
+			opts:       &Options{All: true},
+			expHeaders: []string{"NAME", "READY", "SECRET", "ISSUER", "NOT AFTER", "RENEWAL TIME", "AGE"},
+			expRows: [][]string{
+				{"crt-1", "True", "secret-1", "Issuer/ca-issuer", "2022-03-01T00:00:00Z", "2022-01-30T00:00:00Z", "<unknown>"},
+				{"crt-2", "Unknown", "secret-2", "ClusterIssuer/letsencrypt", "<none>", "<none>", "<unknown>"},
+			},
+		},
+		"all namespaces adds a namespace column and label columns are appended": {
+			opts:       &Options{AllNamespaces: true, LabelColumnOptions: util.LabelColumnOptions{LabelColumns: []string{"team"}}},
+			expHeaders: []string{"NAMESPACE", "NAME", "READY", "SECRET", "ISSUER", "NOT AFTER", "RENEWAL TIME", "AGE", "TEAM"},
+			expRows: [][]string{
+				{"ns-1", "crt-1", "True", "secret-1", "Issuer/ca-issuer", "2022-03-01T00:00:00Z", "2022-01-30T00:00:00Z", "<unknown>", "payments"},
+				{"ns-1", "crt-2", "Unknown", "secret-2", "ClusterIssuer/letsencrypt", "<none>", "<none>", "<unknown>", ""},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			headers, rows := test.opts.certificatesTable(crts())
+			assert.Equal(t, test.expHeaders, headers)
+			assert.Equal(t, test.expRows, rows)
+		})
+	}
+}
+
+func TestCertificatesSummary(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	ready := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue})
+	notReady := gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse})
+	expiresIn := func(d time.Duration) gen.CertificateModifier {
+		return gen.SetCertificateNotAfter(metav1.NewTime(now.Add(d)))
+	}
+
+	tests := map[string]struct {
+		crts           []cmapi.Certificate
+		expiringWithin time.Duration
+		expSummary     string
+	}{
+		"no Certificates": {
+			expiringWithin: 30 * 24 * time.Hour,
+			expSummary:     "0 certificates: 0 Ready, 0 not Ready, 0 expiring soon",
+		},
+		"a single Certificate": {
+			crts:           []cmapi.Certificate{*gen.Certificate("crt-1", ready, expiresIn(60*24*time.Hour))},
+			expiringWithin: 30 * 24 * time.Hour,
+			expSummary:     "1 certificate: 1 Ready, 0 not Ready, 0 expiring soon",
+		},
+		"Certificates without Ready condition are not Ready": {
+			crts: []cmapi.Certificate{
+				*gen.Certificate("crt-1", ready, expiresIn(60*24*time.Hour)),
+				*gen.Certificate("crt-2", ready, expiresIn(10*24*time.Hour)),
+				*gen.Certificate("crt-3", notReady, expiresIn(-time.Hour)),
+				*gen.Certificate("crt-4"),
+			},
+			expiringWithin: 30 * 24 * time.Hour,
+			expSummary:     "4 certificates: 2 Ready, 2 not Ready, 2 expiring soon",
+		},
+		"expiring soon respects --expiring-within": {
+			crts: []cmapi.Certificate{
+				*gen.Certificate("crt-1", ready, expiresIn(60*24*time.Hour)),
+				*gen.Certificate("crt-2", ready, expiresIn(10*24*time.Hour)),
+			},
+			expiringWithin: 90 * 24 * time.Hour,
+			expSummary:     "2 certificates: 2 Ready, 0 not Ready, 2 expiring soon",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expSummary, certificatesSummary(test.crts, now, test.expiringWithin))
+		})
+	}
+}
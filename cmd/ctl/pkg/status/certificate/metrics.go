@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclock "k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+// labelValueEscaper escapes label values as required by the Prometheus text format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// runMetrics prints Prometheus gauges for the Certificate named in args, or
+// all Certificates matching the options when listing.
+func (o *Options) runMetrics(ctx context.Context, args []string) error {
+	var crts []cmapi.Certificate
+	if o.listing() {
+		var err error
+		crts, err = o.listCertificates(ctx)
+		if err != nil {
+			return err
+		}
+	} else {
+		crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error when getting Certificate resource: %v", err)
+		}
+		crts = append(crts, *crt)
+	}
+
+	if len(crts) == 0 {
+		return nil
+	}
+
+	sortCertificates(crts)
+	_, err := io.WriteString(o.Out, certificatesMetrics(crts))
+	return err
+}
+
+// certificatesMetrics returns gauges for the readiness, seconds until expiry
+// and seconds until renewal of crts in the Prometheus text format. Every
+// series is labeled with the Certificate and its issuer. The expiry and
+// renewal series are omitted for Certificates which have not been issued yet.
+func certificatesMetrics(crts []cmapi.Certificate) string {
+	var ready, expiry, renewal strings.Builder
+	now := clock.Now()
+	for i := range crts {
+		crt := &crts[i]
+		labels := certificateMetricLabels(crt)
+
+		readyValue := 0
+		if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+			Type:   cmapi.CertificateConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}) {
+			readyValue = 1
+		}
+		fmt.Fprintf(&ready, "cmctl_certificate_ready%s %d\n", labels, readyValue)
+
+		if crt.Status.NotAfter != nil {
+			fmt.Fprintf(&expiry, "cmctl_certificate_seconds_until_expiry%s %d\n", labels,
+				int64(crt.Status.NotAfter.Sub(now).Seconds()))
+		}
+		if crt.Status.RenewalTime != nil {
+			fmt.Fprintf(&renewal, "cmctl_certificate_seconds_until_renewal%s %d\n", labels,
+				int64(crt.Status.RenewalTime.Sub(now).Seconds()))
+		}
+	}
+
+	var b strings.Builder
+	writeMetricFamily(&b, "cmctl_certificate_ready", "Whether the Certificate is Ready (1) or not (0).", ready.String())
+	writeMetricFamily(&b, "cmctl_certificate_seconds_until_expiry", "The number of seconds until the issued certificate expires.", expiry.String())
+	writeMetricFamily(&b, "cmctl_certificate_seconds_until_renewal", "The number of seconds until the Certificate will be renewed.", renewal.String())
+	return b.String()
+}
+
+// writeMetricFamily writes the HELP and TYPE lines of a gauge followed by
+// its series to b, if there are any series.
+func writeMetricFamily(b *strings.Builder, name, help, series string) {
+	if series == "" {
+		return
+	}
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	b.WriteString(series)
+}
+
+// certificateMetricLabels returns the label set identifying crt, matching the
+// labels of the metrics exposed by the cert-manager controller.
+func certificateMetricLabels(crt *cmapi.Certificate) string {
+	labels := [][2]string{
+		{"name", crt.Name},
+		{"namespace", crt.Namespace},
+		{"issuer_name", crt.Spec.IssuerRef.Name},
+		{"issuer_kind", crt.Spec.IssuerRef.Kind},
+		{"issuer_group", crt.Spec.IssuerRef.Group},
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for _, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label[0], labelValueEscaper.Replace(label[1])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
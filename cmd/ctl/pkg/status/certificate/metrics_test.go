@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCertificatesMetrics(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock = fakeclock.NewFakeClock(now)
+
+	issued := gen.Certificate("issued",
+		gen.SetCertificateNamespace("ns-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer", Group: "cert-manager.io"}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+		gen.SetCertificateNotAfter(metav1.NewTime(now.Add(90*24*time.Hour))),
+		gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(60*24*time.Hour))),
+	)
+	expired := gen.Certificate("expired",
+		gen.SetCertificateNamespace("ns-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse}),
+		gen.SetCertificateNotAfter(metav1.NewTime(now.Add(-time.Hour))),
+		gen.SetCertificateRenewalTime(metav1.NewTime(now.Add(-2*time.Hour))),
+	)
+	pending := gen.Certificate("pending",
+		gen.SetCertificateNamespace("ns-2"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer"}),
+	)
+
+	tests := map[string]struct {
+		crts      []cmapi.Certificate
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"Issued Certificate has all gauges": {
+			crts: []cmapi.Certificate{*issued},
+			expOutput: `# HELP cmctl_certificate_ready Whether the Certificate is Ready (1) or not (0).
+# TYPE cmctl_certificate_ready gauge
+cmctl_certificate_ready{name="issued",namespace="ns-1",issuer_name="letsencrypt",issuer_kind="ClusterIssuer",issuer_group="cert-manager.io"} 1
+# HELP cmctl_certificate_seconds_until_expiry The number of seconds until the issued certificate expires.
+# TYPE cmctl_certificate_seconds_until_expiry gauge
+cmctl_certificate_seconds_until_expiry{name="issued",namespace="ns-1",issuer_name="letsencrypt",issuer_kind="ClusterIssuer",issuer_group="cert-manager.io"} 7776000
+# HELP cmctl_certificate_seconds_until_renewal The number of seconds until the Certificate will be renewed.
+# TYPE cmctl_certificate_seconds_until_renewal gauge
+cmctl_certificate_seconds_until_renewal{name="issued",namespace="ns-1",issuer_name="letsencrypt",issuer_kind="ClusterIssuer",issuer_group="cert-manager.io"} 5184000
+`,
+		},
+		"Multiple Certificates are grouped per gauge and unissued Certificates only report readiness": {
+			crts: []cmapi.Certificate{*expired, *pending},
+			expOutput: `# HELP cmctl_certificate_ready Whether the Certificate is Ready (1) or not (0).
+# TYPE cmctl_certificate_ready gauge
+cmctl_certificate_ready{name="expired",namespace="ns-1",issuer_name="ca-issuer",issuer_kind="Issuer",issuer_group=""} 0
+cmctl_certificate_ready{name="pending",namespace="ns-2",issuer_name="ca-issuer",issuer_kind="Issuer",issuer_group=""} 0
+# HELP cmctl_certificate_seconds_until_expiry The number of seconds until the issued certificate expires.
+# TYPE cmctl_certificate_seconds_until_expiry gauge
+cmctl_certificate_seconds_until_expiry{name="expired",namespace="ns-1",issuer_name="ca-issuer",issuer_kind="Issuer",issuer_group=""} -3600
+# HELP cmctl_certificate_seconds_until_renewal The number of seconds until the Certificate will be renewed.
+# TYPE cmctl_certificate_seconds_until_renewal gauge
+cmctl_certificate_seconds_until_renewal{name="expired",namespace="ns-1",issuer_name="ca-issuer",issuer_kind="Issuer",issuer_group=""} -7200
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actualOutput := certificatesMetrics(test.crts); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
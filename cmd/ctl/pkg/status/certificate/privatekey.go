@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// unavailableKeyType is the private key type printed if the private key of
+// the Secret is not set or cannot be parsed.
+const unavailableKeyType = "<unavailable>"
+
+// withPrivateKey sets the type of the private key stored in the Secret, and
+// compares it to the key requested by the Certificate. Failing to parse the
+// key is not an error, the type of the key is <unavailable> then.
+func (status *CertificateStatus) withPrivateKey(crt *cmapi.Certificate, secret *v1.Secret) *CertificateStatus {
+	if status.SecretStatus == nil || status.SecretStatus.Error != nil || secret == nil {
+		return status
+	}
+
+	status.SecretStatus.PrivateKeyType = unavailableKeyType
+	key, err := pki.DecodePrivateKeyBytes(secret.Data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return status
+	}
+	status.SecretStatus.PrivateKeyType = publicKeyType(key.Public())
+
+	if expected := requestedKeyType(crt); len(expected) > 0 && expected != status.SecretStatus.PrivateKeyType {
+		status.SecretStatus.PrivateKeyMismatch = fmt.Sprintf("the private key is %s, but the Certificate requests %s",
+			status.SecretStatus.PrivateKeyType, expected)
+	}
+	return status
+}
+
+// requestedKeyType returns the type of the private key requested by the
+// spec.privateKey of crt, in the format of publicKeyType, taking the
+// defaults of the algorithm and size into account. An empty string is
+// returned for unknown algorithms.
+func requestedKeyType(crt *cmapi.Certificate) string {
+	var algorithm cmapi.PrivateKeyAlgorithm
+	var size int
+	if crt.Spec.PrivateKey != nil {
+		algorithm, size = crt.Spec.PrivateKey.Algorithm, crt.Spec.PrivateKey.Size
+	}
+
+	switch algorithm {
+	case "", cmapi.RSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.MinRSAKeySize
+		}
+		return fmt.Sprintf("RSA %d", size)
+	case cmapi.ECDSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.ECCurve256
+		}
+		return fmt.Sprintf("ECDSA P-%d", size)
+	case cmapi.Ed25519KeyAlgorithm:
+		return "Ed25519"
+	default:
+		return ""
+	}
+}
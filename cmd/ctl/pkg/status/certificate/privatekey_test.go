@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWithPrivateKey(t *testing.T) {
+	ecKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM, err := pki.EncodePKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		crt     *cmapi.Certificate
+		keyData []byte
+
+		expKeyType  string
+		expMismatch string
+	}{
+		"key matching the requested algorithm and size": {
+			crt:        gen.Certificate("test", gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm)),
+			keyData:    ecKeyPEM,
+			expKeyType: "ECDSA P-256",
+		},
+		"key not matching the requested size": {
+			crt:         gen.Certificate("test", gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm), gen.SetCertificateKeySize(384)),
+			keyData:     ecKeyPEM,
+			expKeyType:  "ECDSA P-256",
+			expMismatch: "the private key is ECDSA P-256, but the Certificate requests ECDSA P-384",
+		},
+		"key not matching the default algorithm": {
+			crt:         gen.Certificate("test"),
+			keyData:     ecKeyPEM,
+			expKeyType:  "ECDSA P-256",
+			expMismatch: "the private key is ECDSA P-256, but the Certificate requests RSA 2048",
+		},
+		"missing key is unavailable": {
+			crt:        gen.Certificate("test"),
+			expKeyType: "<unavailable>",
+		},
+		"key which cannot be parsed is unavailable": {
+			crt:        gen.Certificate("test"),
+			keyData:    []byte("not a key"),
+			expKeyType: "<unavailable>",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret := gen.Secret("test-tls", gen.SetSecretData(map[string][]byte{corev1.TLSPrivateKeyKey: test.keyData}))
+			status := (&CertificateStatus{SecretStatus: &SecretStatus{Name: "test-tls"}}).withPrivateKey(test.crt, secret)
+
+			assert.Equal(t, test.expKeyType, status.SecretStatus.PrivateKeyType)
+			assert.Equal(t, test.expMismatch, status.SecretStatus.PrivateKeyMismatch)
+		})
+	}
+}
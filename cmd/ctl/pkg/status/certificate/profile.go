@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+)
+
+// profile records how long the API calls made to gather the resources of a
+// Certificate took, printed by --profile. A nil profile records nothing.
+type profile struct {
+	steps []profileStep
+}
+
+type profileStep struct {
+	name     string
+	duration time.Duration
+}
+
+// record adds the time elapsed since start as the duration of the step.
+func (p *profile) record(name string, start time.Time) {
+	if p == nil {
+		return
+	}
+	p.steps = append(p.steps, profileStep{name: name, duration: clock.Since(start)})
+}
+
+// write writes the duration of every step in the order they were made,
+// followed by their total.
+func (p *profile) write(out io.Writer) error {
+	var buf bytes.Buffer
+	tabWriter := util.NewTabWriter(&buf)
+	w := util.NewPrefixWriter(tabWriter)
+	w.Write(0, "Profile:\n")
+	var total time.Duration
+	for _, step := range p.steps {
+		total += step.duration
+		w.Write(1, "%s:\t%s\n", step.name, step.duration.Round(time.Microsecond))
+	}
+	w.Write(1, "Total:\t%s\n", total.Round(time.Microsecond))
+	if err := tabWriter.Flush(); err != nil {
+		return err
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
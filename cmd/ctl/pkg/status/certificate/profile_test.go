@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProfileWrite(t *testing.T) {
+	p := &profile{steps: []profileStep{
+		{name: "Get Certificate", duration: 12 * time.Millisecond},
+		{name: "List CertificateRequests", duration: 1500 * time.Millisecond},
+		{name: "Search Certificate events", duration: 1234567 * time.Nanosecond},
+	}}
+
+	var out bytes.Buffer
+	if err := p.write(&out); err != nil {
+		t.Fatal(err)
+	}
+	exp := `Profile:
+  Get Certificate:            12ms
+  List CertificateRequests:   1.5s
+  Search Certificate events:  1.235ms
+  Total:                      1.513235s
+`
+	if out.String() != exp {
+		t.Errorf("unexpected output; expected: \n%s\nactual: \n%s", exp, out.String())
+	}
+}
+
+func TestProfileGetResources(t *testing.T) {
+	clock = fakeclock.NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "crt-tls", Namespace: "default"}}
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("crt-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: "Issuer"}),
+		gen.SetCertificateUID("crt-uid"),
+	)
+	req := gen.CertificateRequest("crt-1",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestRevision("1"),
+		gen.AddCertificateRequestOwnerReferences(gen.CertificateRef("crt", "crt-uid")),
+	)
+	req.UID = "req-uid"
+	order := gen.Order("crt-1-order",
+		gen.SetOrderNamespace("default"),
+		gen.SetOrderOwnerReference(*metav1.NewControllerRef(req, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))),
+	)
+
+	tests := map[string]struct {
+		issuerModifier gen.IssuerModifier
+		expSteps       []string
+	}{
+		"ACME Issuer lists Orders and Challenges": {
+			issuerModifier: gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+			expSteps: []string{
+				"Get Certificate",
+				"Search Certificate events",
+				"Get Issuer",
+				"Search Issuer events",
+				"Get Secret",
+				"Search Secret events",
+				"List CertificateRequests",
+				"Search CertificateRequest events",
+				"List Orders",
+				"List Challenges",
+			},
+		},
+		"CA Issuer does not list Orders": {
+			issuerModifier: gen.SetIssuerCASecretName("ca"),
+			expSteps: []string{
+				"Get Certificate",
+				"Search Certificate events",
+				"Get Issuer",
+				"Search Issuer events",
+				"Get Secret",
+				"Search Secret events",
+				"List CertificateRequests",
+				"Search CertificateRequest events",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("issuer", gen.SetIssuerNamespace("default"), test.issuerModifier)
+			o := &Options{
+				Factory: &factory.Factory{
+					CMClient:   cmfake.NewSimpleClientset(crt, issuer, req, order),
+					KubeClient: kubefake.NewSimpleClientset(secret),
+					Namespace:  "default",
+				},
+				profile: &profile{},
+			}
+
+			if _, err := o.GetResources(context.TODO(), "crt"); err != nil {
+				t.Fatal(err)
+			}
+			var steps []string
+			for _, step := range o.profile.steps {
+				steps = append(steps, step.name)
+			}
+			if !reflect.DeepEqual(steps, test.expSteps) {
+				t.Errorf("unexpected steps; expected: %v, actual: %v", test.expSteps, steps)
+			}
+		})
+	}
+}
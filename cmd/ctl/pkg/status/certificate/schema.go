@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubectl/pkg/describe"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// certificateCRDName is the name of the CustomResourceDefinition of the
+// Certificate resource.
+const certificateCRDName = "certificates." + certmanager.GroupName
+
+// Types of schema findings
+const (
+	// The value of a field doesn't match its schema
+	schemaFindingInvalid = "Invalid"
+	// A field is not part of the schema, and is pruned by the API server
+	schemaFindingUnknown = "Unknown"
+	// A field or the version of the resource is deprecated
+	schemaFindingDeprecated = "Deprecated"
+)
+
+type SchemaReport struct {
+	// Version of the CustomResourceDefinition the Certificate was validated against
+	Version string `json:"version,omitempty"`
+	// Findings of the validation, sorted by field
+	Findings []SchemaFinding `json:"findings"`
+	// Error if the Certificate could not be validated
+	Error error `json:"-"`
+}
+
+type SchemaFinding struct {
+	// One of Invalid, Unknown or Deprecated
+	Type string `json:"type"`
+	// Path of the field the finding is about
+	Field string `json:"field"`
+	// Description of the finding
+	Message string `json:"message"`
+}
+
+// withSchemaReport adds the findings of validating the Certificate against
+// the schema of its installed CustomResourceDefinition to the status.
+func (status *CertificateStatus) withSchemaReport(report *SchemaReport) *CertificateStatus {
+	status.SchemaReport = report
+	return status
+}
+
+// schemaReport validates crt against the schema of the Certificate
+// CustomResourceDefinition installed in the cluster.
+func (o *Options) schemaReport(ctx context.Context, crt *cmapi.Certificate) *SchemaReport {
+	client, err := o.APIExtensions()
+	if err != nil {
+		return &SchemaReport{Error: fmt.Errorf("error when getting the CustomResourceDefinition %q: %w\n", certificateCRDName, err)}
+	}
+	crd, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, certificateCRDName, metav1.GetOptions{})
+	if err != nil {
+		return &SchemaReport{Error: fmt.Errorf("error when getting the CustomResourceDefinition %q: %w\n", certificateCRDName, err)}
+	}
+
+	crt = crt.DeepCopy()
+	crt.APIVersion = cmapi.SchemeGroupVersion.String()
+	crt.Kind = cmapi.CertificateKind
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crt)
+	if err != nil {
+		return &SchemaReport{Error: fmt.Errorf("error when preparing the Certificate for validation: %w\n", err)}
+	}
+
+	return validateSchema(crd, cmapi.SchemeGroupVersion.Version, obj)
+}
+
+// validateSchema validates obj against the schema of the given version of
+// crd. Besides values which don't match the schema, fields which are not part
+// of the schema are reported, as are deprecated fields and versions. A field
+// is deprecated if its description starts with "Deprecated".
+func validateSchema(crd *apiextensionsv1.CustomResourceDefinition, version string, obj map[string]interface{}) *SchemaReport {
+	gv := crd.Spec.Group + "/" + version
+	report := &SchemaReport{Version: gv, Findings: []SchemaFinding{}}
+
+	var crdVersion *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == version {
+			crdVersion = &crd.Spec.Versions[i]
+		}
+	}
+	if crdVersion == nil || !crdVersion.Served {
+		report.Error = fmt.Errorf("the version %s is not served by the CustomResourceDefinition %q\n", gv, crd.Name)
+		return report
+	}
+	if crdVersion.Schema == nil || crdVersion.Schema.OpenAPIV3Schema == nil {
+		report.Error = fmt.Errorf("the version %s of the CustomResourceDefinition %q has no schema\n", gv, crd.Name)
+		return report
+	}
+
+	if crdVersion.Deprecated {
+		message := fmt.Sprintf("%s %s is deprecated", gv, crd.Spec.Names.Kind)
+		if crdVersion.DeprecationWarning != nil {
+			message = *crdVersion.DeprecationWarning
+		}
+		report.Findings = append(report.Findings, SchemaFinding{Type: schemaFindingDeprecated, Field: "apiVersion", Message: message})
+	}
+
+	internal := new(apiextensions.JSONSchemaProps)
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(crdVersion.Schema.OpenAPIV3Schema, internal, nil); err != nil {
+		report.Error = fmt.Errorf("error when converting the schema of the CustomResourceDefinition %q: %w\n", crd.Name, err)
+		return report
+	}
+	validator, _, err := validation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internal})
+	if err != nil {
+		report.Error = fmt.Errorf("error when building the validator of the CustomResourceDefinition %q: %w\n", crd.Name, err)
+		return report
+	}
+	for _, err := range validation.ValidateCustomResource(nil, obj, validator) {
+		report.Findings = append(report.Findings, SchemaFinding{Type: schemaFindingInvalid, Field: err.Field, Message: err.ErrorBody()})
+	}
+
+	report.Findings = append(report.Findings, walkSchema("", obj, crdVersion.Schema.OpenAPIV3Schema)...)
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Field < report.Findings[j].Field
+	})
+	return report
+}
+
+// walkSchema returns the unknown and deprecated fields of value, which is
+// found at path and described by schema.
+func walkSchema(path string, value interface{}, schema *apiextensionsv1.JSONSchemaProps) []SchemaFinding {
+	if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+		return nil
+	}
+
+	var findings []SchemaFinding
+	switch value := value.(type) {
+	case map[string]interface{}:
+		// The metadata is validated by the API server itself
+		if path == "metadata" {
+			return nil
+		}
+
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := key
+			if len(path) > 0 {
+				fieldPath = path + "." + key
+			}
+
+			field, ok := schema.Properties[key]
+			if !ok {
+				if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+					findings = append(findings, walkSchema(fieldPath, value[key], schema.AdditionalProperties.Schema)...)
+				} else if len(schema.Properties) > 0 {
+					findings = append(findings, SchemaFinding{Type: schemaFindingUnknown, Field: fieldPath, Message: "field is not declared in the schema and will be dropped by the API server"})
+				}
+				continue
+			}
+
+			if strings.HasPrefix(strings.TrimSpace(field.Description), "Deprecated") {
+				findings = append(findings, SchemaFinding{Type: schemaFindingDeprecated, Field: fieldPath, Message: firstSentence(field.Description)})
+			}
+			findings = append(findings, walkSchema(fieldPath, value[key], &field)...)
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range value {
+			findings = append(findings, walkSchema(fmt.Sprintf("%s[%d]", path, i), item, schema.Items.Schema)...)
+		}
+	}
+	return findings
+}
+
+// firstSentence returns the first sentence of a field description.
+func firstSentence(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if i := strings.Index(description, ". "); i >= 0 {
+		return description[:i+1]
+	}
+	return description
+}
+
+// String returns the findings of the schema validation as a string to be
+// printed as output
+func (report *SchemaReport) String() string {
+	var buf bytes.Buffer
+	w := describe.NewPrefixWriter(&buf)
+	w.Write(0, "Schema Report:\n")
+	if report.Error != nil {
+		w.Write(1, "%s\n", errorString(report.Error))
+		return buf.String()
+	}
+
+	w.Write(1, "Version: %s\n", report.Version)
+	if len(report.Findings) == 0 {
+		w.Write(1, "No findings\n")
+		return buf.String()
+	}
+	w.Write(1, "Findings:\n")
+	for _, finding := range report.Findings {
+		w.Write(1, "- %s: %s: %s\n", finding.Type, finding.Field, finding.Message)
+	}
+	return buf.String()
+}
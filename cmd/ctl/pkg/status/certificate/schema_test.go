@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// certificateCRD returns the Certificate CustomResourceDefinition bundled
+// with the explain command.
+func certificateCRD(t *testing.T) *apiextensionsv1.CustomResourceDefinition {
+	data, err := os.ReadFile("../../explain/crds/crd-certificates.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd := new(apiextensionsv1.CustomResourceDefinition)
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatal(err)
+	}
+	return crd
+}
+
+func TestValidateSchema(t *testing.T) {
+	certificate := func(spec map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata":   map[string]interface{}{"name": "test-crt", "namespace": "default"},
+			"spec":       spec,
+		}
+	}
+	validSpec := func() map[string]interface{} {
+		return map[string]interface{}{
+			"secretName": "test-crt",
+			"dnsNames":   []interface{}{"example.com"},
+			"issuerRef":  map[string]interface{}{"name": "ca-issuer"},
+		}
+	}
+
+	deprecatedCRD := certificateCRD(t)
+	deprecationWarning := "cert-manager.io/v1 Certificate is deprecated, use cert-manager.io/v2"
+	deprecatedCRD.Spec.Versions[0].Deprecated = true
+	deprecatedCRD.Spec.Versions[0].DeprecationWarning = &deprecationWarning
+	spec := deprecatedCRD.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	dnsNames := spec.Properties["dnsNames"]
+	dnsNames.Description = "Deprecated: the DNS names are taken from the subject. Requested DNS subject alternative names."
+	spec.Properties["dnsNames"] = dnsNames
+	deprecatedCRD.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"] = spec
+
+	tests := map[string]struct {
+		crd     *apiextensionsv1.CustomResourceDefinition
+		version string
+		obj     map[string]interface{}
+
+		expFindings []SchemaFinding
+		expErrMsg   string
+	}{
+		"valid Certificate has no findings": {
+			crd:         certificateCRD(t),
+			version:     "v1",
+			obj:         certificate(validSpec()),
+			expFindings: []SchemaFinding{},
+		},
+		"values not matching the schema are invalid": {
+			crd:     certificateCRD(t),
+			version: "v1",
+			obj: certificate(func() map[string]interface{} {
+				spec := validSpec()
+				spec["privateKey"] = map[string]interface{}{"algorithm": "DSA"}
+				spec["revisionHistoryLimit"] = "3"
+				return spec
+			}()),
+			expFindings: []SchemaFinding{
+				{Type: "Invalid", Field: "spec.privateKey.algorithm", Message: `Unsupported value: "DSA": supported values: "RSA", "ECDSA", "Ed25519"`},
+				{Type: "Invalid", Field: "spec.revisionHistoryLimit", Message: `Invalid value: "string": spec.revisionHistoryLimit in body must be of type integer: "string"`},
+			},
+		},
+		"fields not declared in the schema are unknown": {
+			crd:     certificateCRD(t),
+			version: "v1",
+			obj: certificate(func() map[string]interface{} {
+				spec := validSpec()
+				spec["organization"] = []interface{}{"Example"}
+				spec["issuerRef"] = map[string]interface{}{"name": "ca-issuer", "namespace": "default"}
+				return spec
+			}()),
+			expFindings: []SchemaFinding{
+				{Type: "Unknown", Field: "spec.issuerRef.namespace", Message: "field is not declared in the schema and will be dropped by the API server"},
+				{Type: "Unknown", Field: "spec.organization", Message: "field is not declared in the schema and will be dropped by the API server"},
+			},
+		},
+		"deprecated versions and fields are listed": {
+			crd:     deprecatedCRD,
+			version: "v1",
+			obj:     certificate(validSpec()),
+			expFindings: []SchemaFinding{
+				{Type: "Deprecated", Field: "apiVersion", Message: deprecationWarning},
+				{Type: "Deprecated", Field: "spec.dnsNames", Message: "Deprecated: the DNS names are taken from the subject."},
+			},
+		},
+		"version not served throws error": {
+			crd:       certificateCRD(t),
+			version:   "v1alpha2",
+			obj:       certificate(validSpec()),
+			expErrMsg: `the version cert-manager.io/v1alpha2 is not served by the CustomResourceDefinition "certificates.cert-manager.io"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			report := validateSchema(test.crd, test.version, test.obj)
+			if test.expErrMsg != "" {
+				assert.EqualError(t, report.Error, test.expErrMsg+"\n")
+				return
+			}
+			assert.NoError(t, report.Error)
+			assert.Equal(t, "cert-manager.io/"+test.version, report.Version)
+			assert.Equal(t, test.expFindings, report.Findings)
+		})
+	}
+}
+
+func TestSchemaReport(t *testing.T) {
+	crt := gen.Certificate("test-crt",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("test-crt"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	crt.Spec.IssuerRef.Name = "ca-issuer"
+
+	tests := map[string]struct {
+		crds []*apiextensionsv1.CustomResourceDefinition
+
+		expOutput string
+		expJSON   string
+	}{
+		"Certificate is validated against the installed CustomResourceDefinition": {
+			crds: []*apiextensionsv1.CustomResourceDefinition{certificateCRD(t)},
+			expOutput: `Schema Report:
+  Version: cert-manager.io/v1
+  No findings
+`,
+			expJSON: `{"version":"cert-manager.io/v1","findings":[]}`,
+		},
+		"missing CustomResourceDefinition is reported": {
+			expOutput: `Schema Report:
+  error when getting the CustomResourceDefinition "certificates.cert-manager.io": customresourcedefinitions.apiextensions.k8s.io "certificates.cert-manager.io" not found
+`,
+			expJSON: `{"error":"error when getting the CustomResourceDefinition \"certificates.cert-manager.io\": customresourcedefinitions.apiextensions.k8s.io \"certificates.cert-manager.io\" not found","findings":null}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := apiextensionsfake.NewSimpleClientset()
+			for _, crd := range test.crds {
+				if err := client.Tracker().Add(crd); err != nil {
+					t.Fatal(err)
+				}
+			}
+			opts := &Options{Factory: &factory.Factory{APIExtensionsClient: client}}
+
+			report := opts.schemaReport(context.TODO(), crt)
+			if output := report.String(); output != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, output)
+			}
+			data, err := json.Marshal(report)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.expJSON {
+				t.Errorf("Unexpected JSON; expected: \n%s\nactual: \n%s", test.expJSON, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"bytes"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/describe"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// SpecSummary contains the fields of the spec of a Certificate which matter
+// most when diagnosing it
+type SpecSummary struct {
+	// Name of the Secret the certificate is stored in
+	SecretName string `json:"secretName"`
+	// Reference to the Issuer, with the default kind and group applied
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+	// DNS Names of the Certificate
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// Requested duration of the certificate
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// How long before expiry the certificate is renewed
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+func newSpecSummary(crt *cmapi.Certificate) *SpecSummary {
+	return &SpecSummary{
+		SecretName:  crt.Spec.SecretName,
+		IssuerRef:   util.IssuerRefWithDefaults(crt),
+		DNSNames:    crt.Spec.DNSNames,
+		Duration:    crt.Spec.Duration,
+		RenewBefore: crt.Spec.RenewBefore,
+	}
+}
+
+// String returns the summary of the spec as a string to be printed as output
+func (spec *SpecSummary) String() string {
+	if spec == nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	w := describe.NewPrefixWriter(&buf)
+	w.Write(0, "Spec:\n")
+	w.Write(1, "Secret Name: %s\n", spec.SecretName)
+	w.Write(1, "Issuer Ref: %s/%s (%s)\n", spec.IssuerRef.Kind, spec.IssuerRef.Name, spec.IssuerRef.Group)
+	w.Write(1, "DNS Names: %s\n", joinOrNone(spec.DNSNames))
+	w.Write(1, "Duration: %s\n", formatDuration(spec.Duration))
+	w.Write(1, "Renew Before: %s\n", formatDuration(spec.RenewBefore))
+	return buf.String()
+}
+
+// joinOrNone returns the values separated by commas, or "<none>" if there are
+// none.
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ", ")
+}
+
+// formatDuration returns the duration as a string, or "<none>" if it is not
+// set.
+func formatDuration(d *metav1.Duration) string {
+	if d == nil {
+		return "<none>"
+	}
+	return d.Duration.String()
+}
@@ -18,143 +18,251 @@ package certificate
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/kubectl/pkg/describe"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// CertificateStatus is the status of a Certificate and its related resources.
+// Its JSON representation is printed by --output json and yaml, and addressed
+// by the templated output formats, so renaming or removing fields breaks
+// scripts consuming it.
 type CertificateStatus struct {
 	// Name of the Certificate resource
-	Name string
+	Name string `json:"name"`
 	// Namespace of the Certificate resource
-	Namespace string
+	Namespace string `json:"namespace"`
 	// Creation Time of Certificate resource
-	CreationTime metav1.Time
+	CreationTime metav1.Time `json:"creationTime"`
+	// Labels of Certificate resource
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations of Certificate resource
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Summary of the spec of Certificate resource
+	Spec *SpecSummary `json:"spec,omitempty"`
 	// Conditions of Certificate resource
-	Conditions []cmapi.CertificateCondition
+	Conditions []cmapi.CertificateCondition `json:"conditions,omitempty"`
 	// DNS Names of Certificate resource
-	DNSNames []string
+	DNSNames []string `json:"dnsNames,omitempty"`
 	// Events of Certificate resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
 	// Not Before of Certificate resource
-	NotBefore *metav1.Time
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
 	// Not After of Certificate resource
-	NotAfter *metav1.Time
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 	// Renewal Time of Certificate resource
-	RenewalTime *metav1.Time
-
-	IssuerStatus *IssuerStatus
-
-	SecretStatus *SecretStatus
-
-	CRStatus *CRStatus
-
-	OrderStatus *OrderStatus
-
-	ChallengeStatusList *ChallengeStatusList
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+	// Soonest Not After time of the certificates in the tls.crt of the
+	// Secret, which is earlier than NotAfter if an intermediate certificate
+	// expires before the leaf certificate
+	ChainNotAfter *metav1.Time `json:"chainNotAfter,omitempty"`
+
+	IssuerStatus *IssuerStatus `json:"issuer,omitempty"`
+
+	SecretStatus *SecretStatus `json:"secret,omitempty"`
+
+	CRStatus *CRStatus `json:"certificateRequest,omitempty"`
+
+	OrderStatus *OrderStatus `json:"order,omitempty"`
+
+	ChallengeStatusList *ChallengeStatusList `json:"challenges,omitempty"`
+
+	// Findings of validating the Certificate against the schema of its
+	// CustomResourceDefinition, only set if requested
+	SchemaReport *SchemaReport `json:"schemaReport,omitempty"`
+
+	// expiryHighlight highlights the Not After time in the human readable
+	// output, if not nil
+	expiryHighlight *expiryHighlight
+	// sinceLastTransitionAt is the time the duration since the last
+	// transition of the Ready condition is computed at, it is not printed if
+	// nil
+	sinceLastTransitionAt *time.Time
+	// chainExpiry is the certificate of the chain in the Secret expiring
+	// first, it is not printed if nil
+	chainExpiry *ChainCertificateStatus
+	// If true, the human readable output is colorized
+	color bool
+	// Width the event tables of the human readable output are fitted to,
+	// not limited if 0
+	width int
+	// If true, a summary of the health of the Issuer/ClusterIssuer is
+	// printed after the human readable output
+	issuerHealth bool
 }
 
 type IssuerStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Issuer/ClusterIssuer resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the Issuer/ClusterIssuer resource
-	Name string
+	Name string `json:"name,omitempty"`
 	// Kind of the resource, can be Issuer or ClusterIssuer
-	Kind string
+	Kind string `json:"kind,omitempty"`
+	// Generation of the spec of the Issuer/ClusterIssuer resource
+	Generation int64 `json:"generation,omitempty"`
+	// Generation of the spec observed by the controller when it last set the
+	// Ready condition, 0 if the condition is not set
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// If true, the controller has not observed the latest generation of the
+	// spec yet, so the conditions may be outdated
+	Stale bool `json:"stale,omitempty"`
 	// Conditions of Issuer/ClusterIssuer resource
-	Conditions []cmapi.IssuerCondition
+	Conditions []cmapi.IssuerCondition `json:"conditions,omitempty"`
 	// Events of Issuer/ClusterIssuer resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+
+	// If true, the human readable output is colorized
+	color bool
+	// Width the event tables of the human readable output are fitted to,
+	// not limited if 0
+	width int
 }
 
 type SecretStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Secret resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the Secret resource
-	Name string
+	Name string `json:"name,omitempty"`
 	// Issuer Countries of the x509 certificate in the Secret
-	IssuerCountry []string
+	IssuerCountry []string `json:"issuerCountry,omitempty"`
 	// Issuer Organisations of the x509 certificate in the Secret
-	IssuerOrganisation []string
+	IssuerOrganisation []string `json:"issuerOrganisation,omitempty"`
 	// Issuer Common Name of the x509 certificate in the Secret
-	IssuerCommonName string
+	IssuerCommonName string `json:"issuerCommonName,omitempty"`
 	// Key Usage of the x509 certificate in the Secret
-	KeyUsage x509.KeyUsage
+	KeyUsage x509.KeyUsage `json:"-"`
 	// Extended Key Usage of the x509 certificate in the Secret
-	ExtKeyUsage []x509.ExtKeyUsage
+	ExtKeyUsage []x509.ExtKeyUsage `json:"-"`
 	// Public Key Algorithm of the x509 certificate in the Secret
-	PublicKeyAlgorithm x509.PublicKeyAlgorithm
+	PublicKeyAlgorithm x509.PublicKeyAlgorithm `json:"-"`
 	// Signature Algorithm of the x509 certificate in the Secret
-	SignatureAlgorithm x509.SignatureAlgorithm
+	SignatureAlgorithm x509.SignatureAlgorithm `json:"-"`
 	// Subject Key Id of the x509 certificate in the Secret
-	SubjectKeyId []byte
+	SubjectKeyId []byte `json:"-"`
 	// Authority Key Id of the x509 certificate in the Secret
-	AuthorityKeyId []byte
+	AuthorityKeyId []byte `json:"-"`
 	// Serial Number of the x509 certificate in the Secret
-	SerialNumber *big.Int
+	SerialNumber *big.Int `json:"-"`
+	// Type of the private key in the Secret, e.g. "ECDSA P-256", or
+	// <unavailable> if it cannot be parsed
+	PrivateKeyType string `json:"privateKeyType,omitempty"`
+	// Describes how the private key in the Secret differs from the one
+	// requested by the Certificate, empty if it matches
+	PrivateKeyMismatch string `json:"privateKeyMismatch,omitempty"`
+	// Validity of every certificate in tls.crt, the leaf certificate first
+	Chain []ChainCertificateStatus `json:"chain,omitempty"`
 	// Events of Secret resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+
+	// If true, the human readable output is colorized
+	color bool
+	// Width the event tables of the human readable output are fitted to,
+	// not limited if 0
+	width int
 }
 
 type CRStatus struct {
 	// If Error is not nil, there was a problem getting the status of the CertificateRequest resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the CertificateRequest resource
-	Name string
+	Name string `json:"name,omitempty"`
 	// Namespace of the CertificateRequest resource
-	Namespace string
+	Namespace string `json:"namespace,omitempty"`
 	// Conditions of CertificateRequest resource
-	Conditions []cmapi.CertificateRequestCondition
+	Conditions []cmapi.CertificateRequestCondition `json:"conditions,omitempty"`
 	// Events of CertificateRequest resource
-	Events *v1.EventList
+	Events *v1.EventList `json:"events,omitempty"`
+	// Summary of the CSR of the CertificateRequest resource, only set if requested
+	CSRStatus *CSRStatus `json:"csr,omitempty"`
+
+	// If true, the human readable output is colorized
+	color bool
+	// Width the event tables of the human readable output are fitted to,
+	// not limited if 0
+	width int
+}
+
+type CSRStatus struct {
+	// If Error is not nil, there was a problem decoding the CSR,
+	// so the rest of the fields is unusable
+	Error error `json:"-"`
+	// Subject of the CSR
+	Subject string `json:"subject"`
+	// DNS Names requested by the CSR
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IP Addresses requested by the CSR
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// URIs requested by the CSR
+	URIs []string `json:"uris,omitempty"`
+	// Email Addresses requested by the CSR
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	// Type of the public key of the CSR, e.g. "RSA 2048"
+	KeyType string `json:"keyType"`
 }
 
 type OrderStatus struct {
 	// If Error is not nil, there was a problem getting the status of the Order resource,
 	// so the rest of the fields is unusable
-	Error error
+	Error error `json:"-"`
 	// Name of the Order resource
-	Name string
+	Name string `json:"name,omitempty"`
 	// State of Order resource
-	State cmacme.State
+	State cmacme.State `json:"state,omitempty"`
 	// Reason why the Order resource is in its State
-	Reason string
+	Reason string `json:"reason,omitempty"`
 	// What authorizations must be completed to validate the DNS names specified on the Order
-	Authorizations []cmacme.ACMEAuthorization
+	Authorizations []cmacme.ACMEAuthorization `json:"authorizations,omitempty"`
 	// Time the Order failed
-	FailureTime *metav1.Time
+	FailureTime *metav1.Time `json:"failureTime,omitempty"`
+
+	// If true, the human readable output is colorized
+	color bool
 }
 
 type ChallengeStatusList struct {
 	// If Error is not nil, there was a problem getting the status of the Order resource,
 	// so the rest of the fields is unusable
-	Error             error
-	ChallengeStatuses []*ChallengeStatus
+	Error             error              `json:"-"`
+	ChallengeStatuses []*ChallengeStatus `json:"items,omitempty"`
+
+	// If true, the human readable output is colorized
+	color bool
 }
 
 type ChallengeStatus struct {
-	Name       string
-	Type       cmacme.ACMEChallengeType
-	Token      string
-	Key        string
-	State      cmacme.State
-	Reason     string
-	Processing bool
-	Presented  bool
+	Name string                   `json:"name"`
+	Type cmacme.ACMEChallengeType `json:"type"`
+	// Solver presenting the Challenge, e.g. "HTTP-01 Ingress" or
+	// "DNS-01 Cloudflare"
+	Solver     string       `json:"solver,omitempty"`
+	Token      string       `json:"token"`
+	Key        string       `json:"key"`
+	State      cmacme.State `json:"state"`
+	Reason     string       `json:"reason"`
+	Processing bool         `json:"processing"`
+	Presented  bool         `json:"presented"`
 }
 
 func newCertificateStatusFromCert(crt *cmapi.Certificate) *CertificateStatus {
@@ -163,6 +271,7 @@ func newCertificateStatusFromCert(crt *cmapi.Certificate) *CertificateStatus {
 	}
 	return &CertificateStatus{
 		Name: crt.Name, Namespace: crt.Namespace, CreationTime: crt.CreationTimestamp,
+		Labels: crt.Labels, Annotations: crt.Annotations, Spec: newSpecSummary(crt),
 		Conditions: crt.Status.Conditions, DNSNames: crt.Spec.DNSNames,
 		NotBefore: crt.Status.NotBefore, NotAfter: crt.Status.NotAfter, RenewalTime: crt.Status.RenewalTime}
 }
@@ -180,16 +289,39 @@ func (status *CertificateStatus) withGenericIssuer(genericIssuer cmapi.GenericIs
 	if genericIssuer == nil {
 		return status
 	}
-	if issuerKind == "ClusterIssuer" {
-		status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "ClusterIssuer",
-			Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
-		return status
-	}
-	status.IssuerStatus = &IssuerStatus{Name: genericIssuer.GetName(), Kind: "Issuer",
-		Conditions: genericIssuer.GetStatus().Conditions, Events: issuerEvents}
+	status.IssuerStatus = IssuerStatusFromResource(genericIssuer, issuerKind, issuerEvents, false, 0)
 	return status
 }
 
+// IssuerStatusFromResource returns the status of genericIssuer, an Issuer or
+// ClusterIssuer as given by kind, and of its events. If color is true, the
+// human readable output is colorized. Its event table is fitted to width,
+// unless width is 0.
+func IssuerStatusFromResource(genericIssuer cmapi.GenericIssuer, kind string, events *v1.EventList, color bool, width int) *IssuerStatus {
+	if kind != cmapi.ClusterIssuerKind {
+		kind = cmapi.IssuerKind
+	}
+	issuerStatus := &IssuerStatus{Name: genericIssuer.GetName(), Kind: kind,
+		Conditions: genericIssuer.GetStatus().Conditions, Events: events, color: color, width: width}
+	issuerStatus.withGeneration(genericIssuer)
+	return issuerStatus
+}
+
+// withGeneration sets the generation of genericIssuer and the generation
+// observed by the controller, which lags behind right after the spec of
+// genericIssuer was changed.
+func (issuerStatus *IssuerStatus) withGeneration(genericIssuer cmapi.GenericIssuer) {
+	issuerStatus.Generation = genericIssuer.GetGeneration()
+	if issuerStatus.Generation == 0 {
+		// The generation is unknown, e.g. if the API server doesn't set it
+		return
+	}
+	if ready := util.IssuerReadyCondition(genericIssuer.GetStatus().Conditions); ready != nil {
+		issuerStatus.ObservedGeneration = ready.ObservedGeneration
+	}
+	issuerStatus.Stale = issuerStatus.ObservedGeneration < issuerStatus.Generation
+}
+
 func (status *CertificateStatus) withSecret(secret *v1.Secret, secretEvents *v1.EventList, err error) *CertificateStatus {
 	if err != nil {
 		status.SecretStatus = &SecretStatus{Error: err}
@@ -233,6 +365,120 @@ func (status *CertificateStatus) withCR(req *cmapi.CertificateRequest, events *v
 	return status
 }
 
+// withExpiryHighlight highlights the Not After time in the human readable
+// output according to the thresholds of h.
+func (status *CertificateStatus) withExpiryHighlight(h *expiryHighlight) *CertificateStatus {
+	status.expiryHighlight = h
+	return status
+}
+
+// withColor colorizes the human readable output of the status and of the
+// statuses of the related resources, if color is true. Needs to be called
+// after the statuses of the related resources are set.
+func (status *CertificateStatus) withColor(color bool) *CertificateStatus {
+	status.color = color
+	if status.IssuerStatus != nil {
+		status.IssuerStatus.color = color
+	}
+	if status.SecretStatus != nil {
+		status.SecretStatus.color = color
+	}
+	if status.CRStatus != nil {
+		status.CRStatus.color = color
+	}
+	if status.OrderStatus != nil {
+		status.OrderStatus.color = color
+	}
+	if status.ChallengeStatusList != nil {
+		status.ChallengeStatusList.color = color
+	}
+	return status
+}
+
+// withOutputWidth fits the event tables of the human readable output of the
+// status and of the statuses of the related resources to width. Needs to be
+// called after the statuses of the related resources are set.
+func (status *CertificateStatus) withOutputWidth(width int) *CertificateStatus {
+	status.width = width
+	if status.IssuerStatus != nil {
+		status.IssuerStatus.width = width
+	}
+	if status.SecretStatus != nil {
+		status.SecretStatus.width = width
+	}
+	if status.CRStatus != nil {
+		status.CRStatus.width = width
+	}
+	return status
+}
+
+// withIssuerHealth adds a summary of the health of the Issuer/ClusterIssuer
+// to the end of the human readable output.
+func (status *CertificateStatus) withIssuerHealth() *CertificateStatus {
+	status.issuerHealth = true
+	return status
+}
+
+// withSinceLastTransition adds the time since the last transition of the
+// Ready condition, as of now, to the human readable output.
+func (status *CertificateStatus) withSinceLastTransition(now time.Time) *CertificateStatus {
+	status.sinceLastTransitionAt = &now
+	return status
+}
+
+// sinceLastTransition describes how long the Certificate has been in the
+// state of its Ready condition as of now, e.g. "Ready for 3d4h".
+func sinceLastTransition(conditions []cmapi.CertificateCondition, now time.Time) string {
+	for _, con := range conditions {
+		if con.Type != cmapi.CertificateConditionReady {
+			continue
+		}
+		if con.LastTransitionTime == nil {
+			return "Ready condition has no last transition time"
+		}
+
+		state := "Ready"
+		switch con.Status {
+		case cmmeta.ConditionFalse:
+			state = "Not Ready"
+		case cmmeta.ConditionUnknown:
+			state = "Ready state Unknown"
+		}
+		return fmt.Sprintf("%s for %s", state, duration.HumanDuration(now.Sub(con.LastTransitionTime.Time)))
+	}
+	return "No Ready condition set"
+}
+
+// withCSR adds a summary of the CSR of req to the status of the CertificateRequest.
+// Does nothing if there is no CertificateRequest status to add it to.
+func (status *CertificateStatus) withCSR(req *cmapi.CertificateRequest) *CertificateStatus {
+	if req == nil || status.CRStatus == nil || status.CRStatus.Error != nil {
+		return status
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		status.CRStatus.CSRStatus = &CSRStatus{Error: fmt.Errorf("error when decoding the CSR of the CertificateRequest: %w", err)}
+		return status
+	}
+
+	status.CRStatus.CSRStatus = CSRStatusFromRequest(csr)
+	return status
+}
+
+// CSRStatusFromRequest returns the summary of csr, which is printed by both
+// 'status certificate --show-csr' and 'inspect csr'.
+func CSRStatusFromRequest(csr *x509.CertificateRequest) *CSRStatus {
+	return &CSRStatus{
+		Subject:        csr.Subject.String(),
+		DNSNames:       csr.DNSNames,
+		IPAddresses:    pki.IPAddressesToString(csr.IPAddresses),
+		URIs:           pki.URLsToString(csr.URIs),
+		EmailAddresses: csr.EmailAddresses,
+		KeyType:        publicKeyType(csr.PublicKey),
+	}
+}
+
 func (status *CertificateStatus) withOrder(order *cmacme.Order, err error) *CertificateStatus {
 	if err != nil {
 		status.OrderStatus = &OrderStatus{Error: err}
@@ -262,6 +508,7 @@ func (status *CertificateStatus) withChallenges(challenges []*cmacme.Challenge,
 		list = append(list, &ChallengeStatus{
 			Name:       challenge.Name,
 			Type:       challenge.Spec.Type,
+			Solver:     solverType(challenge.Spec.Solver),
 			Token:      challenge.Spec.Token,
 			Key:        challenge.Spec.Key,
 			State:      challenge.Status.State,
@@ -275,36 +522,47 @@ func (status *CertificateStatus) withChallenges(challenges []*cmacme.Challenge,
 }
 
 func (status *CertificateStatus) String() string {
-	output := ""
-	output += fmt.Sprintf("Name: %s\n", status.Name)
-	output += fmt.Sprintf("Namespace: %s\n", status.Namespace)
-	output += fmt.Sprintf("Created at: %s\n", formatTimeString(&status.CreationTime))
+	var buf bytes.Buffer
+	util.DescribeObjectMeta(&metav1.ObjectMeta{
+		Name:              status.Name,
+		Namespace:         status.Namespace,
+		CreationTimestamp: status.CreationTime,
+		Labels:            status.Labels,
+		Annotations:       status.Annotations,
+	}, describe.NewPrefixWriter(&buf), 0)
+	output := buf.String()
+
+	output += status.Spec.String()
 
 	// Output one line about each type of Condition that is set.
 	// Certificate can have multiple Conditions of different types set, e.g. "Ready" or "Issuing"
 	conditionMsg := ""
 	for _, con := range status.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, util.ColorizeConditionStatus(string(con.Status), status.color), con.Reason, con.Message)
 	}
 	if conditionMsg == "" {
 		conditionMsg = "  No Conditions set\n"
 	}
 	output += fmt.Sprintf("Conditions:\n%s", conditionMsg)
+	if status.sinceLastTransitionAt != nil {
+		output += sinceLastTransition(status.Conditions, *status.sinceLastTransitionAt) + "\n"
+	}
 
-	output += fmt.Sprintf("DNS Names:\n%s", formatStringSlice(status.DNSNames))
-
-	output += eventsToString(status.Events, 0)
+	output += eventsToString(status.Events, 0, status.color, status.width)
 
 	output += status.IssuerStatus.String()
 	output += status.SecretStatus.String()
 
 	output += fmt.Sprintf("Not Before: %s\n", formatTimeString(status.NotBefore))
-	output += fmt.Sprintf("Not After: %s\n", formatTimeString(status.NotAfter))
+	output += fmt.Sprintf("Not After: %s\n", status.expiryHighlight.format(status.NotAfter))
+	output += chainExpiryString(status.chainExpiry)
 	output += fmt.Sprintf("Renewal Time: %s\n", formatTimeString(status.RenewalTime))
 
 	output += status.CRStatus.String()
 
-	// OrderStatus is nil is not found or Issuer/ClusterIssuer is not ACME Issuer
+	// The Order and its Challenges are nested below the CertificateRequest
+	// they were created for. OrderStatus is nil if there is no
+	// CertificateRequest or the Issuer/ClusterIssuer is not an ACME Issuer.
 	if status.OrderStatus != nil {
 		output += status.OrderStatus.String()
 	}
@@ -313,6 +571,14 @@ func (status *CertificateStatus) String() string {
 		output += status.ChallengeStatusList.String()
 	}
 
+	if status.SchemaReport != nil {
+		output += status.SchemaReport.String()
+	}
+
+	if status.issuerHealth {
+		output += status.IssuerStatus.healthString()
+	}
+
 	return output
 }
 
@@ -325,20 +591,61 @@ func (issuerStatus *IssuerStatus) String() string {
 	issuerFormat := `Issuer:
   Name: %s
   Kind: %s
-  Conditions:
+%s  Conditions:
   %s`
+	generationMsg := ""
+	if issuerStatus.Generation != 0 {
+		observedGeneration := "<none>"
+		if issuerStatus.ObservedGeneration != 0 {
+			observedGeneration = fmt.Sprint(issuerStatus.ObservedGeneration)
+		}
+		generationMsg = fmt.Sprintf("  Generation: %d, Observed Generation: %s\n", issuerStatus.Generation, observedGeneration)
+		if issuerStatus.Stale {
+			generationMsg += "  The controller has not observed the latest generation of the spec yet, the conditions may be outdated\n"
+		}
+	}
 	conditionMsg := ""
 	for _, con := range issuerStatus.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, util.ColorizeConditionStatus(string(con.Status), issuerStatus.color), con.Reason, con.Message)
 	}
 	if conditionMsg == "" {
 		conditionMsg = "  No Conditions set\n"
 	}
-	output := fmt.Sprintf(issuerFormat, issuerStatus.Name, issuerStatus.Kind, conditionMsg)
-	output += eventsToString(issuerStatus.Events, 1)
+	output := fmt.Sprintf(issuerFormat, issuerStatus.Name, issuerStatus.Kind, generationMsg, conditionMsg)
+	output += eventsToString(issuerStatus.Events, 1, issuerStatus.color, issuerStatus.width)
 	return output
 }
 
+// healthString returns a summary of the Ready condition of the
+// Issuer/ClusterIssuer, or of the error getting it, as a string to be printed
+// after the status of the Certificate.
+func (issuerStatus *IssuerStatus) healthString() string {
+	var buf bytes.Buffer
+	w := util.NewPrefixWriter(&buf)
+	w.Write(0, "Issuer Health:\n")
+
+	switch {
+	case issuerStatus == nil:
+		w.Write(1, "No Issuer found for this Certificate\n")
+		return buf.String()
+	case issuerStatus.Error != nil:
+		w.Write(1, "Error: %s\n", errorString(issuerStatus.Error))
+		return buf.String()
+	}
+
+	ready := util.IssuerReadyCondition(issuerStatus.Conditions)
+	if ready == nil {
+		w.Write(1, "%s %s: No Ready condition set\n", issuerStatus.Kind, issuerStatus.Name)
+		return buf.String()
+	}
+	w.Write(1, "%s %s: Ready: %s, Reason: %s, Message: %s\n", issuerStatus.Kind, issuerStatus.Name,
+		util.ColoredText(util.ColorizeConditionStatus(string(ready.Status), issuerStatus.color)), ready.Reason, ready.Message)
+	if issuerStatus.Stale {
+		w.Write(1, "The controller has not observed the latest generation of the spec yet, the condition may be outdated\n")
+	}
+	return buf.String()
+}
+
 // String returns the information about the status of a Secret as a string to be printed as output
 func (secretStatus *SecretStatus) String() string {
 	if secretStatus.Error != nil {
@@ -369,7 +676,14 @@ func (secretStatus *SecretStatus) String() string {
 		extKeyUsageString, secretStatus.PublicKeyAlgorithm, secretStatus.SignatureAlgorithm,
 		hex.EncodeToString(secretStatus.SubjectKeyId), hex.EncodeToString(secretStatus.AuthorityKeyId),
 		hex.EncodeToString(secretStatus.SerialNumber.Bytes()))
-	output += eventsToString(secretStatus.Events, 1)
+	if len(secretStatus.PrivateKeyType) > 0 {
+		output += fmt.Sprintf("  Private Key: %s\n", secretStatus.PrivateKeyType)
+	}
+	if len(secretStatus.PrivateKeyMismatch) > 0 {
+		output += fmt.Sprintf("  Warning: %s\n", secretStatus.PrivateKeyMismatch)
+	}
+	output += chainString(secretStatus.Chain)
+	output += eventsToString(secretStatus.Events, 1, secretStatus.color, secretStatus.width)
 	return output
 }
 
@@ -436,7 +750,7 @@ func (crStatus *CRStatus) String() string {
   %s`
 	conditionMsg := ""
 	for _, con := range crStatus.Conditions {
-		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, con.Status, con.Reason, con.Message)
+		conditionMsg += fmt.Sprintf("  %s: %s, Reason: %s, Message: %s\n", con.Type, util.ColorizeConditionStatus(string(con.Status), crStatus.color), con.Reason, con.Message)
 	}
 	if conditionMsg == "" {
 		conditionMsg = "  No Conditions set\n"
@@ -444,66 +758,181 @@ func (crStatus *CRStatus) String() string {
 	infos := fmt.Sprintf(crFormat, crStatus.Name, crStatus.Namespace, conditionMsg)
 	infos = fmt.Sprintf("CertificateRequest:%s", infos)
 
-	infos += eventsToString(crStatus.Events, 1)
+	if crStatus.CSRStatus != nil {
+		infos += crStatus.CSRStatus.String()
+	}
+
+	infos += eventsToString(crStatus.Events, 1, crStatus.color, crStatus.width)
 	return infos
 }
 
-// String returns the information about the status of a CR as a string to be printed as output
+// String returns a summary of the CSR as a string to be printed as output,
+// indented to be part of the CertificateRequest status
+func (csrStatus *CSRStatus) String() string {
+	var buf bytes.Buffer
+	w := describe.NewPrefixWriter(&buf)
+	w.Write(1, "CSR:\n")
+	if csrStatus.Error != nil {
+		w.Write(2, "%s\n", errorString(csrStatus.Error))
+		return buf.String()
+	}
+	csrStatus.Describe(w, 2)
+	return buf.String()
+}
+
+// Describe writes the subject, the key type and the subject alternative names
+// of the CSR with PrefixWriter. Empty values are written as "<none>", so that
+// it is visible that they were not requested.
+func (csrStatus *CSRStatus) Describe(w describe.PrefixWriter, baseLevel int) {
+	subject := csrStatus.Subject
+	if len(subject) == 0 {
+		subject = "<none>"
+	}
+	w.Write(baseLevel, "Subject: %s\n", subject)
+	w.Write(baseLevel, "Key Type: %s\n", csrStatus.KeyType)
+	util.DescribeList("DNS Names", csrStatus.DNSNames, w, baseLevel)
+	util.DescribeList("IP Addresses", csrStatus.IPAddresses, w, baseLevel)
+	util.DescribeList("URIs", csrStatus.URIs, w, baseLevel)
+	util.DescribeList("Email Addresses", csrStatus.EmailAddresses, w, baseLevel)
+}
+
+// String returns the information about the status of an Order as a string to
+// be printed as output, indented to be part of the CertificateRequest status
 func (orderStatus *OrderStatus) String() string {
+	var buf bytes.Buffer
+	w := util.NewPrefixWriter(&buf)
+	w.Write(1, "Order:\n")
 	if orderStatus.Error != nil {
-		return orderStatus.Error.Error()
+		w.Write(2, "%s\n", errorString(orderStatus.Error))
+		return buf.String()
 	}
 
-	output := "Order:\n"
-	output += fmt.Sprintf("  Name: %s\n", orderStatus.Name)
-	output += fmt.Sprintf("  State: %s, Reason: %s\n", orderStatus.State, orderStatus.Reason)
-	authString := ""
+	w.Write(2, "Name: %s\n", orderStatus.Name)
+	w.Write(2, "State: %s, Reason: %s\n", colorizeState(orderStatus.State, orderStatus.color), orderStatus.Reason)
+	if len(orderStatus.Authorizations) == 0 {
+		w.Write(2, "No Authorizations for this Order\n")
+	} else {
+		w.Write(2, "Authorizations:\n")
+	}
 	for _, auth := range orderStatus.Authorizations {
 		wildcardString := "nil (bool pointer not set)"
 		if auth.Wildcard != nil {
 			wildcardString = fmt.Sprintf("%t", *auth.Wildcard)
 		}
-		authString += fmt.Sprintf("    URL: %s, Identifier: %s, Initial State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, wildcardString)
-	}
-	if authString == "" {
-		output += "  No Authorizations for this Order\n"
-	} else {
-		output += "  Authorizations:\n"
-		output += authString
+		w.Write(3, "URL: %s, Identifier: %s, Initial State: %s, Wildcard: %s\n", auth.URL, auth.Identifier, auth.InitialState, wildcardString)
 	}
 	if orderStatus.FailureTime != nil {
-		output += fmt.Sprintf("  FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
+		w.Write(2, "FailureTime: %s\n", formatTimeString(orderStatus.FailureTime))
 	}
 
-	return output
+	return buf.String()
 }
 
+// String returns the information about the status of the Challenges as a
+// string to be printed as output, indented to be part of the Order status
 func (c *ChallengeStatusList) String() string {
+	var buf bytes.Buffer
+	w := util.NewPrefixWriter(&buf)
+	w.Write(2, "Challenges:\n")
 	if c.Error != nil {
-		return c.Error.Error()
+		w.Write(3, "%s\n", errorString(c.Error))
+		return buf.String()
 	}
 
-	challengeStrings := []string{}
 	for _, challengeStatus := range c.ChallengeStatuses {
-		challengeStrings = append(challengeStrings, challengeStatus.String())
+		challengeStatus.write(w, c.color)
+	}
+	return buf.String()
+}
+
+// write writes the status of the Challenge to w as an item of the list of
+// Challenges of an Order. If color is true, the state is colorized.
+func (challengeStatus *ChallengeStatus) write(w describe.PrefixWriter, color bool) {
+	w.Write(2, "- Name: %s\n", challengeStatus.Name)
+	solver := challengeStatus.Solver
+	if solver == "" {
+		solver = "<unknown>"
+	}
+	w.Write(3, "Type: %s, Solver: %s\n", challengeStatus.Type, solver)
+	w.Write(3, "State: %s, Reason: %s\n", colorizeState(challengeStatus.State, color), challengeStatus.Reason)
+	w.Write(3, "Processing: %t, Presented: %t\n", challengeStatus.Processing, challengeStatus.Presented)
+	w.Write(3, "Token: %s, Key: %s\n", challengeStatus.Token, challengeStatus.Key)
+}
+
+// colorizeState returns the state of an Order or Challenge colorized, if
+// color is true: valid in green, failed states in red and pending states in
+// yellow.
+func colorizeState(state cmacme.State, color bool) util.ColoredText {
+	switch state {
+	case cmacme.Valid:
+		return util.ColoredText(util.Colorize(string(state), util.ColorGreen, color))
+	case cmacme.Invalid, cmacme.Errored, cmacme.Expired:
+		return util.ColoredText(util.Colorize(string(state), util.ColorRed, color))
+	case cmacme.Pending, cmacme.Processing, cmacme.Ready:
+		return util.ColoredText(util.Colorize(string(state), util.ColorYellow, color))
+	default:
+		return util.ColoredText(printers.EscapeTerminal(string(state)))
 	}
-	output := "Challenges:\n"
-	output += formatStringSlice(challengeStrings)
-	return output
 }
 
-func (challengeStatus *ChallengeStatus) String() string {
-	return fmt.Sprintf("Name: %s, Type: %s, Token: %s, Key: %s, State: %s, Reason: %s, Processing: %t, Presented: %t",
-		challengeStatus.Name, challengeStatus.Type, challengeStatus.Token, challengeStatus.Key, challengeStatus.State,
-		challengeStatus.Reason, challengeStatus.Processing, challengeStatus.Presented)
+// solverType returns a description of the kind of the solver, e.g.
+// "HTTP-01 Ingress" or "DNS-01 Route53". Returns an empty string if the
+// solver configures neither HTTP-01 nor DNS-01.
+func solverType(solver cmacme.ACMEChallengeSolver) string {
+	switch {
+	case solver.HTTP01 != nil && solver.HTTP01.GatewayHTTPRoute != nil:
+		return "HTTP-01 Gateway HTTPRoute"
+	case solver.HTTP01 != nil:
+		return "HTTP-01 Ingress"
+	case solver.DNS01 == nil:
+		return ""
+	}
+
+	dns01 := solver.DNS01
+	provider := "<unknown>"
+	switch {
+	case dns01.Akamai != nil:
+		provider = "Akamai"
+	case dns01.CloudDNS != nil:
+		provider = "CloudDNS"
+	case dns01.Cloudflare != nil:
+		provider = "Cloudflare"
+	case dns01.Route53 != nil:
+		provider = "Route53"
+	case dns01.AzureDNS != nil:
+		provider = "AzureDNS"
+	case dns01.DigitalOcean != nil:
+		provider = "DigitalOcean"
+	case dns01.AcmeDNS != nil:
+		provider = "AcmeDNS"
+	case dns01.RFC2136 != nil:
+		provider = "RFC2136"
+	case dns01.Webhook != nil:
+		provider = fmt.Sprintf("Webhook (%s/%s)", dns01.Webhook.GroupName, dns01.Webhook.SolverName)
+	}
+	return "DNS-01 " + provider
 }
 
-func eventsToString(events *v1.EventList, baseLevel int) string {
+func eventsToString(events *v1.EventList, baseLevel int, color bool, width int) string {
 	var buf bytes.Buffer
 	defer buf.Reset()
 	tabWriter := util.NewTabWriter(&buf)
-	prefixWriter := describe.NewPrefixWriter(tabWriter)
-	util.DescribeEvents(events, prefixWriter, baseLevel)
+	prefixWriter := util.NewPrefixWriter(tabWriter)
+	util.DescribeEvents(events, prefixWriter, baseLevel, color, width)
 	tabWriter.Flush()
 	return buf.String()
 }
+
+// publicKeyType returns the algorithm and size of the public key, e.g. "RSA 2048" or "ECDSA P-256".
+func publicKeyType(pub crypto.PublicKey) string {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA %s", pub.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return "<unknown>"
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect/secret"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/wait"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// waitForReady blocks until the Certificate is Ready for its current
+// generation, or the timeout is reached. Returns the Ready Certificate.
+func (o *Options) waitForReady(ctx context.Context, crtName string) (*cmapi.Certificate, error) {
+	fmt.Fprintf(o.ErrOut, "Waiting for Certificate %s/%s to become Ready...\n", o.Namespace, crtName)
+
+	crts := o.CMClient.CertmanagerV1().Certificates(o.Namespace)
+	obj, err := wait.For(ctx, o.Timeout, wait.Object{
+		Name:        crtName,
+		Description: fmt.Sprintf("Certificate %s/%s to become Ready", o.Namespace, crtName),
+		Get: func(ctx context.Context) (runtime.Object, error) {
+			crt, err := crts.Get(ctx, crtName, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("error when getting Certificate resource: %v", err)
+			}
+			return crt, nil
+		},
+		Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			return crts.Watch(ctx, opts)
+		},
+		Describe: func(obj runtime.Object) string {
+			if cond := apiutil.GetCertificateCondition(obj.(*cmapi.Certificate), cmapi.CertificateConditionReady); cond != nil {
+				return fmt.Sprintf("Ready: %s, Reason: %s, Message: %s", cond.Status, cond.Reason, cond.Message)
+			}
+			return "the Ready condition is not set"
+		},
+	}, func(obj runtime.Object) (bool, error) {
+		return certificateReady(obj.(*cmapi.Certificate)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*cmapi.Certificate), nil
+}
+
+// certificateReady returns true if crt is Ready for its current generation.
+func certificateReady(crt *cmapi.Certificate) bool {
+	return apiutil.CertificateHasConditionWithObservedGeneration(crt, cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		ObservedGeneration: crt.Generation,
+	})
+}
+
+// inspectSecret prints the details of the certificate stored in the Secret of crt,
+// in the same way as the inspect secret command.
+func (o *Options) inspectSecret(ctx context.Context, crt *cmapi.Certificate) error {
+	inspectOptions := secret.NewOptions(o.IOStreams)
+	inspectOptions.Factory = &factory.Factory{
+		Namespace:  crt.Namespace,
+		KubeClient: o.KubeClient,
+	}
+	return inspectOptions.Run(ctx, []string{crt.Spec.SecretName})
+}
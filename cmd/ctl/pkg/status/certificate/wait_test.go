@@ -0,0 +1,224 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8sclock "k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestWaitReadyThenInspect(t *testing.T) {
+	const ns = "test-ns"
+
+	key, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(gen.Certificate("test-crt",
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateDNSNames("example.com"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: ns},
+		Data:       map[string][]byte{corev1.TLSCertKey: certPEM},
+	}
+
+	readyCrt := gen.Certificate("ready-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateGeneration(2),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 2,
+		}),
+	)
+	outdatedCrt := gen.Certificate("outdated-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateGeneration(2),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue, ObservedGeneration: 1,
+		}),
+	)
+	notReadyCrt := gen.Certificate("not-ready-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "Issuing", Message: "Issuing certificate as Secret does not exist",
+		}),
+	)
+
+	tests := map[string]struct {
+		crtName   string
+		expErrMsg string
+		expOutput string
+	}{
+		"Ready Certificate is inspected right away": {
+			crtName:   readyCrt.Name,
+			expOutput: "Valid for:\n\tDNS Names: \n\t\t- example.com\n",
+		},
+		"Certificate which is Ready for a previous generation times out": {
+			crtName:   outdatedCrt.Name,
+			expErrMsg: "timed out waiting for Certificate test-ns/outdated-crt to become Ready: Ready: True, Reason: , Message: ",
+		},
+		"Certificate which is not Ready times out with the reason": {
+			crtName:   notReadyCrt.Name,
+			expErrMsg: "timed out waiting for Certificate test-ns/not-ready-crt to become Ready: Ready: False, Reason: Issuing, Message: Issuing certificate as Secret does not exist",
+		},
+		"missing Certificate throws error": {
+			crtName:   "missing-crt",
+			expErrMsg: `error when getting Certificate resource: certificates.cert-manager.io "missing-crt" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.WaitReady = true
+			opts.ThenInspect = true
+			opts.Timeout = 10 * time.Millisecond
+			opts.Factory = &factory.Factory{
+				Namespace:  ns,
+				CMClient:   cmfake.NewSimpleClientset(readyCrt, outdatedCrt, notReadyCrt),
+				KubeClient: kubefake.NewSimpleClientset(secret),
+			}
+
+			err := opts.Run(context.Background(), []string{test.crtName})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+			if !strings.Contains(out.String(), test.expOutput) {
+				t.Errorf("Unexpected output; expected to contain: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	const ns = "test-ns"
+
+	// Other tests replace the clock with a fake clock
+	defer func(previous k8sclock.Clock) { clock = previous }(clock)
+	clock = k8sclock.RealClock{}
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace(ns), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}))
+	readyCrt := gen.Certificate("ready-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuer.Name}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue,
+		}),
+	)
+	notReadyCrt := gen.Certificate("not-ready-crt",
+		gen.SetCertificateNamespace(ns),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuer.Name}),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Reason: "Issuing",
+		}),
+	)
+
+	tests := map[string]struct {
+		crtName string
+		// Timeout of the watch, the watch is stopped after 100ms otherwise
+		timeout time.Duration
+
+		// Minimum number of times the status is expected to be printed, or
+		// the exact number if expExact is set
+		expPrinted int
+		expExact   bool
+		expErrMsg  string
+	}{
+		"Ready Certificate is printed once": {
+			crtName:    readyCrt.Name,
+			expPrinted: 1,
+			expExact:   true,
+		},
+		"Certificate which is not Ready is printed until the watch is stopped": {
+			crtName:    notReadyCrt.Name,
+			expPrinted: 2,
+		},
+		"Certificate which is not Ready throws error once the timeout is reached": {
+			crtName:    notReadyCrt.Name,
+			timeout:    50 * time.Millisecond,
+			expPrinted: 2,
+			expErrMsg:  "timed out waiting for Certificate test-ns/not-ready-crt to become Ready",
+		},
+		"missing Certificate throws error": {
+			crtName:   "missing-crt",
+			expErrMsg: `error when getting Certificate resource: certificates.cert-manager.io "missing-crt" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stop := time.AfterFunc(100*time.Millisecond, cancel)
+			defer stop.Stop()
+
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.Watch = true
+			opts.WatchInterval = 10 * time.Millisecond
+			opts.Timeout = time.Minute
+			if test.timeout > 0 {
+				opts.Timeout = test.timeout
+			}
+			opts.Factory = &factory.Factory{
+				Namespace:  ns,
+				CMClient:   cmfake.NewSimpleClientset(issuer, readyCrt, notReadyCrt),
+				KubeClient: kubefake.NewSimpleClientset(),
+			}
+
+			err := opts.Run(ctx, []string{test.crtName})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			printed := strings.Count(out.String(), "Name: "+test.crtName+"\n")
+			if printed < test.expPrinted || (test.expExact && printed != test.expPrinted) {
+				t.Errorf("Unexpected number of statuses printed; expected: %d, actual: %d\n%s", test.expPrinted, printed, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+// clearScreen moves the cursor to the top left corner of the terminal and
+// clears it.
+const clearScreen = "\033[H\033[2J"
+
+// runWatch prints the status of the Certificate every WatchInterval, until the
+// Certificate is Ready for its current generation or Timeout is reached. The
+// related resources are looked up again every time, as they change while the
+// Certificate is issued, which is why they are polled rather than watched. On
+// a terminal the previous status is cleared, otherwise the statuses are
+// separated by a blank line. Stopping the command, e.g. with Ctrl-C, is not an
+// error.
+func (o *Options) runWatch(ctx context.Context, crtName string) error {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	clear := term.IsTerminal(o.Out)
+	for i := 0; ; i++ {
+		data, err := o.GetResources(ctx, crtName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return o.watchStopped(ctx, crtName)
+			}
+			return err
+		}
+
+		if clear {
+			fmt.Fprint(o.Out, clearScreen)
+		} else if i > 0 {
+			fmt.Fprintln(o.Out)
+		}
+		if err := o.printStatus(data); err != nil {
+			return err
+		}
+
+		if certificateReady(data.Certificate) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return o.watchStopped(ctx, crtName)
+		case <-clock.After(o.WatchInterval):
+		}
+	}
+}
+
+// watchStopped returns the error of a watch stopped by ctx being done before
+// the Certificate became Ready. Reaching the timeout is an error, while
+// cancelling the watch is not.
+func (o *Options) watchStopped(ctx context.Context, crtName string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for Certificate %s/%s to become Ready", o.Namespace, crtName)
+	}
+	return nil
+}
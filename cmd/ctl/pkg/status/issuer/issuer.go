@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/tools/reference"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Get details about the current status of a cert-manager Issuer resource, or of a ClusterIssuer resource with --cluster-issuer.
+
+The type of the issuer, e.g. ACME, CA, Vault, SelfSigned or Venafi, is printed along with its conditions and recent
+events. For ACME issuers, the server and the registration of the ACME account are printed as well: the URI of the
+account and the email it was last registered with, or that the account has not been registered yet.
+
+The conditions and events are printed in the same layout as the issuer of a Certificate by 'status certificate', and
+their statuses and types are colorized according to --color the same way.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Query status of Issuer with name 'my-issuer' in current namespace
+{{.BuildName}} status issuer my-issuer
+
+# Query status of Issuer in namespace 'my-namespace'
+{{.BuildName}} status issuer my-issuer --namespace my-namespace
+
+# Query status of ClusterIssuer with name 'letsencrypt-prod'
+{{.BuildName}} status issuer letsencrypt-prod --cluster-issuer
+`)))
+)
+
+// Options is a struct to support status issuer command
+type Options struct {
+	// ClusterIssuer is true if the status of a ClusterIssuer is printed,
+	// rather than of an Issuer. The flag is not named --cluster, which
+	// selects the kubeconfig cluster.
+	ClusterIssuer bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdStatusIssuer returns a cobra command for status issuer
+func NewCmdStatusIssuer(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "issuer",
+		Short:   "Get details about the current status of a cert-manager Issuer or ClusterIssuer resource",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.ClusterIssuer, "cluster-issuer", o.ClusterIssuer, "If present, print the status of the ClusterIssuer with the given name, rather than of an Issuer in the namespace.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Issuer has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Issuer")
+	}
+	return nil
+}
+
+// Run executes status issuer command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	issuer, err := o.getIssuer(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	issuerRef, err := reference.GetReference(ctl.Scheme, issuer)
+	if err != nil {
+		return err
+	}
+	// If no events found, events would be nil and handled down the line in DescribeEvents
+	events, err := o.KubeClient.CoreV1().Events(issuer.GetNamespace()).Search(ctl.Scheme, issuerRef)
+	if err != nil {
+		return err
+	}
+
+	kind := cmapi.IssuerKind
+	if o.ClusterIssuer {
+		kind = cmapi.ClusterIssuerKind
+	}
+	status := certificate.IssuerStatusFromResource(issuer, kind, events, util.ColorEnabled(o.Out), util.OutputWidth(o.Out))
+	fmt.Fprint(o.Out, statusString(issuer, status))
+	return nil
+}
+
+// getIssuer returns the Issuer in the namespace, or the ClusterIssuer if
+// ClusterIssuer is true.
+func (o *Options) getIssuer(ctx context.Context, name string) (cmapi.GenericIssuer, error) {
+	if o.ClusterIssuer {
+		clusterIssuer, err := o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error when getting ClusterIssuer resource: %v", err)
+		}
+		return clusterIssuer, nil
+	}
+	issuer, err := o.CMClient.CertmanagerV1().Issuers(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when getting Issuer resource: %v", err)
+	}
+	return issuer, nil
+}
+
+// statusString returns the status of the Issuer/ClusterIssuer as a string to
+// be printed as output: its metadata, its type and ACME account, if any,
+// followed by status, which is printed in the same way as the issuer of a
+// Certificate by 'status certificate'.
+func statusString(issuer cmapi.GenericIssuer, status *certificate.IssuerStatus) string {
+	var buf bytes.Buffer
+	tabWriter := util.NewTabWriter(&buf)
+	w := util.NewPrefixWriter(tabWriter)
+
+	util.DescribeObjectMeta(issuer, w, 0)
+	w.Write(0, "Type: %s\n", issuerType(issuer.GetSpec()))
+
+	if acme := issuer.GetSpec().ACME; acme != nil {
+		w.Write(0, "ACME Account:\n")
+		w.Write(1, "Server: %s\n", acme.Server)
+		w.Write(1, "Email: %s\n", valueOrNone(acme.Email))
+		acmeStatus := issuer.GetStatus().ACME
+		if acmeStatus == nil || len(acmeStatus.URI) == 0 {
+			w.Write(1, "Registration: Not registered\n")
+		} else {
+			w.Write(1, "Registration: Registered\n")
+			w.Write(1, "URI: %s\n", acmeStatus.URI)
+			w.Write(1, "Last Registered Email: %s\n", valueOrNone(acmeStatus.LastRegisteredEmail))
+		}
+	}
+
+	tabWriter.Flush()
+	return buf.String() + status.String()
+}
+
+// issuerType returns the type of the issuer configured by spec, e.g. "ACME"
+// or "Vault".
+func issuerType(spec *cmapi.IssuerSpec) string {
+	switch {
+	case spec.ACME != nil:
+		return "ACME"
+	case spec.CA != nil:
+		return "CA"
+	case spec.Vault != nil:
+		return "Vault"
+	case spec.SelfSigned != nil:
+		return "SelfSigned"
+	case spec.Venafi != nil:
+		return "Venafi"
+	default:
+		return "<unknown>"
+	}
+}
+
+func valueOrNone(value string) string {
+	if len(value) == 0 {
+		return "<none>"
+	}
+	return value
+}
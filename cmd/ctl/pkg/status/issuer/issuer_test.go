@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestNewCmdStatusIssuer(t *testing.T) {
+	// The flags of the command must not clash with the kubeconfig flags
+	// added by the factory, e.g. --cluster
+	cmd := NewCmdStatusIssuer(context.TODO(), genericclioptions.NewTestIOStreamsDiscard())
+	if cmd.Flags().Lookup("cluster-issuer") == nil {
+		t.Error("expected the --cluster-issuer flag to be registered")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args      []string
+		expErrMsg string
+	}{
+		"no name throws error": {
+			expErrMsg: "the name of the Issuer has to be provided as argument",
+		},
+		"multiple names throw error": {
+			args:      []string{"issuer-1", "issuer-2"},
+			expErrMsg: "only one argument can be passed in: the name of the Issuer",
+		},
+		"single name is valid": {
+			args: []string{"issuer-1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewOptions(genericclioptions.NewTestIOStreamsDiscard()).Validate(test.args)
+			if test.expErrMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if test.expErrMsg != "" && (err == nil || err.Error() != test.expErrMsg) {
+				t.Errorf("expected error %q, got %v", test.expErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	registered := gen.Issuer("issuer-1",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerACMEURL("https://acme.example.com/directory"),
+		gen.SetIssuerACMEEmail("admin@example.com"),
+		gen.SetIssuerACMEAccountURL("https://acme.example.com/acct/1"),
+		gen.SetIssuerACMELastRegisteredEmail("admin@example.com"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Reason: "ACMEAccountRegistered", Message: "The ACME account was registered with the ACME server", ObservedGeneration: 2}),
+	)
+	registered.Generation = 2
+	unregistered := gen.Issuer("issuer-2",
+		gen.SetIssuerNamespace("default"),
+		gen.SetIssuerACMEURL("https://acme.example.com/directory"),
+	)
+	clusterIssuer := gen.ClusterIssuer("ca",
+		gen.SetIssuerCASecretName("ca-key-pair"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionFalse, Reason: "ErrGetKeyPair", Message: "Error getting keypair for CA issuer", ObservedGeneration: 1}),
+	)
+	clusterIssuer.Generation = 2
+
+	tests := map[string]struct {
+		name          string
+		clusterIssuer bool
+
+		expOutput string
+		expErrMsg string
+	}{
+		"registered ACME Issuer": {
+			name: "issuer-1",
+			expOutput: `Name: issuer-1
+Namespace: default
+Created at: 0001-01-01T00:00:00Z
+Labels: <none>
+Annotations: <none>
+Type: ACME
+ACME Account:
+  Server: https://acme.example.com/directory
+  Email: admin@example.com
+  Registration: Registered
+  URI: https://acme.example.com/acct/1
+  Last Registered Email: admin@example.com
+Issuer:
+  Name: issuer-1
+  Kind: Issuer
+  Generation: 2, Observed Generation: 2
+  Conditions:
+    Ready: True, Reason: ACMEAccountRegistered, Message: The ACME account was registered with the ACME server
+  Events:  <none>
+`,
+		},
+		"unregistered ACME Issuer without conditions": {
+			name: "issuer-2",
+			expOutput: `Name: issuer-2
+Namespace: default
+Created at: 0001-01-01T00:00:00Z
+Labels: <none>
+Annotations: <none>
+Type: ACME
+ACME Account:
+  Server: https://acme.example.com/directory
+  Email: <none>
+  Registration: Not registered
+Issuer:
+  Name: issuer-2
+  Kind: Issuer
+  Conditions:
+    No Conditions set
+  Events:  <none>
+`,
+		},
+		"stale CA ClusterIssuer": {
+			name:          "ca",
+			clusterIssuer: true,
+			expOutput: `Name: ca
+Created at: 0001-01-01T00:00:00Z
+Labels: <none>
+Annotations: <none>
+Type: CA
+Issuer:
+  Name: ca
+  Kind: ClusterIssuer
+  Generation: 2, Observed Generation: 1
+  The controller has not observed the latest generation of the spec yet, the conditions may be outdated
+  Conditions:
+    Ready: False, Reason: ErrGetKeyPair, Message: Error getting keypair for CA issuer
+  Events:  <none>
+`,
+		},
+		"missing Issuer throws error": {
+			name:      "ca",
+			expErrMsg: `error when getting Issuer resource: issuers.cert-manager.io "ca" not found`,
+		},
+		"missing ClusterIssuer throws error": {
+			name:          "issuer-1",
+			clusterIssuer: true,
+			expErrMsg:     `error when getting ClusterIssuer resource: clusterissuers.cert-manager.io "issuer-1" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			o := &Options{
+				ClusterIssuer: test.clusterIssuer,
+				IOStreams:     genericclioptions.IOStreams{Out: &out, ErrOut: &out},
+				Factory: &factory.Factory{
+					CMClient:   cmfake.NewSimpleClientset(registered, unregistered, clusterIssuer),
+					KubeClient: kubefake.NewSimpleClientset(),
+					Namespace:  "default",
+				},
+			}
+
+			err := o.Run(context.TODO(), []string{test.name})
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("expected error %q, got %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+		})
+	}
+}
@@ -23,16 +23,18 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/certificate"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status/issuer"
 )
 
 func NewCmdStatus(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	cmds := &cobra.Command{
 		Use:   "status",
 		Short: "Get details on current status of cert-manager resources",
-		Long:  `Get details on current status of cert-manager resources, e.g. Certificate`,
+		Long:  `Get details on current status of cert-manager resources, e.g. Certificate or Issuer`,
 	}
 
 	cmds.AddCommand(certificate.NewCmdStatusCert(ctx, ioStreams))
+	cmds.AddCommand(issuer.NewCmdStatusIssuer(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// ANSI escape codes used to colorize the human readable output. All colors
+// are of the same length, so that cells of a column colorized differently
+// remain aligned by a tabwriter.Writer.
+const (
+	ColorGreen   = "\x1b[32m"
+	ColorYellow  = "\x1b[33m"
+	ColorRed     = "\x1b[31m"
+	ColorDefault = "\x1b[39m"
+	ColorReset   = "\x1b[0m"
+)
+
+// ColorMode controls whether the human readable output is colorized.
+type ColorMode string
+
+const (
+	// ColorAuto colorizes output written to a terminal, unless NO_COLOR is
+	// set or the terminal is dumb
+	ColorAuto ColorMode = "auto"
+	// ColorAlways always colorizes the output
+	ColorAlways ColorMode = "always"
+	// ColorNever never colorizes the output
+	ColorNever ColorMode = "never"
+)
+
+// colorMode is the mode given by --color.
+var colorMode = ColorAuto
+
+// String implements pflag.Value.
+func (m *ColorMode) String() string {
+	return string(*m)
+}
+
+// Set implements pflag.Value, accepting only the known modes.
+func (m *ColorMode) Set(value string) error {
+	switch mode := ColorMode(value); mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		*m = mode
+		return nil
+	default:
+		return fmt.Errorf("must be one of %q, %q or %q", ColorAuto, ColorAlways, ColorNever)
+	}
+}
+
+// Type implements pflag.Value.
+func (m *ColorMode) Type() string {
+	return "string"
+}
+
+// AddColorFlags adds the --color flag to flags.
+func AddColorFlags(flags *pflag.FlagSet) {
+	flags.Var(&colorMode, "color", ""+
+		"Whether to colorize the human readable output, one of 'auto', 'always' or 'never'. "+
+		"With 'auto', output is only colorized if it is written to a terminal and NO_COLOR is not set.")
+}
+
+// ColorEnabled returns whether output written to w is to be colorized,
+// according to the mode given by --color.
+func ColorEnabled(w io.Writer) bool {
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return false
+	}
+	return os.Getenv("TERM") != "dumb"
+}
+
+// Colorize returns s in the given color, if color is enabled.
+func Colorize(s, colorCode string, color bool) string {
+	if !color {
+		return s
+	}
+	return colorCode + s + ColorReset
+}
+
+// ColorizeConditionStatus returns the status of a condition colorized
+// depending on its value: True in green, False in red and Unknown in yellow.
+func ColorizeConditionStatus(status string, color bool) string {
+	switch status {
+	case "True":
+		return Colorize(status, ColorGreen, color)
+	case "False":
+		return Colorize(status, ColorRed, color)
+	case "Unknown":
+		return Colorize(status, ColorYellow, color)
+	default:
+		return status
+	}
+}
+
+// colorizeEventType returns the type of an event colorized: Normal in green,
+// Warning in red and anything else, e.g. the column header, in the default
+// color. The escape codes are counted by a tabwriter.Writer, so every cell of
+// the column gets codes of the same length to stay aligned.
+func colorizeEventType(eventType string, color bool) ColoredText {
+	if !color {
+		return ColoredText(printers.EscapeTerminal(eventType))
+	}
+	switch eventType {
+	case "Normal":
+		return ColoredText(Colorize(eventType, ColorGreen, color))
+	case "Warning":
+		return ColoredText(Colorize(eventType, ColorRed, color))
+	default:
+		return ColoredText(Colorize(printers.EscapeTerminal(eventType), ColorDefault, color))
+	}
+}
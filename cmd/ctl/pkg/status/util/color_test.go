@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorEnabled(t *testing.T) {
+	defer func(mode ColorMode) { colorMode = mode }(colorMode)
+
+	tests := map[string]struct {
+		mode      string
+		expErr    bool
+		expEnable bool
+	}{
+		"auto doesn't colorize output which is not a terminal": {
+			mode:      "auto",
+			expEnable: false,
+		},
+		"always colorizes output which is not a terminal": {
+			mode:      "always",
+			expEnable: true,
+		},
+		"never doesn't colorize": {
+			mode:      "never",
+			expEnable: false,
+		},
+		"unknown modes are rejected": {
+			mode:   "sometimes",
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			colorMode = ColorAuto
+			err := colorMode.Set(test.mode)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expEnable, ColorEnabled(&bytes.Buffer{}))
+		})
+	}
+}
+
+func TestPrefixWriterEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewPrefixWriter(&buf)
+	w.Write(1, "%s %s %d\n", "evil\x1b[2J", ColoredText(Colorize("True", ColorGreen, true)), 3)
+
+	assert.Equal(t, "  evil^[[2J \x1b[32mTrue\x1b[0m 3\n", buf.String())
+}
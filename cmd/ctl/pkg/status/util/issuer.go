@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// IssuerRefWithDefaults returns the issuerRef of crt with the defaults of the
+// cert-manager controller applied: an omitted kind refers to an Issuer and an
+// omitted group to the cert-manager.io group.
+func IssuerRefWithDefaults(crt *cmapi.Certificate) cmmeta.ObjectReference {
+	ref := crt.Spec.IssuerRef
+	if ref.Kind == "" {
+		ref.Kind = cmapi.IssuerKind
+	}
+	if ref.Group == "" {
+		ref.Group = certmanager.GroupName
+	}
+	return ref
+}
+
+// IssuerReadyCondition returns the Ready condition of conditions, or nil if it
+// is not set.
+func IssuerReadyCondition(conditions []cmapi.IssuerCondition) *cmapi.IssuerCondition {
+	for i := range conditions {
+		if conditions[i].Type == cmapi.IssuerConditionReady {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
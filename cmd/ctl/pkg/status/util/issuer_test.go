@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func TestIssuerRefWithDefaults(t *testing.T) {
+	tests := map[string]struct {
+		issuerRef cmmeta.ObjectReference
+		expRef    cmmeta.ObjectReference
+	}{
+		"an omitted kind and group default to an Issuer of cert-manager.io": {
+			issuerRef: cmmeta.ObjectReference{Name: "ca"},
+			expRef:    cmmeta.ObjectReference{Name: "ca", Kind: "Issuer", Group: "cert-manager.io"},
+		},
+		"a given kind and group are kept": {
+			issuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "example.com"},
+			expRef:    cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{IssuerRef: test.issuerRef}}
+			assert.Equal(t, test.expRef, IssuerRefWithDefaults(crt))
+		})
+	}
+}
+
+func TestIssuerReadyCondition(t *testing.T) {
+	ready := cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue, Reason: "ACMEAccountRegistered"}
+
+	tests := map[string]struct {
+		conditions []cmapi.IssuerCondition
+		expCond    *cmapi.IssuerCondition
+	}{
+		"no conditions": {},
+		"the Ready condition is returned": {
+			conditions: []cmapi.IssuerCondition{{Type: "Other", Status: cmmeta.ConditionFalse}, ready},
+			expCond:    &ready,
+		},
+		"other conditions are not returned": {
+			conditions: []cmapi.IssuerCondition{{Type: "Other", Status: cmmeta.ConditionFalse}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expCond, IssuerReadyCondition(test.conditions))
+		})
+	}
+}
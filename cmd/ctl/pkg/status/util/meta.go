@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/describe"
+)
+
+// skipAnnotations are not rendered by DescribeObjectMeta, as they only repeat
+// the object itself.
+var skipAnnotations = map[string]bool{
+	corev1.LastAppliedConfigAnnotation: true,
+}
+
+// DescribeObjectMeta writes the name, namespace, creation time, labels and
+// annotations of obj with PrefixWriter, to be used as the header of the
+// status of an object. The namespace is omitted for cluster scoped objects.
+func DescribeObjectMeta(obj metav1.Object, w describe.PrefixWriter, baseLevel int) {
+	w.Write(baseLevel, "Name: %s\n", obj.GetName())
+	if len(obj.GetNamespace()) > 0 {
+		w.Write(baseLevel, "Namespace: %s\n", obj.GetNamespace())
+	}
+	w.Write(baseLevel, "Created at: %s\n", obj.GetCreationTimestamp().Time.Format(time.RFC3339))
+	describeMap("Labels", obj.GetLabels(), nil, w, baseLevel)
+	describeMap("Annotations", obj.GetAnnotations(), skipAnnotations, w, baseLevel)
+}
+
+// describeMap writes the entries of m sorted by key, one per line below the
+// title, or "<none>" if m has no entries left after leaving out skip.
+func describeMap(title string, m map[string]string, skip map[string]bool, w describe.PrefixWriter, baseLevel int) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if !skip[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		w.Write(baseLevel, "%s: <none>\n", title)
+		return
+	}
+
+	sort.Strings(keys)
+	w.Write(baseLevel, "%s:\n", title)
+	for _, key := range keys {
+		w.Write(baseLevel+1, "%s=%s\n", key, m[key])
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/describe"
+)
+
+// ColoredText is text colorized with ANSI escape codes, which the
+// PrefixWriter returned by NewPrefixWriter writes unescaped.
+type ColoredText string
+
+// prefixWriter is a copy of the PrefixWriter of the describe package, which
+// escapes terminal control characters in the arguments rather than in the
+// whole output, so that ColoredText arguments keep their escape codes.
+type prefixWriter struct {
+	out io.Writer
+}
+
+var _ describe.PrefixWriter = &prefixWriter{}
+
+// NewPrefixWriter creates a new PrefixWriter writing to out. Terminal control
+// characters in the arguments are escaped, unless they are ColoredText.
+func NewPrefixWriter(out io.Writer) describe.PrefixWriter {
+	return &prefixWriter{out: out}
+}
+
+func (pw *prefixWriter) Write(level int, format string, a ...interface{}) {
+	prefix := ""
+	for i := 0; i < level; i++ {
+		prefix += "  "
+	}
+	fmt.Fprintf(pw.out, prefix+format, escapeArgs(a)...)
+}
+
+func (pw *prefixWriter) WriteLine(a ...interface{}) {
+	fmt.Fprintln(pw.out, escapeArgs(a)...)
+}
+
+func (pw *prefixWriter) Flush() {
+	if f, ok := pw.out.(flusher); ok {
+		f.Flush()
+	}
+}
+
+type flusher interface {
+	Flush()
+}
+
+// escapeArgs returns a copy of args with terminal control characters escaped
+// in all text, except ColoredText.
+func escapeArgs(args []interface{}) []interface{} {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case ColoredText:
+			escaped[i] = string(arg)
+		case string:
+			escaped[i] = printers.EscapeTerminal(arg)
+		case fmt.Stringer, error:
+			escaped[i] = printers.EscapeTerminal(fmt.Sprint(arg))
+		default:
+			escaped[i] = arg
+		}
+	}
+	return escaped
+}
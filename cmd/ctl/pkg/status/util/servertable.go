@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// tableAcceptHeader requests the list rendered as a Table by the API server,
+// the same way kubectl does, and the plain list if the server doesn't
+// support it.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json"
+
+// ErrServerPrintUnsupported is returned by ServerTable if the API server did
+// not render the requested list as a Table.
+var ErrServerPrintUnsupported = errors.New("the API server does not support printing the resource as a table")
+
+// ServerTable lists the resources in namespace, or in all namespaces if
+// namespace is empty, and returns the headers and rows of the table rendered
+// by the API server. Only the columns which kubectl prints by default are
+// returned. If allNamespaces is true, a NAMESPACE column is prepended.
+// The columns of labelColumns are appended as they are for client-side
+// rendered tables.
+func ServerTable(ctx context.Context, client rest.Interface, resource, namespace string, opts metav1.ListOptions,
+	allNamespaces bool, labelColumns LabelColumnOptions) ([]string, [][]string, error) {
+	raw, err := client.Get().
+		NamespaceIfScoped(namespace, namespace != "").
+		Resource(resource).
+		VersionedParams(&opts, metav1.ParameterCodec).
+		Param("includeObject", string(metav1.IncludeMetadata)).
+		SetHeader("Accept", tableAcceptHeader).
+		Do(ctx).
+		Raw()
+	if apierrors.IsNotAcceptable(err) || apierrors.IsUnsupportedMediaType(err) {
+		return nil, nil, ErrServerPrintUnsupported
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var table metav1.Table
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, nil, fmt.Errorf("error when decoding the table returned by the API server: %w", err)
+	}
+	if table.Kind != "Table" || len(table.ColumnDefinitions) == 0 {
+		return nil, nil, ErrServerPrintUnsupported
+	}
+
+	var headers []string
+	var columns []int
+	for i, column := range table.ColumnDefinitions {
+		// Columns with a priority other than 0 are only printed by kubectl
+		// when using -o wide
+		if column.Priority != 0 {
+			continue
+		}
+		headers = append(headers, strings.ToUpper(column.Name))
+		columns = append(columns, i)
+	}
+	if allNamespaces {
+		headers = append([]string{"NAMESPACE"}, headers...)
+	}
+	headers = labelColumns.Headers(headers)
+
+	rows := make([][]string, 0, len(table.Rows))
+	for _, tableRow := range table.Rows {
+		var meta metav1.PartialObjectMetadata
+		if len(tableRow.Object.Raw) > 0 {
+			if err := json.Unmarshal(tableRow.Object.Raw, &meta); err != nil {
+				return nil, nil, fmt.Errorf("error when decoding the metadata of a table row: %w", err)
+			}
+		}
+
+		row := make([]string, 0, len(headers))
+		if allNamespaces {
+			row = append(row, meta.Namespace)
+		}
+		for _, i := range columns {
+			row = append(row, formatCell(tableRow.Cells, i))
+		}
+		rows = append(rows, labelColumns.Row(row, meta.Labels))
+	}
+
+	return headers, rows, nil
+}
+
+// formatCell returns the i-th cell of cells as printed by kubectl.
+func formatCell(cells []interface{}, i int) string {
+	if i >= len(cells) || cells[i] == nil {
+		return "<none>"
+	}
+	return fmt.Sprint(cells[i])
+}
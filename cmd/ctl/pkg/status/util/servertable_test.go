@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakerest "k8s.io/client-go/rest/fake"
+)
+
+const testTable = `{
+	"kind": "Table",
+	"apiVersion": "meta.k8s.io/v1",
+	"columnDefinitions": [
+		{"name": "Name", "type": "string", "priority": 0},
+		{"name": "Ready", "type": "string", "priority": 0},
+		{"name": "Status", "type": "string", "priority": 1},
+		{"name": "Age", "type": "date", "priority": 0}
+	],
+	"rows": [
+		{
+			"cells": ["crt-1", "True", "Certificate is up to date and has not expired", "5d"],
+			"object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "crt-1", "namespace": "ns-1", "labels": {"team": "payments"}}}
+		},
+		{
+			"cells": ["crt-2", null, null, "10m"],
+			"object": {"kind": "PartialObjectMetadata", "apiVersion": "meta.k8s.io/v1", "metadata": {"name": "crt-2", "namespace": "ns-2"}}
+		}
+	]
+}`
+
+func TestServerTable(t *testing.T) {
+	tests := map[string]struct {
+		statusCode    int
+		body          string
+		allNamespaces bool
+		labelColumns  LabelColumnOptions
+
+		expHeaders []string
+		expRows    [][]string
+		expErr     error
+	}{
+		"table returned by the API server is rendered without the wide columns": {
+			statusCode: http.StatusOK,
+			body:       testTable,
+			expHeaders: []string{"NAME", "READY", "AGE"},
+			expRows: [][]string{
+				{"crt-1", "True", "5d"},
+				{"crt-2", "<none>", "10m"},
+			},
+		},
+		"namespace and label columns are added from the metadata of the rows": {
+			statusCode:    http.StatusOK,
+			body:          testTable,
+			allNamespaces: true,
+			labelColumns:  LabelColumnOptions{LabelColumns: []string{"team"}},
+			expHeaders:    []string{"NAMESPACE", "NAME", "READY", "AGE", "TEAM"},
+			expRows: [][]string{
+				{"ns-1", "crt-1", "True", "5d", "payments"},
+				{"ns-2", "crt-2", "<none>", "10m", ""},
+			},
+		},
+		"a plain list returned by the API server is not supported": {
+			statusCode: http.StatusOK,
+			body:       `{"kind": "CertificateList", "apiVersion": "cert-manager.io/v1", "items": []}`,
+			expErr:     ErrServerPrintUnsupported,
+		},
+		"a not acceptable response from the API server is not supported": {
+			statusCode: http.StatusNotAcceptable,
+			expErr:     ErrServerPrintUnsupported,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakerest.RESTClient{
+				NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+				Resp: &http.Response{
+					StatusCode: test.statusCode,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(test.body)),
+				},
+			}
+
+			headers, rows, err := ServerTable(context.TODO(), client, "certificates", "", metav1.ListOptions{},
+				test.allNamespaces, test.labelColumns)
+			if !errors.Is(err, test.expErr) {
+				t.Fatalf("Unexpected error; expected: %v, actual: %v", test.expErr, err)
+			}
+			if accept := client.Req.Header.Get("Accept"); accept != tableAcceptHeader {
+				t.Errorf("Unexpected Accept header; expected: %q, actual: %q", tableAcceptHeader, accept)
+			}
+			if !reflect.DeepEqual(headers, test.expHeaders) {
+				t.Errorf("Unexpected headers; expected: %v, actual: %v", test.expHeaders, headers)
+			}
+			if !reflect.DeepEqual(rows, test.expRows) {
+				t.Errorf("Unexpected rows; expected: %v, actual: %v", test.expRows, rows)
+			}
+		})
+	}
+}
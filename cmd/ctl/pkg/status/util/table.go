@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// WriteTable writes the headers and rows as tab aligned columns to w, in the
+// same layout as the tables printed by kubectl. The longest columns are
+// truncated to fit the table into the width given by OutputWidth.
+func WriteTable(w io.Writer, headers []string, rows [][]string) error {
+	if width := OutputWidth(w); width > 0 {
+		lines := append([][]string{append([]string(nil), headers...)}, copyRows(rows)...)
+		FitColumns(lines, width, 0)
+		headers, rows = lines[0], lines[1:]
+	}
+
+	tabWriter := NewTabWriter(w)
+	fmt.Fprintln(tabWriter, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tabWriter, strings.Join(row, "\t"))
+	}
+	return tabWriter.Flush()
+}
+
+// copyRows returns a copy of rows which can be modified without modifying rows.
+func copyRows(rows [][]string) [][]string {
+	copied := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		copied = append(copied, append([]string(nil), row...))
+	}
+	return copied
+}
+
+// WriteTableObject writes the headers and rows to w as a JSON encoded
+// meta.k8s.io/v1 Table, the format in which the API server renders tables
+// for kubectl, so that generic tools can render it. Every cell is a string,
+// and the column names are the headers in the casing used by the API server,
+// e.g. "Not After" for the header "NOT AFTER".
+func WriteTableObject(w io.Writer, headers []string, rows [][]string) error {
+	table := &metav1.Table{
+		TypeMeta:          metav1.TypeMeta{APIVersion: metav1.SchemeGroupVersion.String(), Kind: "Table"},
+		ColumnDefinitions: make([]metav1.TableColumnDefinition, 0, len(headers)),
+		Rows:              make([]metav1.TableRow, 0, len(rows)),
+	}
+	for _, header := range headers {
+		column := metav1.TableColumnDefinition{Name: columnName(header), Type: "string"}
+		if header == "NAME" {
+			column.Format = "name"
+		}
+		table.ColumnDefinitions = append(table.ColumnDefinitions, column)
+	}
+	for _, row := range rows {
+		cells := make([]interface{}, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, cell)
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+
+	return (&printers.JSONPrinter{}).PrintObj(table, w)
+}
+
+// columnName returns the name of the Table column which kubectl prints with
+// the given upper-cased header.
+func columnName(header string) string {
+	words := strings.Fields(strings.ToLower(header))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// LabelColumnOptions holds the flags used to add the labels of the listed
+// objects as columns of a table, mirroring kubectl's --show-labels and
+// --label-columns flags.
+type LabelColumnOptions struct {
+	// If true, a column containing all labels of the object is appended
+	ShowLabels bool
+	// Label keys which are each rendered as a dedicated column
+	LabelColumns []string
+}
+
+// AddFlags adds the --show-labels and --label-columns flags to cmd.
+func (o *LabelColumnOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.ShowLabels, "show-labels", o.ShowLabels,
+		"When printing a table, show all labels as the last column")
+	cmd.Flags().StringSliceVarP(&o.LabelColumns, "label-columns", "L", o.LabelColumns,
+		"Accepts a comma separated list of labels that are going to be presented as columns when printing a table, e.g. -L team,env")
+}
+
+// Headers returns headers with the configured label columns appended.
+// As in kubectl, the header of a label column is the upper-cased name of the
+// label key without its prefix.
+func (o *LabelColumnOptions) Headers(headers []string) []string {
+	for _, key := range o.LabelColumns {
+		p := strings.Split(key, "/")
+		headers = append(headers, strings.ToUpper(p[len(p)-1]))
+	}
+	if o.ShowLabels {
+		headers = append(headers, "LABELS")
+	}
+	return headers
+}
+
+// Row returns row with the configured label columns of an object with
+// objLabels appended. Labels missing on the object are rendered empty.
+func (o *LabelColumnOptions) Row(row []string, objLabels map[string]string) []string {
+	for _, key := range o.LabelColumns {
+		row = append(row, objLabels[key])
+	}
+	if o.ShowLabels {
+		row = append(row, labels.FormatLabels(objLabels))
+	}
+	return row
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteTable(t *testing.T) {
+	objLabels := []map[string]string{
+		{"team": "payments", "example.com/env": "prod"},
+		{"team": "identity"},
+		nil,
+	}
+
+	tests := map[string]struct {
+		opts      LabelColumnOptions
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"No label options renders the plain table": {
+			expOutput: `NAME   READY
+crt-1  True
+crt-2  False
+crt-3  Unknown
+`,
+		},
+		"Label columns render one column per key with missing values empty": {
+			opts: LabelColumnOptions{LabelColumns: []string{"team", "example.com/env"}},
+			expOutput: `NAME   READY    TEAM      ENV
+crt-1  True     payments  prod
+crt-2  False    identity  
+crt-3  Unknown            
+`,
+		},
+		"Show labels appends all labels as the last column": {
+			opts: LabelColumnOptions{ShowLabels: true, LabelColumns: []string{"team"}},
+			expOutput: `NAME   READY    TEAM      LABELS
+crt-1  True     payments  example.com/env=prod,team=payments
+crt-2  False    identity  team=identity
+crt-3  Unknown            <none>
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rows := [][]string{
+				test.opts.Row([]string{"crt-1", "True"}, objLabels[0]),
+				test.opts.Row([]string{"crt-2", "False"}, objLabels[1]),
+				test.opts.Row([]string{"crt-3", "Unknown"}, objLabels[2]),
+			}
+
+			var buf bytes.Buffer
+			if err := WriteTable(&buf, test.opts.Headers([]string{"NAME", "READY"}), rows); err != nil {
+				t.Fatal(err)
+			}
+			if actualOutput := buf.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestWriteTableObject(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"NAME", "READY", "NOT AFTER"}
+	rows := [][]string{
+		{"crt-1", "True", "2022-03-01T00:00:00Z"},
+		{"crt-2", "Unknown", "<none>"},
+	}
+	if err := WriteTableObject(&buf, headers, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	expOutput := `{
+    "kind": "Table",
+    "apiVersion": "meta.k8s.io/v1",
+    "metadata": {},
+    "columnDefinitions": [
+        {
+            "name": "Name",
+            "type": "string",
+            "format": "name",
+            "description": "",
+            "priority": 0
+        },
+        {
+            "name": "Ready",
+            "type": "string",
+            "format": "",
+            "description": "",
+            "priority": 0
+        },
+        {
+            "name": "Not After",
+            "type": "string",
+            "format": "",
+            "description": "",
+            "priority": 0
+        }
+    ],
+    "rows": [
+        {
+            "cells": [
+                "crt-1",
+                "True",
+                "2022-03-01T00:00:00Z"
+            ],
+            "object": null
+        },
+        {
+            "cells": [
+                "crt-2",
+                "Unknown",
+                "\u003cnone\u003e"
+            ],
+            "object": null
+        }
+    ]
+}
+`
+	if actualOutput := buf.String(); actualOutput != expOutput {
+		t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", expOutput, actualOutput)
+	}
+}
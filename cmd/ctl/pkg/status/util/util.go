@@ -22,24 +22,29 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/kubectl/pkg/describe"
 	"k8s.io/kubectl/pkg/util/event"
+	k8sclock "k8s.io/utils/clock"
 )
 
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
 // This file contains functions that are copied from "k8s.io/kubectl/pkg/describe".
-// DescribeEvents was slightly modified. The other functions are copied over.
+// DescribeEvents was modified to render the event count and the span between first and last
+// occurrence in a dedicated column. The other functions are copied over.
 // The purpose of this is to be able to reuse the PrefixWriter interface defined in the describe package,
 // and because we need to indent certain lines differently than the original function.
 
 // DescribeEvents writes a formatted string of the Events in el with PrefixWriter.
 // The intended use is for w to be created with a *tabWriter.Writer underneath, and the caller
 // of DescribeEvents would need to call Flush() on that *tabWriter.Writer to actually print the output.
-func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int) {
+// If color is true, the type of the Events is colorized. The columns are fitted
+// to width as described by FitColumns, they are not limited if width is 0.
+func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int, color bool, width int) {
 	if el == nil || len(el.Items) == 0 {
 		w.Write(baseLevel, "Events:\t<none>\n")
 		w.Flush()
@@ -48,31 +53,73 @@ func DescribeEvents(el *corev1.EventList, w describe.PrefixWriter, baseLevel int
 	w.Flush()
 	sort.Sort(event.SortableEvents(el.Items))
 	w.Write(baseLevel, "Events:\n")
-	w.Write(baseLevel+1, "Type\tReason\tAge\tFrom\tMessage\n")
-	w.Write(baseLevel+1, "----\t------\t----\t----\t-------\n")
+	rows := [][]string{
+		{"Type", "Reason", "Age", "Count", "From", "Message"},
+		{"----", "------", "----", "-----", "----", "-------"},
+	}
 	for _, e := range el.Items {
-		var interval string
-		if e.Count > 1 {
-			interval = fmt.Sprintf("%s (x%d over %s)", translateTimestampSince(e.LastTimestamp), e.Count, translateTimestampSince(e.FirstTimestamp))
-		} else {
-			interval = translateTimestampSince(e.FirstTimestamp)
-		}
-		w.Write(baseLevel+1, "%v\t%v\t%s\t%v\t%v\n",
+		rows = append(rows, []string{
 			e.Type,
 			e.Reason,
-			interval,
+			formatEventAge(e),
+			formatEventCount(e),
 			formatEventSource(e.Source),
 			strings.TrimSpace(e.Message),
-		)
+		})
+	}
+	// The descriptions are rendered into buffers before being printed, so
+	// the width of the output is given by the caller. PrefixWriter indents
+	// every level by two spaces.
+	FitColumns(rows, width, 2*(baseLevel+1))
+	for _, row := range rows {
+		// The type is colorized after fitting the columns, so that the
+		// escape codes don't count towards the width
+		w.Write(baseLevel+1, "%s\t%s\n", colorizeEventType(row[0], color), strings.Join(row[1:], "\t"))
 	}
 	w.Flush()
 }
 
+// DescribeList writes the values below the title with PrefixWriter, one per
+// line in the same way as the lists of 'status certificate', or "<none>" if
+// there are none.
+func DescribeList(title string, values []string, w describe.PrefixWriter, baseLevel int) {
+	if len(values) == 0 {
+		w.Write(baseLevel, "%s: <none>\n", title)
+		return
+	}
+	w.Write(baseLevel, "%s:\n", title)
+	for _, value := range values {
+		w.Write(baseLevel, "- %s\n", value)
+	}
+}
+
 // NewTabWriter returns a *tabwriter.Writer with fixed parameters to be used in the status command
 func NewTabWriter(writer io.Writer) *tabwriter.Writer {
 	return tabwriter.NewWriter(writer, 0, 8, 2, ' ', 0)
 }
 
+// formatEventAge returns the time elapsed since the Event was last seen. Falls back to
+// the first occurrence if the Event has never been repeated.
+func formatEventAge(e corev1.Event) string {
+	if !e.LastTimestamp.IsZero() {
+		return TranslateTimestampSince(e.LastTimestamp)
+	}
+	return TranslateTimestampSince(e.FirstTimestamp)
+}
+
+// formatEventCount returns the number of times the Event occurred. If the Event
+// occurred more than once, the duration between its first and last occurrence is
+// appended, e.g. "3 (over 10m)".
+func formatEventCount(e corev1.Event) string {
+	if e.Count <= 1 {
+		return "1"
+	}
+	if e.FirstTimestamp.IsZero() || e.LastTimestamp.IsZero() {
+		return fmt.Sprintf("%d", e.Count)
+	}
+	return fmt.Sprintf("%d (over %s)", e.Count, duration.HumanDuration(e.LastTimestamp.Sub(e.FirstTimestamp.Time)))
+}
+
 // formatEventSource formats EventSource as a comma separated string excluding Host when empty
 func formatEventSource(es corev1.EventSource) string {
 	EventSourceString := []string{es.Component}
@@ -82,12 +129,12 @@ func formatEventSource(es corev1.EventSource) string {
 	return strings.Join(EventSourceString, ", ")
 }
 
-// translateTimestampSince returns the elapsed time since timestamp in
+// TranslateTimestampSince returns the elapsed time since timestamp in
 // human-readable approximation.
-func translateTimestampSince(timestamp metav1.Time) string {
+func TranslateTimestampSince(timestamp metav1.Time) string {
 	if timestamp.IsZero() {
 		return "<unknown>"
 	}
 
-	return duration.HumanDuration(time.Since(timestamp.Time))
+	return duration.HumanDuration(clock.Since(timestamp.Time))
 }
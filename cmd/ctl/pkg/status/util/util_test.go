@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/describe"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestDescribeEvents(t *testing.T) {
+	now := time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC)
+	clock = fakeclock.NewFakeClock(now)
+
+	tests := map[string]struct {
+		events    *corev1.EventList
+		color     bool
+		width     int
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"No events": {
+			events: nil,
+			expOutput: `Events:  <none>
+`,
+		},
+		"Single event shows age since first seen and a count of 1": {
+			events: &corev1.EventList{Items: []corev1.Event{{
+				Type:           "Normal",
+				Reason:         "Issuing",
+				Message:        "Issuing certificate as Secret does not exist",
+				Source:         corev1.EventSource{Component: "cert-manager-certificates-trigger"},
+				FirstTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+			}}},
+			expOutput: `Events:
+  Type    Reason   Age   Count  From                               Message
+  ----    ------   ----  -----  ----                               -------
+  Normal  Issuing  5m    1      cert-manager-certificates-trigger  Issuing certificate as Secret does not exist
+`,
+		},
+		"Repeated event shows age since last seen, the count and the span": {
+			events: &corev1.EventList{Items: []corev1.Event{{
+				Type:           "Warning",
+				Reason:         "Failed",
+				Message:        "The certificate request has failed to complete and will be retried",
+				Source:         corev1.EventSource{Component: "cert-manager-certificates-issuing"},
+				Count:          4,
+				FirstTimestamp: metav1.NewTime(now.Add(-3 * time.Hour)),
+				LastTimestamp:  metav1.NewTime(now.Add(-10 * time.Minute)),
+			}}},
+			expOutput: `Events:
+  Type     Reason  Age   Count          From                               Message
+  ----     ------  ----  -----          ----                               -------
+  Warning  Failed  10m   4 (over 170m)  cert-manager-certificates-issuing  The certificate request has failed to complete and will be retried
+`,
+		},
+		"Columns are fitted to the width": {
+			events: &corev1.EventList{Items: []corev1.Event{{
+				Type:           "Normal",
+				Reason:         "Issuing",
+				Message:        "Issuing certificate as Secret does not exist",
+				Source:         corev1.EventSource{Component: "cert-manager-certificates-trigger"},
+				FirstTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+			}}},
+			width: 80,
+			expOutput: `Events:
+  Type    Reason   Age   Count  From           Message
+  ----    ------   ----  -----  ----           -------
+  Normal  Issuing  5m    1      cert-manag...  Issuing certificate as Secret ...
+`,
+		},
+		"Colorized event types stay aligned": {
+			events: &corev1.EventList{Items: []corev1.Event{
+				{
+					Type:           "Normal",
+					Reason:         "Issuing",
+					Message:        "Issuing certificate as Secret does not exist",
+					Source:         corev1.EventSource{Component: "cert-manager-certificates-trigger"},
+					FirstTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+				},
+				{
+					Type:           "Warning",
+					Reason:         "Failed",
+					Message:        "The certificate request has failed",
+					Source:         corev1.EventSource{Component: "cert-manager-certificates-issuing"},
+					FirstTimestamp: metav1.NewTime(now.Add(-2 * time.Minute)),
+				},
+			}},
+			color: true,
+			expOutput: "Events:\n" +
+				"  \x1b[39mType\x1b[0m     Reason   Age   Count  From                               Message\n" +
+				"  \x1b[39m----\x1b[0m     ------   ----  -----  ----                               -------\n" +
+				"  \x1b[32mNormal\x1b[0m   Issuing  5m    1      cert-manager-certificates-trigger  Issuing certificate as Secret does not exist\n" +
+				"  \x1b[31mWarning\x1b[0m  Failed   2m    1      cert-manager-certificates-issuing  The certificate request has failed\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tabWriter := NewTabWriter(&buf)
+			DescribeEvents(test.events, NewPrefixWriter(tabWriter), 0, test.color, test.width)
+			tabWriter.Flush()
+			if actualOutput := buf.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestDescribeObjectMeta(t *testing.T) {
+	created := metav1.NewTime(time.Date(2020, 9, 16, 9, 26, 18, 0, time.UTC))
+
+	tests := map[string]struct {
+		obj       metav1.Object
+		baseLevel int
+		expOutput string
+	}{
+		// Newlines are part of the expected output
+		"Object without labels and annotations": {
+			obj: &metav1.ObjectMeta{Name: "test-crt", Namespace: "default", CreationTimestamp: created},
+			expOutput: `Name: test-crt
+Namespace: default
+Created at: 2020-09-16T09:26:18Z
+Labels: <none>
+Annotations: <none>
+`,
+		},
+		"Cluster scoped object leaves out the namespace": {
+			obj: &metav1.ObjectMeta{Name: "test-issuer", CreationTimestamp: created},
+			expOutput: `Name: test-issuer
+Created at: 2020-09-16T09:26:18Z
+Labels: <none>
+Annotations: <none>
+`,
+		},
+		"Labels and annotations are sorted and the last applied configuration is left out": {
+			obj: &metav1.ObjectMeta{
+				Name:              "test-crt",
+				Namespace:         "default",
+				CreationTimestamp: created,
+				Labels:            map[string]string{"team": "web", "app": "frontend"},
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: `{"apiVersion":"cert-manager.io/v1"}`,
+					"example.com/owner":                "alice",
+				},
+			},
+			baseLevel: 1,
+			expOutput: `  Name: test-crt
+  Namespace: default
+  Created at: 2020-09-16T09:26:18Z
+  Labels:
+    app=frontend
+    team=web
+  Annotations:
+    example.com/owner=alice
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			DescribeObjectMeta(test.obj, describe.NewPrefixWriter(&buf), test.baseLevel)
+			if actualOutput := buf.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
+
+func TestDescribeList(t *testing.T) {
+	tests := map[string]struct {
+		values    []string
+		expOutput string
+	}{
+		"no values are written as <none>": {
+			expOutput: "  DNS Names: <none>\n",
+		},
+		"values are written one per line": {
+			values:    []string{"example.com", "www.example.com"},
+			expOutput: "  DNS Names:\n  - example.com\n  - www.example.com\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			DescribeList("DNS Names", test.values, NewPrefixWriter(&buf), 1)
+			if actualOutput := buf.String(); actualOutput != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, actualOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// outputWidth is the width tables are fitted to. If 0, tables written to a
+// terminal are fitted to its width. If negative, tables are never fitted.
+var outputWidth int
+
+// minColumnWidth is the width below which columns are not truncated, so that
+// truncated cells remain recognizable.
+const minColumnWidth = 10
+
+// columnPadding is the padding between columns, as used by NewTabWriter.
+const columnPadding = 2
+
+// AddOutputWidthFlags adds the --output-width flag to flags.
+func AddOutputWidthFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&outputWidth, "output-width", outputWidth, ""+
+		"Width in characters that tables, e.g. of events, are fitted to by truncating their longest columns. "+
+		"Defaults to the width of the terminal. Output which is not written to a terminal is not truncated unless set. "+
+		"Set to -1 to disable truncation.")
+}
+
+// OutputWidth returns the width that tables written to w are fitted to, which
+// is the width given by --output-width, or else the width of the terminal if
+// w is one. Returns 0 if the tables are not to be fitted.
+func OutputWidth(w io.Writer) int {
+	if outputWidth != 0 {
+		return max(outputWidth, 0)
+	}
+
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// FitColumns truncates the cells of rows, so that the rows fit into width
+// when written as tab aligned columns indented by indent characters. The widest
+// columns are truncated first, to no less than minColumnWidth, and truncated
+// cells end in "...". Does nothing if width is 0.
+func FitColumns(rows [][]string, width, indent int) {
+	if width <= 0 {
+		return
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	for {
+		total := indent + (len(widths)-1)*columnPadding
+		widest, secondWidest := -1, 0
+		for i, w := range widths {
+			total += w
+			if widest < 0 || w > widths[widest] {
+				if widest >= 0 {
+					secondWidest = widths[widest]
+				}
+				widest = i
+			} else if w > secondWidest {
+				secondWidest = w
+			}
+		}
+		if total <= width || widest < 0 || widths[widest] <= minColumnWidth {
+			break
+		}
+		// Shrink the widest column no further than the next widest, so that
+		// the excess is spread over the widest columns.
+		shrunk := max(widths[widest]-(total-width), minColumnWidth)
+		if secondWidest < widths[widest] {
+			shrunk = max(shrunk, secondWidest)
+		}
+		widths[widest] = shrunk
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if utf8.RuneCountInString(cell) > widths[i] {
+				row[i] = string([]rune(cell)[:widths[i]-3]) + "..."
+			}
+		}
+	}
+}
+
+func max(value int, values ...int) int {
+	for _, v := range values {
+		if v > value {
+			value = v
+		}
+	}
+	return value
+}
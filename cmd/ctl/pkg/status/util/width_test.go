@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitColumns(t *testing.T) {
+	rows := func() [][]string {
+		return [][]string{
+			{"Type", "Reason", "Message"},
+			{"Normal", "Issuing", "The certificate has been successfully issued"},
+			{"Warning", "Failed", "The certificate request has failed to complete and will be retried"},
+		}
+	}
+
+	tests := map[string]struct {
+		width   int
+		indent  int
+		expRows [][]string
+	}{
+		"rows are not truncated without a width": {
+			width:   0,
+			expRows: rows(),
+		},
+		"rows fitting into the width are not truncated": {
+			width:   100,
+			expRows: rows(),
+		},
+		"the widest column is truncated to fit": {
+			width: 40,
+			expRows: [][]string{
+				{"Type", "Reason", "Message"},
+				{"Normal", "Issuing", "The certificate has..."},
+				{"Warning", "Failed", "The certificate req..."},
+			},
+		},
+		"the indent is part of the width": {
+			width:  44,
+			indent: 4,
+			expRows: [][]string{
+				{"Type", "Reason", "Message"},
+				{"Normal", "Issuing", "The certificate has..."},
+				{"Warning", "Failed", "The certificate req..."},
+			},
+		},
+		"columns are not truncated below the minimum width": {
+			width: 10,
+			expRows: [][]string{
+				{"Type", "Reason", "Message"},
+				{"Normal", "Issuing", "The cer..."},
+				{"Warning", "Failed", "The cer..."},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := rows()
+			FitColumns(actual, test.width, test.indent)
+			assert.Equal(t, test.expRows, actual)
+		})
+	}
+}
+
+func TestWriteTableOutputWidth(t *testing.T) {
+	defer func(width int) { outputWidth = width }(outputWidth)
+
+	headers := []string{"NAME", "ISSUER"}
+	rows := [][]string{{"crt-1", "ClusterIssuer/letsencrypt-production"}}
+
+	tests := map[string]struct {
+		outputWidth int
+		expOutput   string
+	}{
+		"output which is not a terminal is not truncated by default": {
+			outputWidth: 0,
+			expOutput: `NAME   ISSUER
+crt-1  ClusterIssuer/letsencrypt-production
+`,
+		},
+		"output is truncated to the given width": {
+			outputWidth: 25,
+			expOutput: `NAME   ISSUER
+crt-1  ClusterIssuer/l...
+`,
+		},
+		"negative width disables truncation": {
+			outputWidth: -1,
+			expOutput: `NAME   ISSUER
+crt-1  ClusterIssuer/letsencrypt-production
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			outputWidth = test.outputWidth
+			var out bytes.Buffer
+			if err := WriteTable(&out, headers, rows); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expOutput {
+				t.Errorf("Unexpected output; expected: \n%s\nactual: \n%s", test.expOutput, out.String())
+			}
+			assert.Equal(t, "ClusterIssuer/letsencrypt-production", rows[0][1], "the rows must not be modified")
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	componentLabel = "app.kubernetes.io/component"
+	versionLabel   = "app.kubernetes.io/version"
+)
+
+// components are the values of the app.kubernetes.io/component label of the
+// cert-manager Deployments, in the order they are printed.
+var components = []string{"controller", "webhook", "cainjector"}
+
+// ComponentVersion is the version of a cert-manager component installed in
+// the cluster.
+type ComponentVersion struct {
+	// Component is one of controller, webhook or cainjector
+	Component string `json:"component"`
+	// Name of the Deployment running the component
+	Name string `json:"name"`
+	// Version of the component, read from the app.kubernetes.io/version
+	// label of the Deployment or else from the tag of its image
+	Version string `json:"version,omitempty"`
+	// Image of the container running the component
+	Image string `json:"image,omitempty"`
+}
+
+// componentVersions returns the versions of the cert-manager components
+// deployed in o.CertManagerNamespace, as found by the
+// app.kubernetes.io/component label of their Deployments.
+func (o *Options) componentVersions(ctx context.Context) ([]ComponentVersion, error) {
+	selector := fmt.Sprintf("%s in (%s)", componentLabel, strings.Join(components, ","))
+	deployments, err := o.KubeClient.AppsV1().Deployments(o.CertManagerNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]ComponentVersion, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		versions = append(versions, componentVersion(&deployment))
+	}
+	rank := map[string]int{}
+	for i, component := range components {
+		rank[component] = i
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].Component != versions[j].Component {
+			return rank[versions[i].Component] < rank[versions[j].Component]
+		}
+		return versions[i].Name < versions[j].Name
+	})
+	return versions, nil
+}
+
+// componentVersion returns the version of the component run by deployment.
+func componentVersion(deployment *appsv1.Deployment) ComponentVersion {
+	version := ComponentVersion{
+		Component: deployment.Labels[componentLabel],
+		Name:      deployment.Name,
+		Version:   deployment.Labels[versionLabel],
+	}
+	if containers := deployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+		version.Image = containers[0].Image
+	}
+	if len(version.Version) == 0 {
+		version.Version = imageTag(version.Image)
+	}
+	return version
+}
+
+// imageTag returns the tag of image, or an empty string if it has none, e.g.
+// if it is only referenced by digest.
+func imageTag(image string) string {
+	image = strings.SplitN(image, "@", 2)[0]
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		// The colon separates the port of the registry
+		return ""
+	}
+	return image[i+1:]
+}
+
+// writeComponentVersions writes the versions of the components to w, as
+// printed without --output.
+func writeComponentVersions(w io.Writer, versions []ComponentVersion) {
+	if len(versions) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Server Components:")
+	for _, version := range versions {
+		v := version.Version
+		if len(v) == 0 {
+			v = "<unknown>"
+		}
+		fmt.Fprintf(w, "  %s: %s (Deployment: %s, Image: %s)\n", version.Component, v, version.Name, version.Image)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+)
+
+func TestComponentVersions(t *testing.T) {
+	deployment := func(namespace, name string, labels map[string]string, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: image}}},
+				},
+			},
+		}
+	}
+
+	client := kubefake.NewSimpleClientset(
+		deployment("cert-manager", "cert-manager-webhook",
+			map[string]string{componentLabel: "webhook", versionLabel: "v1.12.0"},
+			"quay.io/jetstack/cert-manager-webhook:v1.12.0"),
+		deployment("cert-manager", "cert-manager",
+			map[string]string{componentLabel: "controller"},
+			"registry.example.com:5000/cert-manager-controller:v1.12.1"),
+		deployment("cert-manager", "cert-manager-cainjector",
+			map[string]string{componentLabel: "cainjector"},
+			"quay.io/jetstack/cert-manager-cainjector@sha256:0123"),
+		deployment("cert-manager", "trust-manager",
+			map[string]string{componentLabel: "trust-manager"},
+			"quay.io/jetstack/trust-manager:v0.5.0"),
+		deployment("other", "cert-manager",
+			map[string]string{componentLabel: "controller"},
+			"quay.io/jetstack/cert-manager-controller:v1.11.0"),
+	)
+
+	o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+	o.Factory = &factory.Factory{KubeClient: client}
+
+	versions, err := o.componentVersions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expVersions := []ComponentVersion{
+		{Component: "controller", Name: "cert-manager", Version: "v1.12.1", Image: "registry.example.com:5000/cert-manager-controller:v1.12.1"},
+		{Component: "webhook", Name: "cert-manager-webhook", Version: "v1.12.0", Image: "quay.io/jetstack/cert-manager-webhook:v1.12.0"},
+		{Component: "cainjector", Name: "cert-manager-cainjector", Image: "quay.io/jetstack/cert-manager-cainjector@sha256:0123"},
+	}
+	if !reflect.DeepEqual(versions, expVersions) {
+		t.Errorf("Unexpected versions; expected: %+v, actual: %+v", expVersions, versions)
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	tests := map[string]string{
+		"quay.io/jetstack/cert-manager-controller:v1.12.0":        "v1.12.0",
+		"registry.example.com:5000/cert-manager-controller":       "",
+		"registry.example.com:5000/cert-manager-controller:v1.12": "v1.12",
+		"cert-manager-controller:v1.12.0@sha256:0123":             "v1.12.0",
+		"cert-manager-controller@sha256:0123":                     "",
+	}
+
+	for image, expTag := range tests {
+		t.Run(image, func(t *testing.T) {
+			if tag := imageTag(image); tag != expTag {
+				t.Errorf("Unexpected tag; expected: %q, actual: %q", expTag, tag)
+			}
+		})
+	}
+}
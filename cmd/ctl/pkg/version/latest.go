@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+)
+
+// latestReleaseURL is the GitHub API endpoint returning the latest release of
+// cert-manager. cmctl is released together with cert-manager, so it is the
+// latest release of both. Pre-releases are never returned as the latest
+// release.
+const latestReleaseURL = "https://api.github.com/repos/cert-manager/cert-manager/releases/latest"
+
+// latestRelease returns the version of the latest cert-manager release, as
+// returned by the endpoint at o.LatestReleaseURL. The request is aborted
+// after o.Timeout.
+func (o *Options) latestRelease(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.LatestReleaseURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response from %s: %s", o.LatestReleaseURL, resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("error when decoding the response from %s: %w", o.LatestReleaseURL, err)
+	}
+	if _, err := version.ParseSemantic(release.TagName); err != nil {
+		return "", fmt.Errorf("invalid version of the latest release %q: %w", release.TagName, err)
+	}
+	return release.TagName, nil
+}
+
+// upgradeHints returns hints to upgrade cmctl or the installed cert-manager,
+// if either is older than the latest release, and to upgrade cmctl if it is
+// older than the installed cert-manager. Versions which are empty or not
+// semantic versions, e.g. of development builds, are not compared.
+func upgradeHints(clientVersion, serverVersion, latestVersion string) []string {
+	client, _ := version.ParseSemantic(clientVersion)
+	server, _ := version.ParseSemantic(serverVersion)
+	latest, _ := version.ParseSemantic(latestVersion)
+
+	var hints []string
+	if client != nil && latest != nil && client.LessThan(latest) {
+		hints = append(hints, fmt.Sprintf("%s %s is available, the installed version is %s", build.Name(), latestVersion, clientVersion))
+	} else if client != nil && server != nil && client.LessThan(server) {
+		hints = append(hints, fmt.Sprintf("%s %s is older than the installed cert-manager %s, consider upgrading it", build.Name(), clientVersion, serverVersion))
+	}
+	if server != nil && latest != nil && server.LessThan(latest) {
+		hints = append(hints, fmt.Sprintf("cert-manager %s is available, the installed cert-manager is %s", latestVersion, serverVersion))
+	}
+	return hints
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestLatestRelease(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+		delay  time.Duration
+
+		expVersion string
+		expErrMsg  string
+	}{
+		"the tag of the latest release is returned": {
+			status:     http.StatusOK,
+			body:       `{"tag_name": "v1.11.0", "name": "v1.11.0"}`,
+			expVersion: "v1.11.0",
+		},
+		"an unexpected status code throws error": {
+			status:    http.StatusForbidden,
+			body:      `{"message": "API rate limit exceeded"}`,
+			expErrMsg: "403 Forbidden",
+		},
+		"a tag which is not a semantic version throws error": {
+			status:    http.StatusOK,
+			body:      `{"tag_name": "latest"}`,
+			expErrMsg: `invalid version of the latest release "latest"`,
+		},
+		"a response exceeding the timeout throws error": {
+			status:    http.StatusOK,
+			body:      `{"tag_name": "v1.11.0"}`,
+			delay:     time.Second,
+			expErrMsg: "context deadline exceeded",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(test.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			o := NewOptions(genericclioptions.NewTestIOStreamsDiscard())
+			o.LatestReleaseURL = server.URL
+			o.Timeout = 100 * time.Millisecond
+
+			latest, err := o.latestRelease(context.Background())
+			if test.expErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expErrMsg) {
+					t.Errorf("Unexpected error; expected to contain: %s, actual: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if latest != test.expVersion {
+				t.Errorf("Unexpected version; expected: %s, actual: %s", test.expVersion, latest)
+			}
+		})
+	}
+}
+
+func TestUpgradeHints(t *testing.T) {
+	tests := map[string]struct {
+		client, server, latest string
+
+		expHints []string
+	}{
+		"no hints if all versions are the latest": {
+			client: "v1.11.0",
+			server: "v1.11.0",
+			latest: "v1.11.0",
+		},
+		"outdated client and server get a hint each": {
+			client: "v1.9.1",
+			server: "v1.10.2",
+			latest: "v1.11.0",
+			expHints: []string{
+				"cmctl v1.11.0 is available, the installed version is v1.9.1",
+				"cert-manager v1.11.0 is available, the installed cert-manager is v1.10.2",
+			},
+		},
+		"a client older than the server gets a hint without the latest release": {
+			client:   "v1.10.0",
+			server:   "v1.11.0",
+			expHints: []string{"cmctl v1.10.0 is older than the installed cert-manager v1.11.0, consider upgrading it"},
+		},
+		"versions which are not semantic versions are not compared": {
+			client: "canary",
+			server: "",
+			latest: "v1.11.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hints := upgradeHints(test.client, test.server, test.latest)
+			if !reflect.DeepEqual(hints, test.expHints) {
+				t.Errorf("Unexpected hints; expected: %v, actual: %v", test.expHints, hints)
+			}
+		})
+	}
+}
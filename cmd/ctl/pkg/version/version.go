@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -34,20 +35,37 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/versionchecker"
 )
 
+const defaultCertManagerNamespace = "cert-manager"
+
 // Version is a struct for version information
 type Version struct {
 	ClientVersion *util.Version           `json:"clientVersion,omitempty"`
 	ServerVersion *versionchecker.Version `json:"serverVersion,omitempty"`
+	// Versions of the cert-manager components deployed in the cluster
+	Components []ComponentVersion `json:"components,omitempty"`
+	// Version of the latest cert-manager release, only set with
+	// --check-latest
+	LatestVersion string `json:"latestVersion,omitempty"`
 }
 
 // Options is a struct to support version command
 type Options struct {
 	// If true, don't try to retrieve the installed version
 	ClientOnly bool
+	// Namespace the cert-manager components are deployed in
+	CertManagerNamespace string
 
 	// If true, only prints the version number.
 	Short bool
 
+	// If true, look up the latest cert-manager release and print hints to
+	// upgrade cmctl or the installed cert-manager
+	CheckLatest bool
+	// Length of time to wait for the latest release to be looked up
+	Timeout time.Duration
+	// Endpoint returning the latest cert-manager release
+	LatestReleaseURL string
+
 	// Output is the target output format for the version string. This may be of
 	// value "", "json" or "yaml".
 	Output string
@@ -61,7 +79,9 @@ type Options struct {
 // NewOptions returns initialized Options
 func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
 	return &Options{
-		IOStreams: ioStreams,
+		IOStreams:            ioStreams,
+		CertManagerNamespace: defaultCertManagerNamespace,
+		LatestReleaseURL:     latestReleaseURL,
 	}
 }
 
@@ -77,8 +97,19 @@ version information, the tool checks if all versions are the same and returns
 that version. If no version information is found or the found versions differ,
 an error will be displayed.
 
+The versions of the controller, webhook and cainjector components are listed as well, read
+from the 'app.kubernetes.io/version' label or the image tag of their Deployments in the
+namespace given by '--cert-manager-namespace'.
+
 The '--client' flag can be used to disable the logic that tries to determine the installed
-cert-manager version.
+cert-manager version. If no cluster is configured or the cluster
+cannot be reached, only the client version is printed together with a note.
+
+The '--check-latest' flag looks up the latest cert-manager release on GitHub, and prints
+a hint to upgrade the CLI or the installed cert-manager if either of them is older, or
+to upgrade the CLI if it is older than the installed cert-manager. The command doesn't
+fail if the latest release cannot be looked up, e.g. without network access, a warning
+is printed instead.
 
 Some example uses:
 	$ {{.BuildName}} version
@@ -88,6 +119,8 @@ or
 	$ {{.BuildName}} version --short
 or
 	$ {{.BuildName}} version -o yaml
+or
+	$ {{.BuildName}} version --check-latest
 `)
 }
 
@@ -107,16 +140,23 @@ func NewCmdVersion(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	}
 
 	cmd.Flags().BoolVar(&o.ClientOnly, "client", o.ClientOnly, "If true, shows client version only (no server required).")
+	cmd.Flags().StringVar(&o.CertManagerNamespace, "cert-manager-namespace", o.CertManagerNamespace, "Namespace the cert-manager components are deployed in, their versions are read from their Deployments.")
 	cmd.Flags().BoolVar(&o.Short, "short", o.Short, "If true, print just the version number.")
 	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "One of 'yaml' or 'json'.")
+	cmd.Flags().BoolVar(&o.CheckLatest, "check-latest", o.CheckLatest, "If true, look up the latest cert-manager release and print a hint to upgrade if the client or the installed cert-manager is older. Requires network access, a warning is printed if the lookup fails.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 10*time.Second, "Time to wait for the latest release to be looked up by --check-latest, must include unit, e.g. 10s or 1m.")
 
-	o.Factory = factory.New(ctx, cmd)
+	o.Factory = factory.NewOptional(ctx, cmd)
 
 	return cmd
 }
 
 // Validate validates the provided options
 func (o *Options) Validate() error {
+	if o.CheckLatest && o.Timeout <= 0 {
+		return errors.New("the --timeout flag must be greater than 0")
+	}
+
 	switch o.Output {
 	case "", "yaml", "json":
 		return nil
@@ -130,6 +170,11 @@ func (o *Options) Complete() error {
 	if o.ClientOnly {
 		return nil
 	}
+	if o.Factory == nil || o.RESTConfig == nil {
+		fmt.Fprintln(o.ErrOut, "note: no cluster configured, only the client version is printed")
+		o.ClientOnly = true
+		return nil
+	}
 
 	versionChecker, err := versionchecker.New(o.RESTConfig, scheme.Scheme)
 	if err != nil {
@@ -151,8 +196,26 @@ func (o *Options) Run(ctx context.Context) error {
 	versionInfo.ClientVersion = &clientVersion
 
 	if !o.ClientOnly {
-		serverVersion, serverErr = o.VersionChecker.Version(ctx)
-		versionInfo.ServerVersion = serverVersion
+		if _, err := o.KubeClient.Discovery().ServerVersion(); err != nil {
+			fmt.Fprintf(o.ErrOut, "note: unable to reach the cluster, only the client version is printed: %v\n", err)
+		} else {
+			serverVersion, serverErr = o.VersionChecker.Version(ctx)
+			versionInfo.ServerVersion = serverVersion
+
+			components, err := o.componentVersions(ctx)
+			if err != nil {
+				fmt.Fprintf(o.ErrOut, "warning: unable to list the cert-manager Deployments in namespace %q: %v\n", o.CertManagerNamespace, err)
+			}
+			versionInfo.Components = components
+		}
+	}
+
+	if o.CheckLatest {
+		latestVersion, err := o.latestRelease(ctx)
+		if err != nil {
+			fmt.Fprintf(o.ErrOut, "warning: unable to look up the latest cert-manager release: %v\n", err)
+		}
+		versionInfo.LatestVersion = latestVersion
 	}
 
 	switch o.Output {
@@ -167,6 +230,10 @@ func (o *Options) Run(ctx context.Context) error {
 			if serverVersion != nil {
 				fmt.Fprintf(o.Out, "Server Version: %s\n", fmt.Sprintf("%#v", serverVersion))
 			}
+			writeComponentVersions(o.Out, versionInfo.Components)
+		}
+		if len(versionInfo.LatestVersion) > 0 {
+			fmt.Fprintf(o.Out, "Latest Version: %s\n", versionInfo.LatestVersion)
 		}
 	case "yaml":
 		marshalled, err := yaml.Marshal(&versionInfo)
@@ -186,5 +253,17 @@ func (o *Options) Run(ctx context.Context) error {
 		return fmt.Errorf("VersionOptions were not validated: --output=%q should have been rejected", o.Output)
 	}
 
+	// The hints are printed to stderr, so that they don't break the
+	// structured output formats
+	if o.CheckLatest {
+		var detected string
+		if serverVersion != nil {
+			detected = serverVersion.Detected
+		}
+		for _, hint := range upgradeHints(clientVersion.GitVersion, detected, versionInfo.LatestVersion) {
+			fmt.Fprintf(o.ErrOut, "hint: %s\n", hint)
+		}
+	}
+
 	return serverErr
 }
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package wait implements waiting for a single Kubernetes resource to reach a
+// desired state, shared by all commands which block until that happens.
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// retryInterval is the time waited before re-establishing a watch which was
+// closed by the server.
+var retryInterval = time.Second
+
+// Condition returns true once the observed object has reached the desired
+// state. Returning an error stops waiting.
+type Condition func(obj runtime.Object) (bool, error)
+
+// Object describes the single object to wait for.
+type Object struct {
+	// Name is the name of the object. Watch events for other objects are
+	// ignored.
+	Name string
+
+	// Description completes the timeout error message "timed out waiting for
+	// <Description>", e.g. "Certificate my-ns/my-crt to become Ready".
+	Description string
+
+	// Get returns the current state of the object.
+	Get func(ctx context.Context) (runtime.Object, error)
+
+	// Watch watches the objects matching opts.
+	Watch func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+
+	// Describe optionally describes why the last observed state of the object
+	// does not satisfy the condition. It is appended to the timeout error.
+	Describe func(obj runtime.Object) string
+}
+
+// For blocks until condition is satisfied by the object, or timeout is
+// reached, returning the object in the state that satisfied the condition.
+// The object is fetched once and then watched from the observed resource
+// version, re-establishing the watch if it is closed and re-fetching the
+// object if the resource version has expired. If the timeout is reached, the
+// returned error includes the last observed state of the object.
+func For(ctx context.Context, timeout time.Duration, obj Object, condition Condition) (runtime.Object, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last runtime.Object
+	var resourceVersion string
+	refetch := true
+	for {
+		if refetch {
+			current, err := obj.Get(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, obj.timeoutError(last)
+				}
+				return nil, err
+			}
+			done, err := condition(current)
+			if err != nil {
+				return nil, err
+			}
+			if done {
+				return current, nil
+			}
+			last = current
+			resourceVersion, err = getResourceVersion(current)
+			if err != nil {
+				return nil, err
+			}
+			refetch = false
+		}
+
+		w, err := obj.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", obj.Name).String(),
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, obj.timeoutError(last)
+			}
+			return nil, fmt.Errorf("error when watching %s: %w", obj.Name, err)
+		}
+
+		satisfied, expired, err := obj.watch(ctx, w, condition, &last, &resourceVersion)
+		w.Stop()
+		if err != nil {
+			return nil, err
+		}
+		if satisfied {
+			return last, nil
+		}
+		refetch = expired
+
+		// The watch has been closed, wait before re-establishing it so a
+		// misbehaving server doesn't cause a busy loop.
+		select {
+		case <-ctx.Done():
+			return nil, obj.timeoutError(last)
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// watch consumes the events of w until the condition is satisfied or the
+// watch is closed, returning whether the condition was satisfied and whether
+// the resource version has expired, so that the object has to be fetched
+// again. last and resourceVersion are updated with every observed state of
+// the object.
+func (o Object) watch(ctx context.Context, w watch.Interface, condition Condition, last *runtime.Object, resourceVersion *string) (bool, bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, false, o.timeoutError(*last)
+
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return false, false, nil
+			}
+
+			switch event.Type {
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return false, true, nil
+				}
+				return false, false, fmt.Errorf("error when watching %s: %w", o.Name, err)
+
+			case watch.Deleted:
+				if isObject(event.Object, o.Name) {
+					return false, false, fmt.Errorf("%s was deleted while waiting for it", o.Name)
+				}
+
+			case watch.Added, watch.Modified:
+				if !isObject(event.Object, o.Name) {
+					continue
+				}
+				*last = event.Object
+				rv, err := getResourceVersion(event.Object)
+				if err != nil {
+					return false, false, err
+				}
+				*resourceVersion = rv
+				if done, err := condition(event.Object); err != nil || done {
+					return done, false, err
+				}
+			}
+		}
+	}
+}
+
+func (o Object) timeoutError(last runtime.Object) error {
+	if last == nil || o.Describe == nil {
+		return fmt.Errorf("timed out waiting for %s", o.Description)
+	}
+	return fmt.Errorf("timed out waiting for %s: %s", o.Description, o.Describe(last))
+}
+
+// isObject returns true if obj has the given name. Field selectors are not
+// honoured by every client, so the name of watched objects is checked again.
+func isObject(obj runtime.Object, name string) bool {
+	accessor, err := meta.Accessor(obj)
+	return err == nil && accessor.GetName() == name
+}
+
+func getResourceVersion(obj runtime.Object) (string, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	return accessor.GetResourceVersion(), nil
+}
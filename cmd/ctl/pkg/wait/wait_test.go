@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func configMap(name, resourceVersion, state string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", ResourceVersion: resourceVersion},
+		Data:       map[string]string{"state": state},
+	}
+}
+
+func TestFor(t *testing.T) {
+	realRetryInterval := retryInterval
+	retryInterval = time.Millisecond
+	defer func() { retryInterval = realRetryInterval }()
+
+	gone := &metav1.Status{Status: metav1.StatusFailure, Code: http.StatusGone, Reason: metav1.StatusReasonExpired, Message: "too old resource version"}
+
+	tests := map[string]struct {
+		// gets are returned by consecutive calls to Get
+		gets []runtime.Object
+		// watches are the events sent by consecutive calls to Watch. Every
+		// watch except the last one is closed after sending its events.
+		watches [][]watch.Event
+
+		expErrMsg          string
+		expState           string
+		expGets            int
+		expResourceVersion []string
+	}{
+		"condition satisfied by the current state returns without watching": {
+			gets:     []runtime.Object{configMap("test", "1", "ready")},
+			expState: "ready",
+			expGets:  1,
+		},
+		"condition satisfied by a watch event returns the object": {
+			gets: []runtime.Object{configMap("test", "1", "pending")},
+			watches: [][]watch.Event{{
+				{Type: watch.Modified, Object: configMap("other", "2", "ready")},
+				{Type: watch.Modified, Object: configMap("test", "3", "issuing")},
+				{Type: watch.Modified, Object: configMap("test", "4", "ready")},
+			}},
+			expState:           "ready",
+			expGets:            1,
+			expResourceVersion: []string{"1"},
+		},
+		"timeout returns an error with the last observed state": {
+			gets: []runtime.Object{configMap("test", "1", "pending")},
+			watches: [][]watch.Event{{
+				{Type: watch.Modified, Object: configMap("test", "2", "issuing")},
+			}},
+			expErrMsg:          "timed out waiting for ConfigMap test-ns/test to become ready: state: issuing",
+			expGets:            1,
+			expResourceVersion: []string{"1"},
+		},
+		"closed watch is re-established from the last observed resource version": {
+			gets: []runtime.Object{configMap("test", "1", "pending")},
+			watches: [][]watch.Event{
+				{{Type: watch.Modified, Object: configMap("test", "2", "issuing")}},
+				{{Type: watch.Modified, Object: configMap("test", "3", "ready")}},
+			},
+			expState:           "ready",
+			expGets:            1,
+			expResourceVersion: []string{"1", "2"},
+		},
+		"expired resource version fetches the object again": {
+			gets: []runtime.Object{configMap("test", "1", "pending"), configMap("test", "5", "issuing")},
+			watches: [][]watch.Event{
+				{{Type: watch.Error, Object: gone}},
+				{{Type: watch.Modified, Object: configMap("test", "6", "ready")}},
+			},
+			expState:           "ready",
+			expGets:            2,
+			expResourceVersion: []string{"1", "5"},
+		},
+		"deleted object returns an error": {
+			gets: []runtime.Object{configMap("test", "1", "pending")},
+			watches: [][]watch.Event{{
+				{Type: watch.Deleted, Object: configMap("test", "2", "pending")},
+			}},
+			expErrMsg:          "test was deleted while waiting for it",
+			expGets:            1,
+			expResourceVersion: []string{"1"},
+		},
+		"error when getting the object is returned": {
+			expErrMsg: "error when getting ConfigMap: not found",
+			expGets:   1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gets int
+			var resourceVersions []string
+			obj := Object{
+				Name:        "test",
+				Description: "ConfigMap test-ns/test to become ready",
+				Get: func(ctx context.Context) (runtime.Object, error) {
+					gets++
+					if gets > len(test.gets) {
+						return nil, errors.New("error when getting ConfigMap: not found")
+					}
+					return test.gets[gets-1], nil
+				},
+				Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+					i := len(resourceVersions)
+					resourceVersions = append(resourceVersions, opts.ResourceVersion)
+					if i >= len(test.watches) {
+						return nil, errors.New("unexpected watch")
+					}
+					w := watch.NewFakeWithChanSize(len(test.watches[i]), false)
+					for _, event := range test.watches[i] {
+						w.Action(event.Type, event.Object)
+					}
+					if i < len(test.watches)-1 {
+						w.Stop()
+					}
+					return w, nil
+				},
+				Describe: func(obj runtime.Object) string {
+					return "state: " + obj.(*corev1.ConfigMap).Data["state"]
+				},
+			}
+
+			result, err := For(context.Background(), 50*time.Millisecond, obj, func(obj runtime.Object) (bool, error) {
+				return obj.(*corev1.ConfigMap).Data["state"] == "ready", nil
+			})
+			assert.Equal(t, test.expGets, gets)
+			assert.Equal(t, test.expResourceVersion, resourceVersions)
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				assert.Nil(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expState, result.(*corev1.ConfigMap).Data["state"])
+		})
+	}
+}
@@ -64,6 +64,7 @@ update-licenses:
 
 .PHONY: update-crds
 update-crds: generate-test-crds patch-crds
+	cp ./deploy/crds/crd-*.yaml ./cmd/ctl/pkg/explain/crds/
 
 .PHONY: generate-test-crds
 generate-test-crds: | $(NEEDS_CONTROLLER-GEN)
@@ -18,6 +18,7 @@ package ctl
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"testing"
 
@@ -27,26 +28,62 @@ import (
 )
 
 const (
-	testdataResource1                        = "./testdata/convert/input/resource1.yaml"
-	testdataResource2                        = "./testdata/convert/input/resource2.yaml"
-	testdataResource3                        = "./testdata/convert/input/resource3.yaml"
-	testdataResourceWithOrganizationV1alpha2 = "./testdata/convert/input/resource_with_organization_v1alpha2.yaml"
-	testdataResourcesAsListV1alpha2          = "./testdata/convert/input/resources_as_list_v1alpha2.yaml"
-
-	testdataNoOutputError                    = "./testdata/convert/output/no_output_error.yaml"
-	testdataResource1V1                      = "./testdata/convert/output/resource1_v1.yaml"
-	testdataResource1V1alpha2                = "./testdata/convert/output/resource1_v1alpha2.yaml"
-	testdataResource1V1alpha3                = "./testdata/convert/output/resource1_v1alpha3.yaml"
-	testdataResource2V1                      = "./testdata/convert/output/resource2_v1.yaml"
-	testdataResource2V1alpha2                = "./testdata/convert/output/resource2_v1alpha2.yaml"
-	testdataResource2V1alpha3                = "./testdata/convert/output/resource2_v1alpha3.yaml"
-	testdataResourceWithOrganizationV1alpha3 = "./testdata/convert/output/resource_with_organization_v1alpha3.yaml"
-	testdataResourceWithOrganizationV1beta1  = "./testdata/convert/output/resource_with_organization_v1beta1.yaml"
-	testdataResourceWithOrganizationV1       = "./testdata/convert/output/resource_with_organization_v1.yaml"
-	testdataResourcesOutAsListV1alpha2       = "./testdata/convert/output/resources_as_list_v1alpha2.yaml"
-	testdataResourcesOutAsListV1alpha3       = "./testdata/convert/output/resources_as_list_v1alpha3.yaml"
-	testdataResourcesOutAsListV1beta1        = "./testdata/convert/output/resources_as_list_v1beta1.yaml"
-	testdataResourcesOutAsListV1             = "./testdata/convert/output/resources_as_list_v1.yaml"
+	testdataResource1                         = "./testdata/convert/input/resource1.yaml"
+	testdataResource2                         = "./testdata/convert/input/resource2.yaml"
+	testdataResource3                         = "./testdata/convert/input/resource3.yaml"
+	testdataResourceWithOrganizationV1alpha2  = "./testdata/convert/input/resource_with_organization_v1alpha2.yaml"
+	testdataResourcesAsListV1alpha2           = "./testdata/convert/input/resources_as_list_v1alpha2.yaml"
+	testdataResourcesWithoutTypeV1alpha2      = "./testdata/convert/input/resources_without_type_v1alpha2.yaml"
+	testdataResourceWithoutTypeV1alpha2       = "./testdata/convert/input/resource_without_type_v1alpha2.yaml"
+	testdataResourcesWithoutNamespaceV1alpha2 = "./testdata/convert/input/resources_without_namespace_v1alpha2.yaml"
+	testdataResourcesWithNumericDurations     = "./testdata/convert/input/resources_with_numeric_durations_v1alpha2.yaml"
+	testdataResourcesWithExternalIssuer       = "./testdata/convert/input/resources_with_external_issuer_v1alpha2.yaml"
+	testdataResourcesWithBOMCRLF              = "./testdata/convert/input/resources_with_bom_crlf_v1alpha2.yaml"
+	testdataResourceWithPrivateKeyV1alpha2    = "./testdata/convert/input/resource_with_private_key_v1alpha2.yaml"
+	testdataResourceWithRSAPrivateKeyV1       = "./testdata/convert/input/resource_with_rsa_private_key_v1.yaml"
+	testdataResourcesAsJSONArrayV1alpha2      = "./testdata/convert/input/resources_as_json_array_v1alpha2.json"
+	testdataIssuersWithLegacyHTTP01V1alpha2   = "./testdata/convert/input/issuers_with_legacy_http01_v1alpha2.yaml"
+	testdataIssuersWithSecretRefsV1alpha2     = "./testdata/convert/input/issuers_with_secret_references_v1alpha2.yaml"
+	testdataResourcesMixedJSONYAMLV1alpha2    = "./testdata/convert/input/resources_mixed_json_yaml_v1alpha2.yaml"
+
+	testdataNoOutputError                       = "./testdata/convert/output/no_output_error.yaml"
+	testdataResource1V1                         = "./testdata/convert/output/resource1_v1.yaml"
+	testdataResource1V1alpha2                   = "./testdata/convert/output/resource1_v1alpha2.yaml"
+	testdataResource1V1alpha3                   = "./testdata/convert/output/resource1_v1alpha3.yaml"
+	testdataResource2V1                         = "./testdata/convert/output/resource2_v1.yaml"
+	testdataResource2V1alpha2                   = "./testdata/convert/output/resource2_v1alpha2.yaml"
+	testdataResource2V1alpha3                   = "./testdata/convert/output/resource2_v1alpha3.yaml"
+	testdataResource2V1WithIssuerV1beta1        = "./testdata/convert/output/resource2_v1_with_issuer_v1beta1.yaml"
+	testdataResourceWithOrganizationV1alpha3    = "./testdata/convert/output/resource_with_organization_v1alpha3.yaml"
+	testdataResourceWithOrganizationV1beta1     = "./testdata/convert/output/resource_with_organization_v1beta1.yaml"
+	testdataResourceWithOrganizationV1          = "./testdata/convert/output/resource_with_organization_v1.yaml"
+	testdataResourcesOutAsListV1alpha2          = "./testdata/convert/output/resources_as_list_v1alpha2.yaml"
+	testdataResourcesOutAsListV1alpha3          = "./testdata/convert/output/resources_as_list_v1alpha3.yaml"
+	testdataResourcesOutAsListV1beta1           = "./testdata/convert/output/resources_as_list_v1beta1.yaml"
+	testdataResourcesOutAsListV1                = "./testdata/convert/output/resources_as_list_v1.yaml"
+	testdataResourcesOutWithoutTypeV1           = "./testdata/convert/output/resources_without_type_v1.yaml"
+	testdataResourceWithoutTypeV1               = "./testdata/convert/output/resource_without_type_v1.yaml"
+	testdataResourcesOutWithInputNamespaceV1    = "./testdata/convert/output/resources_with_input_namespace_v1.yaml"
+	testdataResource1WithInputNamespaceV1       = "./testdata/convert/output/resource1_with_input_namespace_v1.yaml"
+	testdataResourcesWithNumericDurationsV1     = "./testdata/convert/output/resources_with_numeric_durations_v1.yaml"
+	testdataResourcesWithExternalIssuerV1       = "./testdata/convert/output/resources_with_external_issuer_v1.yaml"
+	testdataResourcesWithBOMCRLFV1              = "./testdata/convert/output/resources_with_bom_crlf_v1.yaml"
+	testdataResourcesWithExternalIssuerV1alpha3 = "./testdata/convert/output/resources_with_external_issuer_v1alpha3.yaml"
+	testdataResourceWithPrivateKeyV1alpha3      = "./testdata/convert/output/resource_with_private_key_v1alpha3.yaml"
+	testdataResourceWithPrivateKeyV1beta1       = "./testdata/convert/output/resource_with_private_key_v1beta1.yaml"
+	testdataResourceWithPrivateKeyV1            = "./testdata/convert/output/resource_with_private_key_v1.yaml"
+	testdataResourceWithRSAPrivateKeyV1alpha2   = "./testdata/convert/output/resource_with_rsa_private_key_v1alpha2.yaml"
+	testdataResourceWithRSAPrivateKeyV1alpha3   = "./testdata/convert/output/resource_with_rsa_private_key_v1alpha3.yaml"
+	testdataResourceWithRSAPrivateKeyV1beta1    = "./testdata/convert/output/resource_with_rsa_private_key_v1beta1.yaml"
+	testdataResourcesOutAsJSONArrayV1           = "./testdata/convert/output/resources_as_json_array_v1.json"
+	testdataResourcesOutAsJSONArrayV1YAML       = "./testdata/convert/output/resources_as_json_array_v1.yaml"
+	testdataResourcesOutListAsJSONArrayV1       = "./testdata/convert/output/resources_as_list_json_array_v1.json"
+	testdataIssuersWithLegacyHTTP01V1           = "./testdata/convert/output/issuers_with_legacy_http01_v1.yaml"
+	testdataIssuersWithSecretRefsV1             = "./testdata/convert/output/issuers_with_secret_references_v1.yaml"
+	testdataResourcesMixedJSONYAMLV1            = "./testdata/convert/output/resources_mixed_json_yaml_v1.yaml"
+	testdataResource3V1                         = "./testdata/convert/output/resource3_v1.yaml"
+	testdataResource3V1alpha2                   = "./testdata/convert/output/resource3_v1alpha2.yaml"
+	testdataResource3IssuerV1                   = "./testdata/convert/output/resource3_issuer_v1.yaml"
 
 	targetv1alpha2 = "cert-manager.io/v1alpha2"
 	targetv1alpha3 = "cert-manager.io/v1alpha3"
@@ -58,12 +95,47 @@ func TestCtlConvert(t *testing.T) {
 	tests := map[string]struct {
 		input, expOutputFile string
 		targetVersion        string
+		targetVersionPerKind map[string]string
+		defaultAPIVersion    string
+		defaultKind          string
+		inputNamespace       string
+		force                bool
+		stdin                bool
+		stdinJSONArray       bool
+		jsonArray            bool
+		skipNonCertManager   bool
+		noFailFast           bool
+		allOrNothing         bool
+		outputFormat         string
 		expErr               bool
 	}{
+		"a JSON array of cert-manager resources read from stdin should convert to a JSON array": {
+			input:          testdataResourcesAsJSONArrayV1alpha2,
+			stdinJSONArray: true,
+			outputFormat:   "json",
+			expOutputFile:  testdataResourcesOutAsJSONArrayV1,
+		},
+		"a JSON array of cert-manager resources read from stdin should convert to YAML documents": {
+			input:          testdataResourcesAsJSONArrayV1alpha2,
+			stdinJSONArray: true,
+			expOutputFile:  testdataResourcesOutAsJSONArrayV1YAML,
+		},
+		"a List of cert-manager resources should convert to a JSON array of its items with --json-array": {
+			input:         testdataResourcesAsListV1alpha2,
+			targetVersion: targetv1,
+			jsonArray:     true,
+			outputFormat:  "json",
+			expOutputFile: testdataResourcesOutListAsJSONArrayV1,
+		},
 		"a single cert-manager resource should convert to v1 with no target": {
 			input:         testdataResource1,
 			expOutputFile: testdataResource1V1,
 		},
+		"a single cert-manager resource read from stdin should convert to a single object as read from a file": {
+			input:         testdataResource1,
+			stdin:         true,
+			expOutputFile: testdataResource1V1,
+		},
 		"a single cert-manager resource should convert to v1alpha2 with target v1alpha2": {
 			input:         testdataResource1,
 			targetVersion: targetv1alpha2,
@@ -105,6 +177,30 @@ func TestCtlConvert(t *testing.T) {
 			expOutputFile: testdataNoOutputError,
 			expErr:        true,
 		},
+		"a list of a mix of cert-manager and non cert-manager resources should pass the non cert-manager resources through with no target": {
+			input:              testdataResource3,
+			skipNonCertManager: true,
+			expOutputFile:      testdataResource3V1,
+		},
+		"a list of a mix of cert-manager and non cert-manager resources should pass the non cert-manager resources through with target v1alpha2": {
+			input:              testdataResource3,
+			targetVersion:      targetv1alpha2,
+			skipNonCertManager: true,
+			expOutputFile:      testdataResource3V1alpha2,
+		},
+		"a list of a mix of cert-manager and non cert-manager resources should output the cert-manager resources and error without fail-fast": {
+			input:         testdataResource3,
+			noFailFast:    true,
+			expOutputFile: testdataResource3IssuerV1,
+			expErr:        true,
+		},
+		"a list of a mix of cert-manager and non cert-manager resources should error without output with all-or-nothing": {
+			input:         testdataResource3,
+			noFailFast:    true,
+			allOrNothing:  true,
+			expOutputFile: testdataNoOutputError,
+			expErr:        true,
+		},
 		"an object in v1alpha2 that uses a field that has been renamed in v1alpha3 should be converted properly": {
 			input:         testdataResourceWithOrganizationV1alpha2,
 			targetVersion: targetv1alpha3,
@@ -140,6 +236,136 @@ func TestCtlConvert(t *testing.T) {
 			targetVersion: targetv1,
 			expOutputFile: testdataResourcesOutAsListV1,
 		},
+		"objects without apiVersion and kind should be converted using the defaults": {
+			input:             testdataResourcesWithoutTypeV1alpha2,
+			targetVersion:     targetv1,
+			defaultAPIVersion: targetv1alpha2,
+			defaultKind:       "Certificate",
+			expOutputFile:     testdataResourcesOutWithoutTypeV1,
+		},
+		"a single object without apiVersion and kind should convert to a single object using the defaults": {
+			input:             testdataResourceWithoutTypeV1alpha2,
+			targetVersion:     targetv1,
+			defaultAPIVersion: targetv1alpha2,
+			defaultKind:       "Certificate",
+			expOutputFile:     testdataResourceWithoutTypeV1,
+		},
+		"a single object without apiVersion and kind read from stdin should convert to a single object using the defaults": {
+			input:             testdataResourceWithoutTypeV1alpha2,
+			stdin:             true,
+			targetVersion:     targetv1,
+			defaultAPIVersion: targetv1alpha2,
+			defaultKind:       "Certificate",
+			expOutputFile:     testdataResourceWithoutTypeV1,
+		},
+		"objects without apiVersion and kind should error without defaults": {
+			input:         testdataResourcesWithoutTypeV1alpha2,
+			targetVersion: targetv1,
+			expOutputFile: testdataNoOutputError,
+			expErr:        true,
+		},
+		"namespaced resources should be moved to the input namespace": {
+			input:          testdataResourcesWithoutNamespaceV1alpha2,
+			inputNamespace: "target",
+			expOutputFile:  testdataResourcesOutWithInputNamespaceV1,
+		},
+		"a resource defining a conflicting namespace should error with an input namespace": {
+			input:          testdataResource1,
+			inputNamespace: "target",
+			expOutputFile:  testdataNoOutputError,
+			expErr:         true,
+		},
+		"a resource defining a conflicting namespace should be moved to the input namespace with force": {
+			input:          testdataResource1,
+			inputNamespace: "target",
+			force:          true,
+			expOutputFile:  testdataResource1WithInputNamespaceV1,
+		},
+		"durations given as numbers should be converted to duration strings": {
+			input:         testdataResourcesWithNumericDurations,
+			targetVersion: targetv1,
+			expOutputFile: testdataResourcesWithNumericDurationsV1,
+		},
+		"Issuers with the legacy HTTP-01 configuration should be migrated to an HTTP-01 solver": {
+			input:         testdataIssuersWithLegacyHTTP01V1alpha2,
+			targetVersion: targetv1,
+			expOutputFile: testdataIssuersWithLegacyHTTP01V1,
+		},
+		"Issuers referencing Secrets should keep their references when converted": {
+			input:         testdataIssuersWithSecretRefsV1alpha2,
+			targetVersion: targetv1,
+			expOutputFile: testdataIssuersWithSecretRefsV1,
+		},
+		"a list of cert-manager resources should convert Issuers to the version given for their kind": {
+			input:                testdataResource2,
+			targetVersion:        targetv1,
+			targetVersionPerKind: map[string]string{"Issuer": targetv1beta1},
+			expOutputFile:        testdataResource2V1WithIssuerV1beta1,
+		},
+		"issuerRef groups of external issuers should be left intact when converting to v1": {
+			input:         testdataResourcesWithExternalIssuer,
+			targetVersion: targetv1,
+			expOutputFile: testdataResourcesWithExternalIssuerV1,
+		},
+		"issuerRef groups of external issuers should be left intact when converting to v1alpha3": {
+			input:         testdataResourcesWithExternalIssuer,
+			targetVersion: targetv1alpha3,
+			expOutputFile: testdataResourcesWithExternalIssuerV1alpha3,
+		},
+		"a file with a byte order mark and CRLF line endings should be converted": {
+			input:         testdataResourcesWithBOMCRLF,
+			targetVersion: targetv1,
+			expOutputFile: testdataResourcesWithBOMCRLFV1,
+		},
+		"a file with a byte order mark and CRLF line endings should be converted using the defaults": {
+			input:             testdataResourcesWithBOMCRLF,
+			targetVersion:     targetv1,
+			defaultAPIVersion: targetv1alpha2,
+			defaultKind:       "Certificate",
+			expOutputFile:     testdataResourcesWithBOMCRLFV1,
+		},
+		"a file mixing JSON objects and YAML documents should be converted": {
+			input:         testdataResourcesMixedJSONYAMLV1alpha2,
+			targetVersion: targetv1,
+			expOutputFile: testdataResourcesMixedJSONYAMLV1,
+		},
+		"a file mixing JSON objects and YAML documents should be converted using the defaults": {
+			input:             testdataResourcesMixedJSONYAMLV1alpha2,
+			targetVersion:     targetv1,
+			defaultAPIVersion: targetv1alpha2,
+			defaultKind:       "Certificate",
+			expOutputFile:     testdataResourcesMixedJSONYAMLV1,
+		},
+		"private key options in v1alpha2 should be converted to v1alpha3": {
+			input:         testdataResourceWithPrivateKeyV1alpha2,
+			targetVersion: targetv1alpha3,
+			expOutputFile: testdataResourceWithPrivateKeyV1alpha3,
+		},
+		"private key options in v1alpha2 should be converted to the privateKey block in v1beta1": {
+			input:         testdataResourceWithPrivateKeyV1alpha2,
+			targetVersion: targetv1beta1,
+			expOutputFile: testdataResourceWithPrivateKeyV1beta1,
+		},
+		"private key options in v1alpha2 should be converted to the privateKey block in v1": {
+			input:         testdataResourceWithPrivateKeyV1alpha2,
+			targetVersion: targetv1,
+			expOutputFile: testdataResourceWithPrivateKeyV1,
+		},
+		"the privateKey block in v1 should be converted to the private key options in v1alpha2": {
+			input:         testdataResourceWithRSAPrivateKeyV1,
+			targetVersion: targetv1alpha2,
+			expOutputFile: testdataResourceWithRSAPrivateKeyV1alpha2,
+		},
+		"the privateKey block in v1 should be converted to the private key options in v1alpha3": {
+			input:         testdataResourceWithRSAPrivateKeyV1,
+			targetVersion: targetv1alpha3,
+			expOutputFile: testdataResourceWithRSAPrivateKeyV1alpha3,
+		},
+		"the privateKey block in v1 should be converted to v1beta1": {
+			input:         testdataResourceWithRSAPrivateKeyV1,
+			targetVersion: targetv1beta1,
+			expOutputFile: testdataResourceWithRSAPrivateKeyV1beta1,
+		},
 	}
 
 	for name, test := range tests {
@@ -150,17 +376,42 @@ func TestCtlConvert(t *testing.T) {
 			}
 
 			// Run ctl convert command with input options
-			streams, _, outBuf, _ := genericclioptions.NewTestIOStreams()
+			streams, inBuf, outBuf, _ := genericclioptions.NewTestIOStreams()
 
 			opts := convert.NewOptions(streams)
 			opts.OutputVersion = test.targetVersion
-			opts.Filenames = []string{test.input}
+			opts.OutputVersionPerKind = test.targetVersionPerKind
+			opts.DefaultAPIVersion = test.defaultAPIVersion
+			opts.DefaultKind = test.defaultKind
+			opts.InputNamespace = test.inputNamespace
+			opts.Force = test.force
+			opts.SkipNonCertManager = test.skipNonCertManager
+			opts.FailFast = !test.noFailFast
+			opts.AllOrNothing = test.allOrNothing
+			opts.JSONArray = test.jsonArray
+			switch {
+			case test.stdin || test.stdinJSONArray:
+				input, err := os.ReadFile(test.input)
+				if err != nil {
+					t.Fatalf("%s: %s", test.input, err)
+				}
+				inBuf.Write(input)
+				opts.StdinJSONArray = test.stdinJSONArray
+				if test.stdin {
+					opts.Filenames = []string{"-"}
+				}
+			default:
+				opts.Filenames = []string{test.input}
+			}
+			if len(test.outputFormat) > 0 {
+				opts.PrintFlags.OutputFormat = &test.outputFormat
+			}
 
 			if err := opts.Complete(); err != nil {
 				t.Fatal(err)
 			}
 
-			err = opts.Run()
+			err = opts.Run(context.TODO())
 			if test.expErr != (err != nil) {
 				t.Errorf("got unexpected error, exp=%t got=%v",
 					test.expErr, err)
@@ -101,7 +101,9 @@ func TestCtlRenew(t *testing.T) {
 		inputNamespace     string
 		inputAll           bool
 		inputAllNamespaces bool
+		inputLabelRenewed  []string
 
+		expErr          string
 		crtsWithIssuing map[*cmapi.Certificate]bool
 	}{
 		"certificate name and namespace given": {
@@ -114,6 +116,17 @@ func TestCtlRenew(t *testing.T) {
 				crt4: false,
 			},
 		},
+		"missing certificate name given": {
+			inputArgs:      []string{crt1Name, "missing-crt"},
+			inputNamespace: ns1,
+			expErr:         `Certificate(s) testns-1/missing-crt not found, no Certificates were renewed`,
+			crtsWithIssuing: map[*cmapi.Certificate]bool{
+				crt1: false,
+				crt2: false,
+				crt3: false,
+				crt4: false,
+			},
+		},
 		"--all and namespace given": {
 			inputAll:       true,
 			inputNamespace: ns2,
@@ -147,6 +160,18 @@ func TestCtlRenew(t *testing.T) {
 				crt4: false,
 			},
 		},
+		"--all, namespace and --label-renewed given": {
+			inputAll:          true,
+			inputNamespace:    ns1,
+			inputLabelRenewed: []string{"rotation=campaign-1"},
+
+			crtsWithIssuing: map[*cmapi.Certificate]bool{
+				crt1: true,
+				crt2: true,
+				crt3: false,
+				crt4: false,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -166,6 +191,7 @@ func TestCtlRenew(t *testing.T) {
 				LabelSelector: test.inputLabels,
 				All:           test.inputAll,
 				AllNamespaces: test.inputAllNamespaces,
+				LabelRenewed:  test.inputLabelRenewed,
 				Factory: &factory.Factory{
 					CMClient:   cmCl,
 					RESTConfig: config,
@@ -174,8 +200,12 @@ func TestCtlRenew(t *testing.T) {
 				IOStreams: streams,
 			}
 
-			if err := cmd.Run(ctx, test.inputArgs); err != nil {
+			err := cmd.Run(ctx, test.inputArgs)
+			switch {
+			case test.expErr == "" && err != nil:
 				t.Fatal(err)
+			case test.expErr != "" && (err == nil || err.Error() != test.expErr):
+				t.Fatalf("expected error %q, got %v", test.expErr, err)
 			}
 
 			// Check issuing condition against Certificates
@@ -193,6 +223,13 @@ func TestCtlRenew(t *testing.T) {
 				if shouldIssue != hasCondition {
 					t.Errorf("%s/%s expected to have issuing condition=%t got=%t", crt.Namespace, crt.Name, shouldIssue, hasCondition)
 				}
+
+				if len(test.inputLabelRenewed) > 0 {
+					_, hasLabel := gotCrt.Labels["rotation"]
+					if shouldIssue != hasLabel {
+						t.Errorf("%s/%s expected to have renewed label=%t got=%t", crt.Namespace, crt.Name, shouldIssue, hasLabel)
+					}
+				}
 			}
 
 			// Clean up Certificates
@@ -167,17 +167,25 @@ MA6koCR/K23HZfML8vT6lcHvQJp9XXaHRIe9NX/M/2f6VpfO7JjKWLou5k5a
 			expOutput: `^Name: testcrt-1
 Namespace: testns-1
 Created at: .*
+Labels: <none>
+Annotations: <none>
+Spec:
+  Secret Name: example-tls
+  Issuer Ref: ClusterIssuer/letsencrypt-prod \(cert-manager.io\)
+  DNS Names: www.example.com
+  Duration: <none>
+  Renew Before: <none>
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
-DNS Names:
-- www.example.com
 Events:
-  Type  Reason  Age        From  Message
-  ----  ------  ----       ----  -------
-  type  reason  <unknown>        message
+  Type  Reason  Age        Count  From  Message
+  ----  ------  ----       -----  ----  -------
+  type  reason  <unknown>  1            message
 Issuer:
   Name: letsencrypt-prod
   Kind: ClusterIssuer
+  Generation: 1, Observed Generation: <none>
+  The controller has not observed the latest generation of the spec yet, the conditions may be outdated
   Conditions:
     No Conditions set
   Events:  <none>
@@ -263,21 +271,29 @@ No CertificateRequest found for this Certificate$`,
 			expOutput: `^Name: testcrt-2
 Namespace: testns-1
 Created at: .*
+Labels: <none>
+Annotations: <none>
+Spec:
+  Secret Name: existing-tls-secret
+  Issuer Ref: Issuer/letsencrypt-prod \(cert-manager.io\)
+  DNS Names: www.example.com
+  Duration: <none>
+  Renew Before: <none>
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
-- www.example.com
 Events:  <none>
 Issuer:
   Name: letsencrypt-prod
   Kind: Issuer
+  Generation: 1, Observed Generation: <none>
+  The controller has not observed the latest generation of the spec yet, the conditions may be outdated
   Conditions:
     No Conditions set
   Events:
-    Type  Reason  Age        From  Message
-    ----  ------  ----       ----  -------
-    type  reason  <unknown>        message
+    Type  Reason  Age        Count  From  Message
+    ----  ------  ----       -----  ----  -------
+    type  reason  <unknown>  1            message
 Secret:
   Name: existing-tls-secret
   Issuer Country: 
@@ -290,12 +306,16 @@ Secret:
   Subject Key ID: 
   Authority Key ID: 
   Serial Number: e2f88edc942c148463219da909fd633a
+  Private Key: <unavailable>
+  Certificate Chain:
+    - test: Not After 2020-10-28T16:11:43Z \(expired .* ago\)
   Events:
-    Type  Reason  Age        From  Message
-    ----  ------  ----       ----  -------
-    type  reason  <unknown>        message
+    Type  Reason  Age        Count  From  Message
+    ----  ------  ----       -----  ----  -------
+    type  reason  <unknown>  1            message
 Not Before: <none>
 Not After: .*
+Chain Expires In: expired .* ago \(test, Not After 2020-10-28T16:11:43Z\)
 Renewal Time: <none>
 CertificateRequest:
   Name: testreq-1
@@ -303,13 +323,21 @@ CertificateRequest:
   Conditions:
     Ready: False, Reason: Pending, Message: Waiting on certificate issuance from order default/example-order: "pending"
   Events:  <none>
-Order:
-  Name: example-order
-  State: , Reason: 
-  No Authorizations for this Order
-Challenges:
-- Name: test-challenge1, Type: HTTP-01, Token: dummy-token1, Key: , State: , Reason: , Processing: false, Presented: false
-- Name: test-challenge2, Type: DNS-01, Token: dummy-token2, Key: , State: , Reason: , Processing: false, Presented: false$`,
+  Order:
+    Name: example-order
+    State: , Reason: 
+    No Authorizations for this Order
+    Challenges:
+    - Name: test-challenge1
+      Type: HTTP-01, Solver: <unknown>
+      State: , Reason: 
+      Processing: false, Presented: false
+      Token: dummy-token1, Key: 
+    - Name: test-challenge2
+      Type: DNS-01, Solver: <unknown>
+      State: , Reason: 
+      Processing: false, Presented: false
+      Token: dummy-token2, Key: $`,
 		},
 		"certificate issued and renewal in progress without Issuer": {
 			certificate: gen.Certificate(crt3Name,
@@ -343,11 +371,17 @@ Challenges:
 			expOutput: `^Name: testcrt-3
 Namespace: testns-1
 Created at: .*
+Labels: <none>
+Annotations: <none>
+Spec:
+  Secret Name: example-tls
+  Issuer Ref: Issuer/non-existing-issuer \(cert-manager.io\)
+  DNS Names: www.example.com
+  Duration: <none>
+  Renew Before: <none>
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
-- www.example.com
 Events:  <none>
 error when getting Issuer: issuers.cert-manager.io "non-existing-issuer" not found
 error when finding Secret "example-tls": secrets "example-tls" not found
@@ -360,9 +394,9 @@ CertificateRequest:
   Conditions:
     Ready: False, Reason: Pending, Message: Waiting on certificate issuance from order default/example-order: "pending"
   Events:
-    Type  Reason  Age        From  Message
-    ----  ------  ----       ----  -------
-    type  reason  <unknown>        message$`,
+    Type  Reason  Age        Count  From  Message
+    ----  ------  ----       -----  ----  -------
+    type  reason  <unknown>  1            message$`,
 		},
 		"certificate issued and renewal in progress without ClusterIssuer": {
 			certificate: gen.Certificate(crt4Name,
@@ -385,11 +419,17 @@ CertificateRequest:
 			expOutput: `^Name: testcrt-4
 Namespace: testns-1
 Created at: .*
+Labels: <none>
+Annotations: <none>
+Spec:
+  Secret Name: example-tls
+  Issuer Ref: ClusterIssuer/non-existing-clusterissuer \(cert-manager.io\)
+  DNS Names: www.example.com
+  Duration: <none>
+  Renew Before: <none>
 Conditions:
   Ready: True, Reason: , Message: Certificate is up to date and has not expired
   Issuing: True, Reason: , Message: Issuance of a new Certificate is in Progress
-DNS Names:
-- www.example.com
 Events:  <none>
 error when getting ClusterIssuer: clusterissuers.cert-manager.io "non-existing-clusterissuer" not found
 error when finding Secret "example-tls": secrets "example-tls" not found